@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func (c *controller) GetAllPlayStandings(ctx context.Context, leagueID int32) ([]model.AllPlayStanding, error) {
+	weeklyResults, lastWeek, err := c.getAllResults(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return calculateAllPlayStandings(weeklyResults, lastWeek), nil
+}
+
+// Add up the actual and all-play records of each team for every week up to and
+// including week. The standings are sorted by expected wins, so the best team by
+// all-play record is first. The all-play records are set from the scores of each
+// week, since weeks synced before they were saved don't have them.
+func calculateAllPlayStandings(weeklyResults map[int][]model.Matchup, week int) []model.AllPlayStanding {
+	standings := make(map[string]*model.AllPlayStanding)
+	for w := 1; w <= week; w++ {
+		model.SetAllPlayRecords(weeklyResults[w])
+		for _, m := range weeklyResults[w] {
+			if m.TeamA == nil || m.TeamB == nil {
+				continue
+			}
+			for _, pair := range [][2]*model.TeamResult{{m.TeamA, m.TeamB}, {m.TeamB, m.TeamA}} {
+				t, opponent := pair[0], pair[1]
+				s, found := standings[t.TeamID]
+				if !found {
+					s = &model.AllPlayStanding{TeamID: t.TeamID, TeamName: t.TeamName}
+					standings[t.TeamID] = s
+				}
+
+				if t.Score > opponent.Score {
+					s.Wins++
+				} else if t.Score < opponent.Score {
+					s.Losses++
+				} else {
+					s.Draws++
+				}
+
+				s.AllPlayWins += t.AllPlayWins
+				s.AllPlayLosses += t.AllPlayLosses
+				s.AllPlayDraws += t.AllPlayDraws
+				if games := t.AllPlayWins + t.AllPlayLosses + t.AllPlayDraws; games > 0 {
+					s.ExpectedWins += (float64(t.AllPlayWins) + 0.5*float64(t.AllPlayDraws)) / float64(games)
+				}
+			}
+		}
+	}
+
+	results := make([]model.AllPlayStanding, 0, len(standings))
+	for _, s := range standings {
+		s.ExpectedWins = math.Round(s.ExpectedWins*100) / 100
+		s.Luck = math.Round((float64(s.Wins)+0.5*float64(s.Draws)-s.ExpectedWins)*100) / 100
+		results = append(results, *s)
+	}
+	slices.SortFunc(results, func(a, b model.AllPlayStanding) int {
+		if a.ExpectedWins == b.ExpectedWins {
+			return cmp.Compare(a.TeamID, b.TeamID)
+		}
+		return cmp.Compare(b.ExpectedWins, a.ExpectedWins)
+	})
+	return results
+}
+
+// Score each team by how many games over .500 they would be based on their
+// all-play record, the same way the record component scores actual wins.
+func calculateAllPlayScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) map[string]int32 {
+	scores := make(map[string]int32)
+	for _, s := range calculateAllPlayStandings(weeklyResults, week) {
+		games := float64(s.Wins + s.Losses + s.Draws)
+		scores[s.TeamID] = int32(math.Round((2*s.ExpectedWins - games) * float64(pr.Config.AllPlayPoints)))
+	}
+	return scores
+}
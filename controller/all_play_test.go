@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestCalculateAllPlayStandings(t *testing.T) {
+	_, weeklyResults := getDataForTest()
+	for _, matchups := range weeklyResults {
+		model.SetAllPlayRecords(matchups)
+	}
+
+	standings := calculateAllPlayStandings(weeklyResults, 5)
+
+	expected := []model.AllPlayStanding{
+		{TeamID: "3", Wins: 4, Losses: 1, AllPlayWins: 10, AllPlayLosses: 3, AllPlayDraws: 2, ExpectedWins: 3.67, Luck: 0.33},
+		{TeamID: "1", Wins: 3, Losses: 2, AllPlayWins: 6, AllPlayLosses: 6, AllPlayDraws: 3, ExpectedWins: 2.5, Luck: 0.5},
+		{TeamID: "4", Wins: 2, Losses: 3, AllPlayWins: 5, AllPlayLosses: 8, AllPlayDraws: 2, ExpectedWins: 2, Luck: 0},
+		{TeamID: "2", Wins: 1, Losses: 4, AllPlayWins: 4, AllPlayLosses: 8, AllPlayDraws: 3, ExpectedWins: 1.83, Luck: -0.83},
+	}
+	if !reflect.DeepEqual(expected, standings) {
+		t.Errorf("expected: %v, got: %v", expected, standings)
+	}
+
+	// Only the weeks up to the one asked for are included. Teams 1 and 4 had the
+	// same high score in week 1.
+	standings = calculateAllPlayStandings(weeklyResults, 1)
+	if standings[0].TeamID != "1" || standings[0].Wins != 1 || standings[0].AllPlayWins != 2 || standings[0].AllPlayDraws != 1 {
+		t.Errorf("unexpected standings for week 1: %v", standings)
+	}
+}
+
+func TestCalculateAllPlayStandings_noSavedAllPlay(t *testing.T) {
+	// Weeks synced before the all-play records were saved have them as 0-0-0, so
+	// they are calculated from the scores instead of only counting the actual wins.
+	_, weeklyResults := getDataForTest()
+	for w, matchups := range weeklyResults {
+		if w != 3 {
+			model.SetAllPlayRecords(matchups)
+		}
+	}
+	for _, m := range weeklyResults[3] {
+		if m.TeamA.AllPlayWins+m.TeamA.AllPlayLosses+m.TeamA.AllPlayDraws != 0 {
+			t.Fatalf("expected week 3 to have no all-play records, got: %v", m.TeamA)
+		}
+	}
+
+	_, expectedResults := getDataForTest()
+	for _, matchups := range expectedResults {
+		model.SetAllPlayRecords(matchups)
+	}
+	expected := calculateAllPlayStandings(expectedResults, 5)
+
+	standings := calculateAllPlayStandings(weeklyResults, 5)
+	if !reflect.DeepEqual(expected, standings) {
+		t.Errorf("expected: %v, got: %v", expected, standings)
+	}
+	for _, s := range standings {
+		if s.AllPlayWins+s.AllPlayLosses+s.AllPlayDraws != 15 {
+			t.Errorf("expected 15 all-play games for team %s, got: %v", s.TeamID, s)
+		}
+	}
+}
+
+func TestAllPlayComponent(t *testing.T) {
+	pr, weeklyResults := getDataForTest()
+	for _, matchups := range weeklyResults {
+		model.SetAllPlayRecords(matchups)
+	}
+	in := &powerRankingInput{pr: pr, weeklyResults: weeklyResults, week: 5}
+
+	// The all-play component isn't used by default
+	calculateComponentScores(in, powerRankingComponents)
+	if _, found := pr.Teams[0].Scores[componentAllPlay]; found {
+		t.Errorf("expected the all-play component to be disabled, got: %v", pr.Components)
+	}
+
+	pr.Config.AllPlayPoints = 10
+	calculateComponentScores(in, powerRankingComponents)
+	if pr.Components[len(pr.Components)-1] != componentAllPlay {
+		t.Fatalf("expected the all-play component to be enabled, got: %v", pr.Components)
+	}
+	expected := map[string]int32{"1": 0, "2": -13, "3": 23, "4": -10}
+	for _, team := range pr.Teams {
+		if team.Scores[componentAllPlay] != expected[team.TeamID] {
+			t.Errorf("expected team %s to have an all-play score of %d, got: %d",
+				team.TeamID, expected[team.TeamID], team.Scores[componentAllPlay])
+		}
+	}
+}
+
+func TestGetAllPlayStandings(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error adding players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		ctrl.ArchiveLeague(ctx, l.ID)
+	}()
+
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	const weeks = 3
+	for w := 1; w <= weeks; w++ {
+		if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, w); err != nil {
+			t.Fatalf("error syncing week %d results: %v", w, err)
+		}
+	}
+
+	// The all-play records are saved with the results
+	results, err := ctrl.GetLeagueResults(ctx, l.ID, 1)
+	if err != nil {
+		t.Fatalf("error getting week 1 results: %v", err)
+	}
+	for _, m := range results {
+		for _, tr := range []*model.TeamResult{m.TeamA, m.TeamB} {
+			if tr.AllPlayWins+tr.AllPlayLosses+tr.AllPlayDraws != 3 {
+				t.Errorf("expected team %s to have an all-play record against 3 teams, got: %v", tr.TeamID, tr)
+			}
+		}
+	}
+
+	standings, err := ctrl.GetAllPlayStandings(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting all-play standings: %v", err)
+	}
+	if len(standings) != 4 {
+		t.Fatalf("expected 4 teams, got: %v", standings)
+	}
+
+	// Across the league the wins and expected wins balance out, so the luck does too
+	luck := 0.0
+	for _, s := range standings {
+		if s.Wins+s.Losses+s.Draws != weeks {
+			t.Errorf("expected team %s to have played %d games, got: %v", s.TeamID, weeks, s)
+		}
+		if s.TeamName == "" {
+			t.Errorf("expected team %s to have a name", s.TeamID)
+		}
+		luck += s.Luck
+	}
+	if math.Abs(luck) > 0.05 {
+		t.Errorf("expected the total luck to be close to 0, got: %f", luck)
+	}
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
@@ -10,6 +11,9 @@ import (
 	"github.com/itbasis/go-clock"
 	"github.com/mww/fantasy_manager_v2/db"
 	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/platforms/espn"
+	"github.com/mww/fantasy_manager_v2/platforms/fleaflicker"
+	"github.com/mww/fantasy_manager_v2/platforms/mfl"
 	"github.com/mww/fantasy_manager_v2/platforms/sleeper"
 	"github.com/mww/fantasy_manager_v2/platforms/yahoo"
 	"golang.org/x/oauth2"
@@ -28,15 +32,33 @@ type C interface {
 	GetTopScores(ctx context.Context, leagueID int32, week int) ([]model.PlayerScore, error)
 	RunPeriodicPlayerUpdates(frequency time.Duration, shutdown chan bool, wg *sync.WaitGroup)
 
-	// Add a new rankings for players. This will parse the data from the reader (in CSV format) and
-	// create a new rankings data point. Returns the id of the new rankings and an error if there
-	// was one.
-	AddRanking(ctx context.Context, r io.Reader, date time.Time) (int32, error)
+	// Add a new rankings for players. This will parse the data from the reader and create a new
+	// rankings data point. The format of the data is detected unless opts.Source is set. Returns
+	// the id of the new rankings and an error if there was one.
+	AddRanking(ctx context.Context, r io.Reader, date time.Time, opts model.RankingImportOptions) (int32, error)
 	GetRanking(ctx context.Context, id int32) (*model.Ranking, error)
 	DeleteRanking(ctx context.Context, id int32) error
 	ListRankings(ctx context.Context) ([]model.Ranking, error)
+	// Find the players that moved up or down, were added or were dropped between two
+	// rankings. If pos isn't POS_UNKNOWN only the players at that position are compared.
+	CompareRankings(ctx context.Context, fromID, toID int32, pos model.Position) (*model.RankingComparison, error)
+	// Get the player's rank in every ranking they are in, oldest ranking first.
+	GetPlayerRankings(ctx context.Context, playerID string) ([]model.PlayerRankingPoint, error)
+	// Blend several rankings into a consensus ranking using the weight of each one. The
+	// consensus ranking is saved as a normal ranking. Returns the id of the new ranking.
+	AddConsensusRanking(ctx context.Context, opts model.ConsensusOptions) (int32, error)
+	// Get a consensus ranking with the rank each of its sources gave the players.
+	GetConsensusRanking(ctx context.Context, id int32) (*model.ConsensusRanking, error)
+	// Lists the rows of a ranking that couldn't be matched to a player when it was added,
+	// with the players each row might be.
+	ListUnmatchedRankingPlayers(ctx context.Context, rankingID int32) ([]model.UnmatchedRankingPlayer, error)
+	// Match an unmatched row to a player, which adds the player to the ranking. If saveAlias
+	// is true future imports will match the row's name to the player.
+	ResolveUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32, playerID string, saveAlias bool) error
+	// Removes an unmatched row without adding a player to the ranking.
+	DismissUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32) error
 
-	GetLeaguesFromPlatform(ctx context.Context, username, platform, year string) ([]model.League, error)
+	GetLeaguesFromPlatform(ctx context.Context, username, platform, year, stateToken string) ([]model.League, error)
 	AddLeague(ctx context.Context, platform, externalID, year, stateToken string) (*model.League, error)
 	AddLeagueManagers(ctx context.Context, leagueID int32) (*model.League, error) // Will also update the list
 	GetLeague(ctx context.Context, id int32) (*model.League, error)
@@ -47,11 +69,64 @@ type C interface {
 	ListLeagueResultWeeks(ctx context.Context, leagueID int32) ([]int, error)
 	GetLeagueResults(ctx context.Context, leagueID int32, week int) ([]model.Matchup, error)
 	GetLeagueStandings(ctx context.Context, leagueID int32) ([]model.LeagueStanding, error)
+	// Compare each team's actual record to their all-play record, the record they would
+	// have if they played every team each week, to see which teams have been lucky.
+	GetAllPlayStandings(ctx context.Context, leagueID int32) ([]model.AllPlayStanding, error)
+	// Rate how hard each team's past and remaining schedule is, based on how their
+	// opponents have done so far. The remaining schedule is only rated for platforms
+	// that provide future matchups.
+	GetStrengthOfSchedule(ctx context.Context, leagueID int32) ([]model.ScheduleStrength, error)
+	// Simulate the rest of the regular season to get each team's chances of making the
+	// playoffs, getting a bye and finishing as each seed.
+	SimulateSeason(ctx context.Context, leagueID int32, cfg model.SimulationConfig) (*model.SeasonSimulation, error)
+	// Get the transactions for a single week, or for the whole season, in the order they were processed.
+	GetLeagueTransactions(ctx context.Context, leagueID int32, week int) ([]model.Transaction, error)
+	ListLeagueTransactions(ctx context.Context, leagueID int32) ([]model.Transaction, error)
+
+	// Load all of the drafts for the league from the platform and save them.
+	SyncDraftsFromPlatform(ctx context.Context, leagueID int32) error
+	ListDrafts(ctx context.Context, leagueID int32) ([]model.Draft, error)
+	// Grade each team's draft against the ranking closest to the draft date. The
+	// grades are sorted from best to worst.
+	GetDraftGrades(ctx context.Context, leagueID, draftID int32) (*model.Draft, []model.DraftGrade, error)
+
+	// Franchises chain the seasons of a league together.
+	ListFranchises(ctx context.Context) ([]model.Franchise, error)
+	// Get the franchise the league is part of, or nil if it isn't part of one.
+	GetLeagueFranchise(ctx context.Context, leagueID int32) (*model.Franchise, error)
+	// Add the league to the franchise, if franchiseID is 0 then a new franchise is
+	// created. Returns the id of the franchise.
+	LinkLeagueToFranchise(ctx context.Context, leagueID, franchiseID int32) (int32, error)
+	// Follow the previous seasons of the league on the platform, adding any missing
+	// seasons and linking them all into the same franchise. Returns the id of the franchise.
+	DiscoverFranchise(ctx context.Context, leagueID int32) (int32, error)
+	// Aggregate the champions and records of every season of the franchise.
+	GetFranchiseHistory(ctx context.Context, franchiseID int32) (*model.FranchiseHistory, error)
+
+	// Get the Elo rating history of every team in the league through week. The ratings
+	// are updated every time results are synced, and carry over from the previous
+	// season when the league is part of a franchise.
+	GetEloHistory(ctx context.Context, leagueID int32, week int) (*model.EloHistory, error)
 
 	ListPowerRankings(ctx context.Context, leagueID int32) ([]model.PowerRanking, error)
 	GetPowerRanking(ctx context.Context, leagueID, powerRankingID int32) (*model.PowerRanking, error)
-	// Calculates the power ranking and returns the id of the saved rankings
-	CalculatePowerRanking(ctx context.Context, leagueID, rankingID int32, week int) (int32, error)
+	// Get the weights used to calculate power rankings for the league.
+	GetPowerRankingConfig(ctx context.Context, leagueID int32) (*model.PowerRankingConfig, error)
+	UpdatePowerRankingConfig(ctx context.Context, leagueID int32, cfg *model.PowerRankingConfig) error
+	// Calculates the power ranking and returns the id of the saved rankings. The rank
+	// changes are calculated from the compareID power ranking, or when compareID is 0
+	// from the most recent power ranking for an earlier week.
+	CalculatePowerRanking(ctx context.Context, leagueID, rankingID int32, week int, compareID int32) (int32, error)
+	// Calculates the power ranking the same way as CalculatePowerRanking, but doesn't save it.
+	PreviewPowerRanking(ctx context.Context, leagueID, rankingID int32, week int, compareID int32) (*model.PowerRanking, error)
+	// Calculate a saved power ranking again with a different ranking or week and replace
+	// it. The power ranking keeps its id and the config it was first calculated with.
+	RecomputePowerRanking(ctx context.Context, leagueID, powerRankingID, rankingID int32, week int, compareID int32) error
+	// Get the rank of every team from the power rankings for each week of the season.
+	GetPowerRankingHistory(ctx context.Context, leagueID int32) (*model.PowerRankingHistory, error)
+	DeletePowerRanking(ctx context.Context, leagueID, powerRankingID int32) error
+	// Breaks down each team's score in a saved power ranking and how it changed from the previous one.
+	GetPowerRankingExplanation(ctx context.Context, leagueID, powerRankingID int32) (*model.PowerRankingExplanation, error)
 
 	// These methods are all for OAuth linking. Start creates a state token and
 	// saves it for 5 minutes, returning the auth code URL.
@@ -78,6 +153,9 @@ type controller struct {
 	sleeper     sleeper.Client
 	yahoo       *yahoo.Client
 	yahooConfig *oauth2.Config
+	espn        espn.Client
+	mfl         mfl.Client
+	fleaflicker fleaflicker.Client
 	oauthStates map[string]*oauthState
 }
 
@@ -87,13 +165,16 @@ type oauthState struct {
 	token    *oauth2.Token
 }
 
-func New(clock clock.Clock, db db.DB, sleeper sleeper.Client, yahoo *yahoo.Client, yahooConfig *oauth2.Config) (C, error) {
+func New(clock clock.Clock, db db.DB, sleeper sleeper.Client, yahoo *yahoo.Client, yahooConfig *oauth2.Config, espn espn.Client, mfl mfl.Client, fleaflicker fleaflicker.Client) (C, error) {
 	c := &controller{
 		clock:       clock,
 		db:          db,
 		sleeper:     sleeper,
 		yahoo:       yahoo,
 		yahooConfig: yahooConfig,
+		espn:        espn,
+		mfl:         mfl,
+		fleaflicker: fleaflicker,
 		oauthStates: make(map[string]*oauthState),
 	}
 	return c, nil
@@ -102,23 +183,55 @@ func New(clock clock.Clock, db db.DB, sleeper sleeper.Client, yahoo *yahoo.Clien
 // When we need to make calls that are specific to a platform, grab a platform
 // adapter and it will do it. This is internal to the controller package.
 type platformAdpater interface {
-	getLeagues(user, year string) ([]model.League, error)
-	getLeagueName(ctx context.Context, leagueID, stateToken string) (string, error)
+	getLeagues(ctx context.Context, user, year, stateToken string) ([]model.League, error)
+	getLeagueName(ctx context.Context, leagueID, year, stateToken string) (string, error)
 	getManagers(ctx context.Context, l *model.League) ([]model.LeagueManager, error)
 	sortManagers(m []model.LeagueManager)
 	getMatchupResults(ctx context.Context, l *model.League, week int) ([]model.Matchup, []model.PlayerScore, error)
 	getRosters(ctx context.Context, l *model.League) ([]model.Roster, error)
 	// Get all the starting roster spots. This is used in the power rankings calculations.
 	getStarters(ctx context.Context, l *model.League) ([]model.RosterSpot, error)
-	getLeagueStandings(ctx context.Context, leagueID string) ([]model.LeagueStanding, error)
+	getLeagueStandings(ctx context.Context, l *model.League) ([]model.LeagueStanding, error)
+	// Get the completed transactions for the week. Platforms that can't provide
+	// transactions return errTransactionsNotSupported.
+	getTransactions(ctx context.Context, l *model.League, week int) ([]model.Transaction, error)
+	// Get all of the completed drafts for the league, with the team ids of the picks
+	// set to the external id of the league managers.
+	getDrafts(ctx context.Context, l *model.League) ([]model.Draft, error)
+	// Get the league for the previous season, only the platform, external id, name and
+	// year are set. Returns nil if this is the first season of the league. Platforms
+	// that don't create a new league each season return errPreviousLeagueNotSupported.
+	getPreviousLeague(ctx context.Context, l *model.League) (*model.League, error)
+	// Get the external id of the team that won the league, or "" if there isn't a
+	// champion yet or the platform can't tell.
+	getChampion(ctx context.Context, l *model.League) (string, error)
+	// Get the regular season matchups that will be played after week, without scores.
+	// The team ids are the external ids of the league managers. Platforms that can't
+	// provide future matchups return errScheduleNotSupported.
+	getSchedule(ctx context.Context, l *model.League, week int) ([]model.Matchup, error)
 }
 
+var (
+	errTransactionsNotSupported = errors.New("transactions are not supported for this platform")
+	errDraftsNotSupported       = errors.New("drafts are not supported for this platform")
+	// The platform reuses the same league every season, or can't look up the
+	// previous season. These leagues have to be linked together manually.
+	errPreviousLeagueNotSupported = errors.New("finding the previous league is not supported for this platform")
+	errScheduleNotSupported       = errors.New("the remaining schedule is not supported for this platform")
+)
+
 func getPlatformAdapter(platform string, c *controller) platformAdpater {
 	switch platform {
 	case model.PlatformSleeper:
 		return &sleeperAdapter{c}
 	case model.PlatformYahoo:
 		return &yahooAdapter{c}
+	case model.PlatformESPN:
+		return &espnAdapter{c}
+	case model.PlatformMFL:
+		return &mflAdapter{c}
+	case model.PlatformFleaflicker:
+		return &fleaflickerAdapter{c}
 	default:
 		return &nilPlatformAdapter{err: fmt.Errorf("%s is not a supported platform", platform)}
 	}
@@ -130,11 +243,11 @@ type nilPlatformAdapter struct {
 	err error
 }
 
-func (a *nilPlatformAdapter) getLeagues(user, year string) ([]model.League, error) {
+func (a *nilPlatformAdapter) getLeagues(ctx context.Context, user, year, stateToken string) ([]model.League, error) {
 	return nil, a.err
 }
 
-func (a *nilPlatformAdapter) getLeagueName(ctx context.Context, leagueID, stateToken string) (string, error) {
+func (a *nilPlatformAdapter) getLeagueName(ctx context.Context, leagueID, year, stateToken string) (string, error) {
 	return "", a.err
 }
 
@@ -157,6 +270,26 @@ func (a *nilPlatformAdapter) getStarters(ctx context.Context, l *model.League) (
 	return nil, a.err
 }
 
-func (a *nilPlatformAdapter) getLeagueStandings(ctx context.Context, leagueID string) ([]model.LeagueStanding, error) {
+func (a *nilPlatformAdapter) getLeagueStandings(ctx context.Context, l *model.League) ([]model.LeagueStanding, error) {
+	return nil, a.err
+}
+
+func (a *nilPlatformAdapter) getTransactions(ctx context.Context, l *model.League, week int) ([]model.Transaction, error) {
+	return nil, a.err
+}
+
+func (a *nilPlatformAdapter) getDrafts(ctx context.Context, l *model.League) ([]model.Draft, error) {
+	return nil, a.err
+}
+
+func (a *nilPlatformAdapter) getPreviousLeague(ctx context.Context, l *model.League) (*model.League, error) {
+	return nil, a.err
+}
+
+func (a *nilPlatformAdapter) getChampion(ctx context.Context, l *model.League) (string, error) {
+	return "", a.err
+}
+
+func (a *nilPlatformAdapter) getSchedule(ctx context.Context, l *model.League, week int) ([]model.Matchup, error) {
 	return nil, a.err
 }
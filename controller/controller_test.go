@@ -7,6 +7,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/mww/fantasy_manager_v2/platforms/espn"
+	"github.com/mww/fantasy_manager_v2/platforms/fleaflicker"
+	"github.com/mww/fantasy_manager_v2/platforms/mfl"
 	"github.com/mww/fantasy_manager_v2/platforms/sleeper"
 	"github.com/mww/fantasy_manager_v2/platforms/yahoo"
 	"github.com/mww/fantasy_manager_v2/testutils"
@@ -38,7 +41,10 @@ func controllerForTest() (C, *testutils.TestController) {
 	tc := testutils.NewTestController(testDB)
 	sleeper := sleeper.NewForTest(tc.SleeperURL())
 	yahoo := yahoo.NewForTest(tc.YahooURL())
-	ctrl, err := New(tc.Clock, testDB.DB, sleeper, yahoo, tc.YahooConfig)
+	espn := espn.NewForTest(tc.ESPNURL())
+	mfl := mfl.NewForTest(tc.MFLURL())
+	fleaflicker := fleaflicker.NewForTest(tc.FleaflickerURL())
+	ctrl, err := New(tc.Clock, testDB.DB, sleeper, yahoo, tc.YahooConfig, espn, mfl, fleaflicker)
 	if err != nil {
 		panic(fmt.Sprintf("error creating controller for test: %v", err))
 	}
@@ -51,12 +57,12 @@ func TestNilPlatformAdapter(t *testing.T) {
 	expectedErr := errors.New("expected error")
 	a := &nilPlatformAdapter{err: expectedErr}
 
-	_, err := a.getLeagues("user", "2023")
+	_, err := a.getLeagues(ctx, "user", "2023", "")
 	if !errors.Is(err, expectedErr) {
 		t.Error("getLeagues did not return expected response")
 	}
 
-	_, err = a.getLeagueName(ctx, "", "")
+	_, err = a.getLeagueName(ctx, "", "", "")
 	if !errors.Is(err, expectedErr) {
 		t.Error("getLeagueName did not return expected response")
 	}
@@ -80,4 +86,29 @@ func TestNilPlatformAdapter(t *testing.T) {
 	if !errors.Is(err, expectedErr) {
 		t.Error("getStarters did not return expected response")
 	}
+
+	_, err = a.getTransactions(ctx, nil, 0)
+	if !errors.Is(err, expectedErr) {
+		t.Error("getTransactions did not return expected response")
+	}
+
+	_, err = a.getDrafts(ctx, nil)
+	if !errors.Is(err, expectedErr) {
+		t.Error("getDrafts did not return expected response")
+	}
+
+	_, err = a.getPreviousLeague(ctx, nil)
+	if !errors.Is(err, expectedErr) {
+		t.Error("getPreviousLeague did not return expected response")
+	}
+
+	_, err = a.getChampion(ctx, nil)
+	if !errors.Is(err, expectedErr) {
+		t.Error("getChampion did not return expected response")
+	}
+
+	_, err = a.getSchedule(ctx, nil, 1)
+	if !errors.Is(err, expectedErr) {
+		t.Error("getSchedule did not return expected response")
+	}
 }
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// Letter grades from best to worst, the teams in a draft are spread out across them.
+var draftLetterGrades = []string{"A+", "A", "A-", "B+", "B", "B-", "C+", "C", "C-", "D+", "D", "F"}
+
+func (c *controller) SyncDraftsFromPlatform(ctx context.Context, leagueID int32) error {
+	l, err := c.db.GetLeague(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("error looking up league: %w", err)
+	}
+	l.Managers, err = c.db.GetLeagueManagers(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("error loading league managers: %w", err)
+	}
+
+	drafts, err := getPlatformAdapter(l.Platform, c).getDrafts(ctx, l)
+	if err != nil {
+		return fmt.Errorf("error getting drafts: %w", err)
+	}
+
+	for i := range drafts {
+		if err := c.db.SaveDraft(ctx, l.ID, &drafts[i]); err != nil {
+			return fmt.Errorf("error saving draft: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *controller) ListDrafts(ctx context.Context, leagueID int32) ([]model.Draft, error) {
+	return c.db.ListDrafts(ctx, leagueID)
+}
+
+func (c *controller) GetDraftGrades(ctx context.Context, leagueID, draftID int32) (*model.Draft, []model.DraftGrade, error) {
+	d, err := c.db.GetDraft(ctx, leagueID, draftID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting draft %d: %w", draftID, err)
+	}
+
+	ranking, err := c.getRankingForDate(ctx, d.Started)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, gradeDraft(d, ranking), nil
+}
+
+// Find the most recent ranking from on or before the date. If there aren't any,
+// use the earliest ranking after the date instead.
+func (c *controller) getRankingForDate(ctx context.Context, date time.Time) (*model.Ranking, error) {
+	// Rankings are listed with the newest first
+	rankings, err := c.db.ListRankings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing rankings: %w", err)
+	}
+	if len(rankings) == 0 {
+		return nil, errors.New("there are no rankings to grade the draft with")
+	}
+
+	id := rankings[len(rankings)-1].ID
+	for _, r := range rankings {
+		if !r.Date.After(date) {
+			id = r.ID
+			break
+		}
+	}
+
+	ranking, err := c.db.GetRanking(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ranking with id %d: %w", id, err)
+	}
+	return ranking, nil
+}
+
+// Grade the draft by comparing the value of each player picked with the value
+// of a player ranked at the slot the pick was made in. Both use the same curve
+// as the power rankings, so getting a top player late is worth a lot more than
+// getting a deep bench player late.
+func gradeDraft(d *model.Draft, ranking *model.Ranking) []model.DraftGrade {
+	slots := draftSlots(d)
+
+	grades := make([]model.DraftGrade, 0)
+	teams := make(map[string]int) // team id to the index in grades
+	for _, p := range d.Picks {
+		idx, found := teams[p.TeamID]
+		if !found {
+			idx = len(grades)
+			teams[p.TeamID] = idx
+			grades = append(grades, model.DraftGrade{TeamID: p.TeamID, TeamName: p.TeamName})
+		}
+
+		g := model.GradedPick{DraftPick: p, Slot: slots[p.Pick], Rank: 1000}
+		if r, found := ranking.Players[p.PlayerID]; found {
+			g.Rank = r.Rank
+		}
+		g.SlotValue = calculatePlayerValue(int32(g.Slot))
+		g.Value = calculatePlayerValue(g.Rank)
+		g.Surplus = g.Value - g.SlotValue
+
+		// Keepers weren't really drafted, so they are listed but don't count toward the grade
+		if !p.IsKeeper {
+			grades[idx].Score += g.Surplus
+		}
+		grades[idx].Picks = append(grades[idx].Picks, g)
+	}
+
+	slices.SortFunc(grades, func(a, b model.DraftGrade) int {
+		if a.Score == b.Score {
+			return cmp.Compare(a.TeamID, b.TeamID)
+		}
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	// Spread the teams across the letter grades, the best team always gets
+	// the top grade and the worst team the bottom grade.
+	for i := range grades {
+		g := 0
+		if len(grades) > 1 {
+			g = int(math.Round(float64(i*(len(draftLetterGrades)-1)) / float64(len(grades)-1)))
+		}
+		grades[i].Grade = draftLetterGrades[g]
+	}
+
+	return grades
+}
+
+// Get the slot each pick was made in, indexed by pick number. For auctions the
+// slot is the order of the price paid, since the order of the nominations doesn't
+// reflect how highly the player was valued.
+func draftSlots(d *model.Draft) map[int]int {
+	slots := make(map[int]int, len(d.Picks))
+	if d.Type != model.DraftAuction {
+		for _, p := range d.Picks {
+			slots[p.Pick] = p.Pick
+		}
+		return slots
+	}
+
+	picks := slices.Clone(d.Picks)
+	slices.SortStableFunc(picks, func(a, b model.DraftPick) int {
+		if a.Cost == b.Cost {
+			return a.Pick - b.Pick
+		}
+		return cmp.Compare(b.Cost, a.Cost)
+	})
+	for i, p := range picks {
+		slots[p.Pick] = i + 1
+	}
+	return slots
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestGradeDraft(t *testing.T) {
+	d := &model.Draft{
+		Type:   model.DraftSnake,
+		Rounds: 3,
+		Picks: []model.DraftPick{
+			{Round: 1, Pick: 1, TeamID: "A", TeamName: "Team A", PlayerID: "1"},
+			{Round: 1, Pick: 2, TeamID: "B", TeamName: "Team B", PlayerID: "2"},
+			{Round: 2, Pick: 3, TeamID: "B", TeamName: "Team B", PlayerID: "3"},
+			{Round: 2, Pick: 4, TeamID: "A", TeamName: "Team A", PlayerID: "4"},
+			{Round: 3, Pick: 5, TeamID: "A", TeamName: "Team A", PlayerID: "5", IsKeeper: true},
+			{Round: 3, Pick: 6, TeamID: "B", TeamName: "Team B", PlayerID: "6"},
+		},
+	}
+	ranking := &model.Ranking{
+		Players: map[string]model.RankingPlayer{
+			"1": {Rank: 3},
+			"2": {Rank: 1},
+			"3": {Rank: 10},
+			// Player 4 is not ranked
+			"5": {Rank: 2},
+			"6": {Rank: 4},
+		},
+	}
+
+	grades := gradeDraft(d, ranking)
+	if len(grades) != 2 {
+		t.Fatalf("expected 2 grades, got: %d", len(grades))
+	}
+
+	v := calculatePlayerValue
+	scoreA := (v(3) - v(1)) + (v(1000) - v(4))
+	scoreB := (v(1) - v(2)) + (v(10) - v(3)) + (v(4) - v(6))
+
+	if grades[0].TeamID != "B" || grades[0].Grade != "A+" || grades[0].Score != scoreB {
+		t.Errorf("unexpected grade for the best team: %v", grades[0])
+	}
+	if grades[1].TeamID != "A" || grades[1].Grade != "F" || grades[1].Score != scoreA {
+		t.Errorf("unexpected grade for the worst team: %v", grades[1])
+	}
+
+	// The keeper is still listed, but doesn't count toward the score
+	keeper := grades[1].Picks[2]
+	expected := model.GradedPick{
+		DraftPick: d.Picks[4],
+		Slot:      5,
+		Rank:      2,
+		SlotValue: v(5),
+		Value:     v(2),
+		Surplus:   v(2) - v(5),
+	}
+	if !reflect.DeepEqual(expected, keeper) {
+		t.Errorf("expected: %v, got: %v", expected, keeper)
+	}
+	if grades[1].Picks[1].Rank != 1000 {
+		t.Errorf("expected unranked player to have rank 1000, got: %d", grades[1].Picks[1].Rank)
+	}
+}
+
+func TestDraftSlots(t *testing.T) {
+	picks := []model.DraftPick{
+		{Pick: 1, Cost: 12},
+		{Pick: 2, Cost: 45},
+		{Pick: 3, Cost: 12},
+		{Pick: 4, Cost: 60},
+	}
+
+	tests := map[string]struct {
+		draftType model.DraftType
+		expected  map[int]int
+	}{
+		"snake":   {draftType: model.DraftSnake, expected: map[int]int{1: 1, 2: 2, 3: 3, 4: 4}},
+		"auction": {draftType: model.DraftAuction, expected: map[int]int{1: 3, 2: 2, 3: 4, 4: 1}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			slots := draftSlots(&model.Draft{Type: tc.draftType, Picks: picks})
+			if !reflect.DeepEqual(tc.expected, slots) {
+				t.Errorf("expected: %v, got: %v", tc.expected, slots)
+			}
+		})
+	}
+}
+
+func TestDraftGrades(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error adding players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		ctrl.ArchiveLeague(ctx, l.ID)
+	}()
+
+	l, err = ctrl.AddLeagueManagers(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	if err := ctrl.SyncDraftsFromPlatform(ctx, l.ID); err != nil {
+		t.Fatalf("error syncing drafts: %v", err)
+	}
+
+	drafts, err := ctrl.ListDrafts(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error listing drafts: %v", err)
+	}
+	if len(drafts) != 1 || drafts[0].ExternalID != testutils.SleeperDraftID {
+		t.Fatalf("expected only the completed draft, got: %v", drafts)
+	}
+
+	// Rank the players in the reverse order they were drafted. Jolly Roger kept
+	// the top ranked player, which doesn't count toward their grade.
+	date := time.Date(2023, time.August, 30, 0, 0, 0, 0, time.UTC)
+	playerRanks := map[string]int32{
+		"4080": 1, "2359": 2, "1339": 3, "10219": 4, "8154": 5, "7601": 6,
+		"4993": 7, "1352": 8, "3225": 9, "1992": 10, "2216": 11, "1166": 12,
+	}
+	ranking, err := testDB.DB.AddRanking(ctx, date, model.RankingSourceFantasyPros, model.SCORING_UNKNOWN, playerRanks, nil)
+	if err != nil {
+		t.Fatalf("error adding ranking: %v", err)
+	}
+	defer func() {
+		ctrl.DeleteRanking(ctx, ranking.ID)
+	}()
+
+	d, grades, err := ctrl.GetDraftGrades(ctx, l.ID, drafts[0].ID)
+	if err != nil {
+		t.Fatalf("error getting draft grades: %v", err)
+	}
+	if len(d.Picks) != 12 {
+		t.Errorf("expected 12 picks, got: %d", len(d.Picks))
+	}
+	if d.Picks[0].FirstName != "Kirk" || d.Picks[0].TeamName != "Puk Nukem" {
+		t.Errorf("unexpected first pick: %v", d.Picks[0])
+	}
+
+	if len(grades) != 4 {
+		t.Fatalf("expected 4 grades, got: %d", len(grades))
+	}
+	if grades[0].TeamName != "gee17" || grades[0].Grade != "A+" {
+		t.Errorf("expected gee17 to have the best draft, got: %v", grades[0])
+	}
+	if grades[3].TeamName != "Jolly Roger" || grades[3].Grade != "F" {
+		t.Errorf("expected Jolly Roger to have the worst draft, got: %v", grades[3])
+	}
+}
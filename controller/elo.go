@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+const (
+	// How much a single game can change a rating.
+	eloK = 20
+	// The part of a rating's distance from the initial rating that is kept
+	// between seasons, the rest regresses back to the mean.
+	eloCarryOver = 2.0 / 3.0
+)
+
+func (c *controller) GetEloHistory(ctx context.Context, leagueID int32, week int) (*model.EloHistory, error) {
+	l, err := c.GetLeague(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up league: %w", err)
+	}
+
+	ratings, err := c.db.GetEloRatings(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for _, m := range l.Managers {
+		names[m.ExternalID] = m.TeamName
+		if m.TeamName == "" {
+			names[m.ExternalID] = m.ManagerName
+		}
+	}
+	return eloHistory(ratings, week, names), nil
+}
+
+// Build the history of every team's rating through week from the saved ratings,
+// which are sorted by week.
+func eloHistory(ratings []model.EloRating, week int, names map[string]string) *model.EloHistory {
+	h := &model.EloHistory{Weeks: make([]int, 0, 18)}
+	teams := make(map[string]*model.TeamElo)
+	for _, r := range ratings {
+		if r.Week > week {
+			break
+		}
+		if len(h.Weeks) == 0 || h.Weeks[len(h.Weeks)-1] != r.Week {
+			h.Weeks = append(h.Weeks, r.Week)
+		}
+
+		t, found := teams[r.TeamID]
+		if !found {
+			t = &model.TeamElo{TeamID: r.TeamID, TeamName: names[r.TeamID], Rating: r.Rating}
+			teams[r.TeamID] = t
+		}
+		// Teams that joined part way through the season don't have a rating for
+		// the earlier weeks, use the rating they started with.
+		for len(t.History) < len(h.Weeks)-1 {
+			t.History = append(t.History, r.Rating)
+		}
+		t.Change = r.Rating - t.Rating
+		t.Rating = r.Rating
+		t.History = append(t.History, r.Rating)
+	}
+
+	h.Teams = make([]model.TeamElo, 0, len(teams))
+	for _, t := range teams {
+		h.Teams = append(h.Teams, *t)
+	}
+	slices.SortFunc(h.Teams, func(a, b model.TeamElo) int {
+		if a.Rating == b.Rating {
+			return cmp.Compare(a.TeamID, b.TeamID)
+		}
+		return cmp.Compare(b.Rating, a.Rating)
+	})
+	return h
+}
+
+// Recalculate the Elo ratings of the league from all of its results. If the league
+// is part of a franchise the later seasons are recalculated too, since their
+// starting ratings carry over from this season.
+func (c *controller) updateEloRatings(ctx context.Context, leagueID int32) error {
+	f, err := c.GetLeagueFranchise(ctx, leagueID)
+	if err != nil {
+		return err
+	}
+
+	seasons := []int32{leagueID}
+	var previousID int32
+	if f != nil {
+		idx := slices.IndexFunc(f.Leagues, func(l model.League) bool { return l.ID == leagueID })
+		if idx > 0 {
+			previousID = f.Leagues[idx-1].ID
+		}
+		seasons = seasons[:0]
+		for _, l := range f.Leagues[max(idx, 0):] {
+			seasons = append(seasons, l.ID)
+		}
+	}
+
+	for _, id := range seasons {
+		if err := c.calculateSeasonEloRatings(ctx, id, previousID); err != nil {
+			return err
+		}
+		previousID = id
+	}
+	return nil
+}
+
+// Calculate and save the ratings for a single season. If previousID isn't 0 the
+// teams start with their rating from the end of that season.
+func (c *controller) calculateSeasonEloRatings(ctx context.Context, leagueID, previousID int32) error {
+	l, err := c.GetLeague(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("error looking up league: %w", err)
+	}
+
+	start := make(map[string]float64)
+	for _, m := range l.Managers {
+		start[m.ExternalID] = model.EloInitialRating
+	}
+
+	if previousID != 0 {
+		prev, err := c.GetLeague(ctx, previousID)
+		if err != nil {
+			return fmt.Errorf("error looking up the previous season: %w", err)
+		}
+		prevRatings, err := c.db.GetEloRatings(ctx, previousID)
+		if err != nil {
+			return err
+		}
+
+		// The ratings are sorted by week, so this leaves each team's final rating
+		final := make(map[string]float64)
+		for _, r := range prevRatings {
+			final[r.TeamID] = r.Rating
+		}
+		// Team ids can change between seasons, so match the managers instead
+		byManager := make(map[string]float64)
+		for _, m := range prev.Managers {
+			if r, found := final[m.ExternalID]; found {
+				byManager[managerKey(prev.Platform, m)] = r
+			}
+		}
+		for _, m := range l.Managers {
+			if r, found := byManager[managerKey(l.Platform, m)]; found {
+				start[m.ExternalID] = model.EloInitialRating + (r-model.EloInitialRating)*eloCarryOver
+			}
+		}
+	}
+
+	weeklyResults, _, err := c.getAllResults(ctx, leagueID)
+	if err != nil {
+		return err
+	}
+
+	return c.db.SaveEloRatings(ctx, leagueID, calculateEloRatings(start, weeklyResults))
+}
+
+// Play through every week of results, starting from the start ratings. Returns the
+// starting rating of every team as week 0, and then every team's rating after
+// each week with results.
+func calculateEloRatings(start map[string]float64, weeklyResults map[int][]model.Matchup) []model.EloRating {
+	weeks := slices.Sorted(maps.Keys(weeklyResults))
+
+	ratings := maps.Clone(start)
+	for _, w := range weeks {
+		for _, m := range weeklyResults[w] {
+			for _, t := range []*model.TeamResult{m.TeamA, m.TeamB} {
+				if t == nil {
+					continue
+				}
+				if _, found := ratings[t.TeamID]; !found {
+					ratings[t.TeamID] = model.EloInitialRating
+				}
+			}
+		}
+	}
+	teams := slices.Sorted(maps.Keys(ratings))
+
+	results := make([]model.EloRating, 0, len(teams)*(len(weeks)+1))
+	for _, id := range teams {
+		results = append(results, model.EloRating{TeamID: id, Week: 0, Rating: ratings[id]})
+	}
+
+	for _, w := range weeks {
+		for _, m := range weeklyResults[w] {
+			if m.TeamA == nil || m.TeamB == nil {
+				continue
+			}
+			change := eloChange(ratings[m.TeamA.TeamID], ratings[m.TeamB.TeamID], m.TeamA.Score, m.TeamB.Score)
+			ratings[m.TeamA.TeamID] += change
+			ratings[m.TeamB.TeamID] -= change
+		}
+		for _, id := range teams {
+			results = append(results, model.EloRating{TeamID: id, Week: w, Rating: ratings[id]})
+		}
+	}
+	return results
+}
+
+// Get how much team A's rating changes after playing team B, team B's rating
+// changes by the opposite amount. Bigger wins move the ratings more, but less so
+// when the favorite wins since they were expected to win big.
+func eloChange(ratingA, ratingB float64, scoreA, scoreB int32) float64 {
+	expected := 1 / (1 + math.Pow(10, (ratingB-ratingA)/400))
+
+	if scoreA == scoreB {
+		return eloK * (0.5 - expected)
+	}
+
+	actual, winnerDiff := 1.0, ratingA-ratingB
+	if scoreA < scoreB {
+		actual, winnerDiff = 0, ratingB-ratingA
+	}
+	margin := math.Abs(float64(scoreA-scoreB)) / 1000
+	multiplier := math.Log(margin+1) * 2.2 / (winnerDiff*0.001 + 2.2)
+	return eloK * multiplier * (actual - expected)
+}
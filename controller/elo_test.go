@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestEloChange(t *testing.T) {
+	if c := eloChange(1500, 1500, 100000, 100000); c != 0 {
+		t.Errorf("expected a draw between equal teams to not change the ratings, got: %f", c)
+	}
+	if c := eloChange(1600, 1500, 100000, 100000); c >= 0 {
+		t.Errorf("expected the favorite to lose rating with a draw, got: %f", c)
+	}
+
+	small := eloChange(1500, 1500, 110000, 100000)
+	big := eloChange(1500, 1500, 140000, 100000)
+	if small <= 0 || big <= small {
+		t.Errorf("expected a bigger win to move the rating more, got: %f and %f", small, big)
+	}
+	if loss := eloChange(1500, 1500, 100000, 110000); loss != -small {
+		t.Errorf("expected a loss to be the opposite of a win, got: %f and %f", loss, small)
+	}
+
+	favorite := eloChange(1600, 1500, 110000, 100000)
+	underdog := eloChange(1500, 1600, 110000, 100000)
+	if favorite <= 0 || underdog <= favorite {
+		t.Errorf("expected an upset to move the rating more, got: %f and %f", favorite, underdog)
+	}
+}
+
+func TestCalculateEloRatings(t *testing.T) {
+	_, weeklyResults := getDataForTest()
+
+	ratings := calculateEloRatings(map[string]float64{"1": 1600}, weeklyResults)
+	if len(ratings) != 24 {
+		t.Fatalf("expected a rating for 4 teams for weeks 0 through 5, got: %v", ratings)
+	}
+
+	byWeek := make(map[int]map[string]float64)
+	for _, r := range ratings {
+		if byWeek[r.Week] == nil {
+			byWeek[r.Week] = make(map[string]float64)
+		}
+		byWeek[r.Week][r.TeamID] = r.Rating
+	}
+
+	expected := map[string]float64{"1": 1600, "2": 1500, "3": 1500, "4": 1500}
+	if !reflect.DeepEqual(expected, byWeek[0]) {
+		t.Errorf("expected starting ratings: %v, got: %v", expected, byWeek[0])
+	}
+
+	// Every game moves the same amount between the two teams
+	for w := 1; w <= 5; w++ {
+		total := 0.0
+		for _, r := range byWeek[w] {
+			total += r
+		}
+		if math.Abs(total-6100) > 0.000001 {
+			t.Errorf("expected the ratings for week %d to add up to 6100, got: %f", w, total)
+		}
+	}
+
+	// Starting even, team 3 is 4-1 and team 2 is 1-4
+	final := make(map[string]float64)
+	for _, r := range calculateEloRatings(map[string]float64{}, weeklyResults) {
+		final[r.TeamID] = r.Rating
+	}
+	for _, id := range []string{"1", "2", "4"} {
+		if final["3"] <= final[id] {
+			t.Errorf("expected team 3 to have a higher rating than team %s, got: %v", id, final)
+		}
+	}
+	if final["2"] >= 1500 {
+		t.Errorf("expected team 2 to lose rating, got: %v", final)
+	}
+}
+
+func TestEloHistory(t *testing.T) {
+	ratings := []model.EloRating{
+		{TeamID: "A", Week: 0, Rating: 1500},
+		{TeamID: "B", Week: 0, Rating: 1510},
+		{TeamID: "A", Week: 1, Rating: 1520},
+		{TeamID: "B", Week: 1, Rating: 1490},
+		{TeamID: "A", Week: 2, Rating: 1515},
+		{TeamID: "B", Week: 2, Rating: 1495},
+		{TeamID: "C", Week: 2, Rating: 1500},
+		{TeamID: "A", Week: 3, Rating: 1530},
+		{TeamID: "B", Week: 3, Rating: 1480},
+		{TeamID: "C", Week: 3, Rating: 1500},
+	}
+	names := map[string]string{"A": "Team A", "B": "Team B"}
+
+	expected := &model.EloHistory{
+		Weeks: []int{0, 1, 2},
+		Teams: []model.TeamElo{
+			{TeamID: "A", TeamName: "Team A", Rating: 1515, Change: -5, History: []float64{1500, 1520, 1515}},
+			{TeamID: "C", Rating: 1500, Change: 0, History: []float64{1500, 1500, 1500}},
+			{TeamID: "B", TeamName: "Team B", Rating: 1495, Change: 5, History: []float64{1510, 1490, 1495}},
+		},
+	}
+
+	h := eloHistory(ratings, 2, names)
+	if !reflect.DeepEqual(expected, h) {
+		t.Errorf("expected: %v, got: %v", expected, h)
+	}
+}
+
+func TestEloRatingsCarryOver(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error adding players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2023", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	if _, err := ctrl.DiscoverFranchise(ctx, l.ID); err != nil {
+		t.Fatalf("error discovering franchise: %v", err)
+	}
+	f, err := ctrl.GetLeagueFranchise(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting league franchise: %v", err)
+	}
+	defer func() {
+		for _, s := range f.Leagues {
+			ctrl.ArchiveLeague(ctx, s.ID)
+		}
+	}()
+	prev := f.Leagues[0]
+
+	// Before any results everyone starts at the initial rating
+	h, err := ctrl.GetEloHistory(ctx, l.ID, 1)
+	if err != nil {
+		t.Fatalf("error getting elo history: %v", err)
+	}
+	if len(h.Teams) != 4 || !reflect.DeepEqual([]int{0}, h.Weeks) {
+		t.Fatalf("unexpected elo history: %v", h)
+	}
+	for _, team := range h.Teams {
+		if team.Rating != model.EloInitialRating || team.TeamName == "" {
+			t.Errorf("unexpected starting rating: %v", team)
+		}
+	}
+
+	// Syncing the previous season changes the ratings the current season starts with
+	for w := 1; w <= 3; w++ {
+		if err := ctrl.SyncResultsFromPlatform(ctx, prev.ID, w); err != nil {
+			t.Fatalf("error syncing week %d results: %v", w, err)
+		}
+	}
+
+	prevHistory, err := ctrl.GetEloHistory(ctx, prev.ID, 3)
+	if err != nil {
+		t.Fatalf("error getting elo history for the previous season: %v", err)
+	}
+	if !reflect.DeepEqual([]int{0, 1, 2, 3}, prevHistory.Weeks) {
+		t.Fatalf("unexpected weeks for the previous season: %v", prevHistory.Weeks)
+	}
+	finalRatings := make(map[string]float64)
+	for _, team := range prevHistory.Teams {
+		finalRatings[team.TeamName] = team.Rating
+	}
+
+	h, err = ctrl.GetEloHistory(ctx, l.ID, 1)
+	if err != nil {
+		t.Fatalf("error getting elo history: %v", err)
+	}
+	changed := 0
+	for _, team := range h.Teams {
+		// The same managers have the same team names in both seasons of the test data
+		expected := model.EloInitialRating + (finalRatings[team.TeamName]-model.EloInitialRating)*eloCarryOver
+		if math.Abs(team.Rating-expected) > 0.000001 {
+			t.Errorf("expected team %s to start with a rating of %f, got: %f", team.TeamName, expected, team.Rating)
+		}
+		if team.Rating != model.EloInitialRating {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Errorf("expected the ratings to carry over from the previous season, got: %v", h.Teams)
+	}
+
+	if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, 1); err != nil {
+		t.Fatalf("error syncing week 1 results: %v", err)
+	}
+	h, err = ctrl.GetEloHistory(ctx, l.ID, 1)
+	if err != nil {
+		t.Fatalf("error getting elo history: %v", err)
+	}
+	if !reflect.DeepEqual([]int{0, 1}, h.Weeks) {
+		t.Errorf("expected ratings for weeks 0 and 1, got: %v", h.Weeks)
+	}
+}
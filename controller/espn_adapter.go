@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+type espnAdapter struct {
+	c *controller
+}
+
+// For ESPN the user is the SWID of the ESPN account.
+func (a *espnAdapter) getLeagues(ctx context.Context, user, year, stateToken string) ([]model.League, error) {
+	return a.c.espn.GetLeaguesForUser(user, year)
+}
+
+func (a *espnAdapter) getLeagueName(ctx context.Context, leagueID, year, stateToken string) (string, error) {
+	return a.c.espn.GetLeagueName(leagueID, year)
+}
+
+func (a *espnAdapter) getManagers(ctx context.Context, l *model.League) ([]model.LeagueManager, error) {
+	managers, err := a.c.espn.GetLeagueManagers(l.ExternalID, l.Year)
+	if err != nil {
+		return nil, fmt.Errorf("error loading managers from espn for %s: %w", l.ExternalID, err)
+	}
+	return managers, nil
+}
+
+func (a *espnAdapter) sortManagers(m []model.LeagueManager) {
+	a.c.espn.SortManagers(m)
+}
+
+func (a *espnAdapter) getMatchupResults(ctx context.Context, l *model.League, week int) ([]model.Matchup, []model.PlayerScore, error) {
+	matchups, scores, err := a.c.espn.GetMatchupResults(l.ExternalID, l.Year, week)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	players := make([]model.ESPNPlayer, 0, len(scores))
+	for _, s := range scores {
+		players = append(players, s.Player)
+	}
+	ids, err := a.c.db.ConvertESPNPlayerIDs(ctx, players)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	playerScores := make([]model.PlayerScore, 0, len(scores))
+	for i, s := range scores {
+		playerScores = append(playerScores, model.PlayerScore{PlayerID: ids[i], Score: s.Score})
+	}
+	return matchups, playerScores, nil
+}
+
+func (a *espnAdapter) getRosters(ctx context.Context, l *model.League) ([]model.Roster, error) {
+	rosters, err := a.c.espn.GetRosters(l.ExternalID, l.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.Roster, 0, len(rosters))
+	for _, r := range rosters {
+		ids, err := a.c.db.ConvertESPNPlayerIDs(ctx, r.Players)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Roster{TeamID: r.TeamID, PlayerIDs: ids})
+	}
+	return results, nil
+}
+
+func (a *espnAdapter) getStarters(ctx context.Context, l *model.League) ([]model.RosterSpot, error) {
+	return a.c.espn.GetStarters(l.ExternalID, l.Year)
+}
+
+func (a *espnAdapter) getLeagueStandings(ctx context.Context, l *model.League) ([]model.LeagueStanding, error) {
+	return a.c.espn.GetLeagueStandings(l.ExternalID, l.Year)
+}
+
+func (a *espnAdapter) getTransactions(ctx context.Context, l *model.League, week int) ([]model.Transaction, error) {
+	return nil, errTransactionsNotSupported
+}
+
+func (a *espnAdapter) getDrafts(ctx context.Context, l *model.League) ([]model.Draft, error) {
+	return nil, errDraftsNotSupported
+}
+
+func (a *espnAdapter) getPreviousLeague(ctx context.Context, l *model.League) (*model.League, error) {
+	return nil, errPreviousLeagueNotSupported
+}
+
+func (a *espnAdapter) getChampion(ctx context.Context, l *model.League) (string, error) {
+	return "", nil
+}
+
+func (a *espnAdapter) getSchedule(ctx context.Context, l *model.League, week int) ([]model.Matchup, error) {
+	return nil, errScheduleNotSupported
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestESPNGetTransactions(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+	adapter := &espnAdapter{ctrl.(*controller)}
+
+	_, err := adapter.getTransactions(context.Background(), espnLeague(), 1)
+	if !errors.Is(err, errTransactionsNotSupported) {
+		t.Errorf("expected transactions to not be supported, got: %v", err)
+	}
+}
+
+func espnLeague() *model.League {
+	return &model.League{
+		Platform:   model.PlatformESPN,
+		ExternalID: testutils.ESPNLeagueID,
+		Name:       "ESPN Fantasy Friends",
+		Year:       "2024",
+	}
+}
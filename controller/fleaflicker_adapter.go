@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+type fleaflickerAdapter struct {
+	c *controller
+}
+
+// For Fleaflicker the user is the email address of the account.
+func (a *fleaflickerAdapter) getLeagues(ctx context.Context, user, year, stateToken string) ([]model.League, error) {
+	return a.c.fleaflicker.GetLeaguesForUser(user, year)
+}
+
+func (a *fleaflickerAdapter) getLeagueName(ctx context.Context, leagueID, year, stateToken string) (string, error) {
+	return a.c.fleaflicker.GetLeagueName(leagueID, year)
+}
+
+func (a *fleaflickerAdapter) getManagers(ctx context.Context, l *model.League) ([]model.LeagueManager, error) {
+	managers, err := a.c.fleaflicker.GetLeagueManagers(l.ExternalID, l.Year)
+	if err != nil {
+		return nil, fmt.Errorf("error loading managers from fleaflicker for %s: %w", l.ExternalID, err)
+	}
+	return managers, nil
+}
+
+func (a *fleaflickerAdapter) sortManagers(m []model.LeagueManager) {
+	a.c.fleaflicker.SortManagers(m)
+}
+
+func (a *fleaflickerAdapter) getMatchupResults(ctx context.Context, l *model.League, week int) ([]model.Matchup, []model.PlayerScore, error) {
+	matchups, scores, err := a.c.fleaflicker.GetMatchupResults(l.ExternalID, l.Year, week)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	players := make([]model.FleaflickerPlayer, 0, len(scores))
+	for _, s := range scores {
+		players = append(players, s.Player)
+	}
+	ids, err := a.c.db.ConvertFleaflickerPlayerIDs(ctx, players)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	playerScores := make([]model.PlayerScore, 0, len(scores))
+	for i, s := range scores {
+		playerScores = append(playerScores, model.PlayerScore{PlayerID: ids[i], Score: s.Score})
+	}
+	return matchups, playerScores, nil
+}
+
+func (a *fleaflickerAdapter) getRosters(ctx context.Context, l *model.League) ([]model.Roster, error) {
+	rosters, err := a.c.fleaflicker.GetRosters(l.ExternalID, l.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.Roster, 0, len(rosters))
+	for _, r := range rosters {
+		ids, err := a.c.db.ConvertFleaflickerPlayerIDs(ctx, r.Players)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Roster{TeamID: r.TeamID, PlayerIDs: ids})
+	}
+	return results, nil
+}
+
+func (a *fleaflickerAdapter) getStarters(ctx context.Context, l *model.League) ([]model.RosterSpot, error) {
+	return a.c.fleaflicker.GetStarters(l.ExternalID, l.Year)
+}
+
+func (a *fleaflickerAdapter) getLeagueStandings(ctx context.Context, l *model.League) ([]model.LeagueStanding, error) {
+	return a.c.fleaflicker.GetLeagueStandings(l.ExternalID, l.Year)
+}
+
+func (a *fleaflickerAdapter) getTransactions(ctx context.Context, l *model.League, week int) ([]model.Transaction, error) {
+	return nil, errTransactionsNotSupported
+}
+
+func (a *fleaflickerAdapter) getDrafts(ctx context.Context, l *model.League) ([]model.Draft, error) {
+	return nil, errDraftsNotSupported
+}
+
+func (a *fleaflickerAdapter) getPreviousLeague(ctx context.Context, l *model.League) (*model.League, error) {
+	return nil, errPreviousLeagueNotSupported
+}
+
+func (a *fleaflickerAdapter) getChampion(ctx context.Context, l *model.League) (string, error) {
+	return "", nil
+}
+
+func (a *fleaflickerAdapter) getSchedule(ctx context.Context, l *model.League, week int) ([]model.Matchup, error) {
+	return nil, errScheduleNotSupported
+}
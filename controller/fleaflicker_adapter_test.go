@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+// Run through the full flow of adding a league, syncing results and calculating
+// a power ranking to make sure everything works for a Fleaflicker league.
+func TestFleaflickerPowerRanking(t *testing.T) {
+	ctx := context.Background()
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformFleaflicker, testutils.FleaflickerLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding a new league: %v", err)
+	}
+	defer func() {
+		if err := ctrl.ArchiveLeague(ctx, l.ID); err != nil {
+			t.Fatalf("error archiving league: %v", err)
+		}
+	}()
+	if l.Name != "Fleaflicker Friends League" {
+		t.Errorf("league name was not expected value: %s", l.Name)
+	}
+
+	l, err = ctrl.AddLeagueManagers(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+	if len(l.Managers) != 4 {
+		t.Fatalf("expected 4 managers, got %d", len(l.Managers))
+	}
+
+	if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, 1); err != nil {
+		t.Fatalf("error syncing results: %v", err)
+	}
+
+	// Fleaflicker can't provide the remaining schedule
+	if _, err := ctrl.SimulateSeason(ctx, l.ID, model.DefaultSimulationConfig()); !errors.Is(err, ErrSimulationNotSupported) {
+		t.Errorf("expected simulations to not be supported, got: %v", err)
+	}
+
+	rankingDate, err := time.ParseInLocation(time.DateOnly, "2024-09-01", time.UTC)
+	if err != nil {
+		t.Fatalf("error parsing ranking date: %v", err)
+	}
+	rankingID, err := ctrl.AddRanking(ctx, getRankingsData(), rankingDate, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding ranking: %v", err)
+	}
+	defer ctrl.DeleteRanking(ctx, rankingID)
+
+	prID, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, 1, 0 /* compareID */)
+	if err != nil {
+		t.Fatalf("error calculating power ranking: %v", err)
+	}
+	pr, err := ctrl.GetPowerRanking(ctx, l.ID, prID)
+	if err != nil {
+		t.Fatalf("error getting power ranking: %v", err)
+	}
+	if len(pr.Teams) != 4 {
+		t.Fatalf("expected 4 teams in the power ranking, got %d", len(pr.Teams))
+	}
+	for i, team := range pr.Teams {
+		if team.Rank != i+1 {
+			t.Errorf("expected team %s to have rank %d, got %d", team.TeamID, i+1, team.Rank)
+		}
+		if team.TeamName == "" {
+			t.Errorf("expected team %s to have a name", team.TeamID)
+		}
+	}
+}
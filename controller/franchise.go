@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func (c *controller) ListFranchises(ctx context.Context) ([]model.Franchise, error) {
+	return c.db.ListFranchises(ctx)
+}
+
+func (c *controller) GetLeagueFranchise(ctx context.Context, leagueID int32) (*model.Franchise, error) {
+	id, err := c.db.GetLeagueFranchiseID(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if id == 0 {
+		return nil, nil
+	}
+	return c.db.GetFranchise(ctx, id)
+}
+
+func (c *controller) LinkLeagueToFranchise(ctx context.Context, leagueID, franchiseID int32) (int32, error) {
+	l, err := c.db.GetLeague(ctx, leagueID)
+	if err != nil {
+		return 0, fmt.Errorf("error looking up league: %w", err)
+	}
+
+	if franchiseID == 0 {
+		f := &model.Franchise{Name: l.Name}
+		if err := c.db.AddFranchise(ctx, f); err != nil {
+			return 0, err
+		}
+		franchiseID = f.ID
+	} else if _, err := c.db.GetFranchise(ctx, franchiseID); err != nil {
+		return 0, fmt.Errorf("error looking up franchise: %w", err)
+	}
+
+	if err := c.db.LinkLeague(ctx, franchiseID, l.ID); err != nil {
+		return 0, err
+	}
+
+	// The league's ratings now carry over from the previous season of the franchise
+	if err := c.updateEloRatings(ctx, l.ID); err != nil {
+		return 0, fmt.Errorf("error updating elo ratings: %w", err)
+	}
+	return franchiseID, nil
+}
+
+func (c *controller) DiscoverFranchise(ctx context.Context, leagueID int32) (int32, error) {
+	l, err := c.db.GetLeague(ctx, leagueID)
+	if err != nil {
+		return 0, fmt.Errorf("error looking up league: %w", err)
+	}
+	adapter := getPlatformAdapter(l.Platform, c)
+
+	existing, err := c.db.ListLeagues(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Walk back through the previous seasons, adding any that haven't been added yet.
+	seasons := []*model.League{l}
+	seen := map[string]bool{l.ExternalID: true}
+	for cur := l; ; {
+		prev, err := adapter.getPreviousLeague(ctx, cur)
+		if errors.Is(err, errPreviousLeagueNotSupported) {
+			return 0, fmt.Errorf("%s leagues must be linked to a franchise manually: %w", l.Platform, err)
+		} else if err != nil {
+			return 0, fmt.Errorf("error getting the league before %s: %w", cur.ExternalID, err)
+		}
+		if prev == nil || seen[prev.ExternalID] {
+			break
+		}
+		seen[prev.ExternalID] = true
+
+		if idx := slices.IndexFunc(existing, func(e model.League) bool {
+			return e.Platform == prev.Platform && e.ExternalID == prev.ExternalID
+		}); idx >= 0 {
+			prev = &existing[idx]
+		} else {
+			if err := c.db.AddLeague(ctx, prev); err != nil {
+				return 0, fmt.Errorf("error adding the %s season: %w", prev.Year, err)
+			}
+			if _, err := c.AddLeagueManagers(ctx, prev.ID); err != nil {
+				return 0, fmt.Errorf("error adding managers for the %s season: %w", prev.Year, err)
+			}
+		}
+		seasons = append(seasons, prev)
+		cur = prev
+	}
+
+	// Reuse a franchise if any of the seasons are already part of one
+	var franchiseID int32
+	for _, s := range seasons {
+		if franchiseID, err = c.db.GetLeagueFranchiseID(ctx, s.ID); err != nil {
+			return 0, err
+		} else if franchiseID != 0 {
+			break
+		}
+	}
+	if franchiseID == 0 {
+		f := &model.Franchise{Name: l.Name}
+		if err := c.db.AddFranchise(ctx, f); err != nil {
+			return 0, err
+		}
+		franchiseID = f.ID
+	}
+
+	for _, s := range seasons {
+		if err := c.db.LinkLeague(ctx, franchiseID, s.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	// Recalculating the oldest season also recalculates the seasons after it
+	if err := c.updateEloRatings(ctx, seasons[len(seasons)-1].ID); err != nil {
+		return 0, fmt.Errorf("error updating elo ratings: %w", err)
+	}
+	return franchiseID, nil
+}
+
+func (c *controller) GetFranchiseHistory(ctx context.Context, franchiseID int32) (*model.FranchiseHistory, error) {
+	f, err := c.db.GetFranchise(ctx, franchiseID)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up franchise: %w", err)
+	}
+
+	h := &model.FranchiseHistory{
+		Franchise: *f,
+		Seasons:   make([]model.FranchiseSeason, 0, len(f.Leagues)),
+	}
+	managers := make(map[string]*model.FranchiseManager)
+	order := make([]string, 0)
+
+	// Go through the seasons with the most recent first, so the most recent
+	// team names are listed first for each manager.
+	for i := len(f.Leagues) - 1; i >= 0; i-- {
+		l, err := c.GetLeague(ctx, f.Leagues[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		adapter := getPlatformAdapter(l.Platform, c)
+
+		s := model.FranchiseSeason{LeagueID: l.ID, Year: l.Year, Name: l.Name}
+		// Older seasons might not be available from the platform anymore, so
+		// show what we can instead of failing the whole history.
+		if s.Standings, err = c.GetLeagueStandings(ctx, l.ID); err != nil {
+			log.Printf("error getting standings for league %d: %v", l.ID, err)
+		}
+		if s.ChampionID, err = adapter.getChampion(ctx, l); err != nil {
+			log.Printf("error getting champion for league %d: %v", l.ID, err)
+		}
+
+		standings := make(map[string]model.LeagueStanding)
+		for _, st := range s.Standings {
+			standings[st.TeamID] = st
+		}
+
+		for _, m := range l.Managers {
+			key := managerKey(l.Platform, m)
+			fm, found := managers[key]
+			if !found {
+				fm = &model.FranchiseManager{ManagerName: m.ManagerName}
+				managers[key] = fm
+				order = append(order, key)
+			}
+
+			teamName := m.TeamName
+			if teamName == "" {
+				teamName = m.ManagerName
+			}
+			if !slices.Contains(fm.TeamNames, teamName) {
+				fm.TeamNames = append(fm.TeamNames, teamName)
+			}
+			fm.Seasons = append(fm.Seasons, l.Year)
+
+			st := standings[m.ExternalID]
+			fm.Wins += st.Wins
+			fm.Losses += st.Losses
+			fm.Draws += st.Draws
+
+			if s.ChampionID != "" && s.ChampionID == m.ExternalID {
+				s.ChampionName = teamName
+				fm.Championships++
+			}
+		}
+
+		h.Seasons = append(h.Seasons, s)
+	}
+
+	h.Managers = make([]model.FranchiseManager, 0, len(order))
+	for _, key := range order {
+		h.Managers = append(h.Managers, *managers[key])
+	}
+	slices.SortStableFunc(h.Managers, func(a, b model.FranchiseManager) int {
+		if len(a.Seasons) != len(b.Seasons) {
+			return cmp.Compare(len(b.Seasons), len(a.Seasons))
+		}
+		return cmp.Compare(b.Wins, a.Wins)
+	})
+
+	return h, nil
+}
+
+// Get a key that identifies the person managing a team across seasons. Sleeper
+// uses the user id for the external id, but the other platforms use a team id
+// that can change between seasons so fall back to the manager's name.
+func managerKey(platform string, m model.LeagueManager) string {
+	if platform == model.PlatformSleeper {
+		return m.ExternalID
+	}
+	return m.ManagerName
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestDiscoverFranchise(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2023", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	franchiseID, err := ctrl.DiscoverFranchise(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error discovering franchise: %v", err)
+	}
+	f, err := ctrl.GetLeagueFranchise(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting league franchise: %v", err)
+	}
+	defer func() {
+		for _, s := range f.Leagues {
+			ctrl.ArchiveLeague(ctx, s.ID)
+		}
+	}()
+
+	if f.ID != franchiseID || f.Name != "Footclan & Friends Dynasty" || len(f.Leagues) != 2 {
+		t.Fatalf("unexpected franchise: %v", f)
+	}
+	prev := f.Leagues[0]
+	if prev.ExternalID != testutils.SleeperPreviousLeagueID || prev.Year != "2022" || f.Leagues[1].ID != l.ID {
+		t.Errorf("unexpected franchise leagues: %v", f.Leagues)
+	}
+
+	// Discovering again should reuse the franchise and the previous season
+	again, err := ctrl.DiscoverFranchise(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error discovering franchise a second time: %v", err)
+	}
+	f, err = ctrl.GetLeagueFranchise(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting league franchise: %v", err)
+	}
+	if again != franchiseID || len(f.Leagues) != 2 || f.Leagues[0].ID != prev.ID {
+		t.Errorf("expected the same franchise, got: %v", f)
+	}
+
+	h, err := ctrl.GetFranchiseHistory(ctx, franchiseID)
+	if err != nil {
+		t.Fatalf("error getting franchise history: %v", err)
+	}
+	if len(h.Seasons) != 2 {
+		t.Fatalf("expected 2 seasons, got: %v", h.Seasons)
+	}
+	if h.Seasons[0].Year != "2023" || h.Seasons[0].ChampionName != "Jolly Roger" {
+		t.Errorf("unexpected 2023 season: %v", h.Seasons[0])
+	}
+	if h.Seasons[1].Year != "2022" || h.Seasons[1].ChampionName != "Puk Nukem" {
+		t.Errorf("unexpected 2022 season: %v", h.Seasons[1])
+	}
+
+	// The same managers played both seasons
+	if len(h.Managers) != 4 {
+		t.Fatalf("expected 4 managers, got: %v", h.Managers)
+	}
+	expected := model.FranchiseManager{
+		ManagerName:   "Jollymon",
+		TeamNames:     []string{"Jolly Roger"},
+		Seasons:       []string{"2023", "2022"},
+		Wins:          48,
+		Losses:        8,
+		Championships: 1,
+	}
+	if !reflect.DeepEqual(expected, h.Managers[0]) {
+		t.Errorf("expected: %v, got: %v", expected, h.Managers[0])
+	}
+}
+
+func TestLinkLeagueToFranchise(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	l := setupTest(t, ctx, testCtrl.Clock)
+	defer func() {
+		ctrl.ArchiveLeague(ctx, l.ID)
+	}()
+
+	// Yahoo leagues can't be discovered, they have to be linked manually
+	if _, err := ctrl.DiscoverFranchise(ctx, l.ID); !errors.Is(err, errPreviousLeagueNotSupported) {
+		t.Errorf("expected errPreviousLeagueNotSupported, got: %v", err)
+	}
+
+	f, err := ctrl.GetLeagueFranchise(ctx, l.ID)
+	if err != nil || f != nil {
+		t.Errorf("expected league to not be part of a franchise, got: %v, err: %v", f, err)
+	}
+
+	franchiseID, err := ctrl.LinkLeagueToFranchise(ctx, l.ID, 0)
+	if err != nil {
+		t.Fatalf("error linking league to a new franchise: %v", err)
+	}
+
+	prev := &model.League{
+		Platform:   model.PlatformYahoo,
+		ExternalID: testutils.YahooLeagueID,
+		Name:       "Fake Yahoo League",
+		Year:       "2023",
+	}
+	if err := testDB.DB.AddLeague(ctx, prev); err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		ctrl.ArchiveLeague(ctx, prev.ID)
+	}()
+
+	if id, err := ctrl.LinkLeagueToFranchise(ctx, prev.ID, franchiseID); err != nil || id != franchiseID {
+		t.Fatalf("error linking league to franchise, got: %d, err: %v", id, err)
+	}
+
+	f, err = ctrl.GetLeagueFranchise(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting league franchise: %v", err)
+	}
+	if f.Name != "Fake Yahoo League" || len(f.Leagues) != 2 || f.Leagues[0].ID != prev.ID {
+		t.Errorf("unexpected franchise: %v", f)
+	}
+
+	if _, err := ctrl.LinkLeagueToFranchise(ctx, l.ID, franchiseID+1000); err == nil {
+		t.Error("expected an error linking to a franchise that doesn't exist")
+	}
+}
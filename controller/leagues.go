@@ -12,12 +12,12 @@ import (
 
 const yearOnlyFormat = "2006"
 
-func (c *controller) GetLeaguesFromPlatform(ctx context.Context, username, platform, year string) ([]model.League, error) {
+func (c *controller) GetLeaguesFromPlatform(ctx context.Context, username, platform, year, stateToken string) ([]model.League, error) {
 	if _, err := time.Parse(yearOnlyFormat, year); err != nil {
 		return nil, fmt.Errorf("year parameter must be in the YYYY format, got: %s", year)
 	}
 
-	return getPlatformAdapter(platform, c).getLeagues(username, year)
+	return getPlatformAdapter(platform, c).getLeagues(ctx, username, year, stateToken)
 }
 
 func (c *controller) AddLeague(ctx context.Context, platform, externalID, year, stateToken string) (*model.League, error) {
@@ -34,15 +34,15 @@ func (c *controller) AddLeague(ctx context.Context, platform, externalID, year,
 		return nil, errors.New("externalID must be provided")
 	}
 
-	name, err := adapter.getLeagueName(ctx, externalID, stateToken)
-	if err != nil {
-		return nil, fmt.Errorf("league name not found: %w", err)
-	}
-
 	if _, err := time.Parse(yearOnlyFormat, year); err != nil {
 		return nil, fmt.Errorf("year parameter must be in the YYYY format, got: %s", year)
 	}
 
+	name, err := adapter.getLeagueName(ctx, externalID, year, stateToken)
+	if err != nil {
+		return nil, fmt.Errorf("league name not found: %w", err)
+	}
+
 	l := &model.League{
 		Platform:   platform,
 		ExternalID: externalID,
@@ -121,6 +121,7 @@ func (c *controller) SyncResultsFromPlatform(ctx context.Context, leagueID int32
 		return fmt.Errorf("error getting matchup results: %w", err)
 	}
 
+	model.SetAllPlayRecords(matchups)
 	if err := c.db.SaveResults(ctx, l.ID, matchups); err != nil {
 		return fmt.Errorf("error saving matchup results: %w", err)
 	}
@@ -129,6 +130,23 @@ func (c *controller) SyncResultsFromPlatform(ctx context.Context, leagueID int32
 		return fmt.Errorf("error saving player scores: %w", err)
 	}
 
+	transactions, err := getPlatformAdapter(l.Platform, c).getTransactions(ctx, l, week)
+	if err != nil && !errors.Is(err, errTransactionsNotSupported) {
+		return fmt.Errorf("error getting transactions: %w", err)
+	}
+	if err == nil {
+		if err := c.db.SaveTransactions(ctx, l.ID, transactions); err != nil {
+			return fmt.Errorf("error saving transactions: %w", err)
+		}
+	}
+
+	// The ratings are updated last so a failure doesn't stop the transactions
+	// from being saved. They are rebuilt from all of the results, so syncing
+	// again fixes them.
+	if err := c.updateEloRatings(ctx, l.ID); err != nil {
+		return fmt.Errorf("error updating elo ratings: %w", err)
+	}
+
 	return nil
 }
 
@@ -140,6 +158,35 @@ func (c *controller) GetLeagueResults(ctx context.Context, leagueID int32, week
 	return c.db.GetResults(ctx, leagueID, week)
 }
 
+// Load the results for every week that has been synced, keyed by week. Also
+// returns the last week with results, or 0 if there aren't any.
+func (c *controller) getAllResults(ctx context.Context, leagueID int32) (map[int][]model.Matchup, int, error) {
+	weeks, err := c.db.ListResultWeeks(ctx, leagueID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing result weeks: %w", err)
+	}
+
+	lastWeek := 0
+	weeklyResults := make(map[int][]model.Matchup)
+	for _, w := range weeks {
+		results, err := c.db.GetResults(ctx, leagueID, w)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error getting results for week %d: %w", w, err)
+		}
+		weeklyResults[w] = results
+		lastWeek = max(lastWeek, w)
+	}
+	return weeklyResults, lastWeek, nil
+}
+
+func (c *controller) GetLeagueTransactions(ctx context.Context, leagueID int32, week int) ([]model.Transaction, error) {
+	return c.db.GetTransactions(ctx, leagueID, week)
+}
+
+func (c *controller) ListLeagueTransactions(ctx context.Context, leagueID int32) ([]model.Transaction, error) {
+	return c.db.ListTransactions(ctx, leagueID)
+}
+
 func (c *controller) GetLeagueStandings(ctx context.Context, leagueID int32) ([]model.LeagueStanding, error) {
 	l, err := c.db.GetLeague(ctx, leagueID)
 	if err != nil {
@@ -160,7 +207,7 @@ func (c *controller) GetLeagueStandings(ctx context.Context, leagueID int32) ([]
 		nameMap[t.ExternalID] = name
 	}
 
-	standings, err := getPlatformAdapter(l.Platform, c).getLeagueStandings(ctx, l.ExternalID)
+	standings, err := getPlatformAdapter(l.Platform, c).getLeagueStandings(ctx, l)
 	if err != nil {
 		return nil, fmt.Errorf("error getting league standings: %w", err)
 	}
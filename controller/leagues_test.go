@@ -36,7 +36,7 @@ func TestGetLeaguesFromPlatform(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			leagues, err := ctrl.GetLeaguesFromPlatform(ctx, tc.username, tc.platform, tc.year)
+			leagues, err := ctrl.GetLeaguesFromPlatform(ctx, tc.username, tc.platform, tc.year, "")
 			if tc.exErrMsg == "" {
 				if !reflect.DeepEqual(tc.exLeagues, leagues) {
 					t.Errorf("leagues are not as expected, got: %v", leagues)
@@ -266,3 +266,58 @@ func TestGetLeagueStandings(t *testing.T) {
 		t.Errorf("expected: %v, got: %v", expected, standings)
 	}
 }
+
+func TestSyncTransactionsFromPlatform(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error adding players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		ctrl.ArchiveLeague(ctx, l.ID)
+	}()
+
+	l, err = ctrl.AddLeagueManagers(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, 2); err != nil {
+		t.Fatalf("error syncing league results: %v", err)
+	}
+
+	transactions, err := ctrl.GetLeagueTransactions(ctx, l.ID, 2)
+	if err != nil {
+		t.Fatalf("error loading transactions: %v", err)
+	}
+
+	expected := []string{
+		"Puk Nukem claimed Andrei Iosivas for $17 and dropped Zach Ertz",
+		"No-Bell Prizes added Luke Schoonmaker",
+		"gee17 dropped Cedric Tillman",
+		"Trade: Puk Nukem receives Ameer Abdullah; Jolly Roger receives Zay Jones, 2025 round 2 pick, $10 FAAB",
+	}
+	summaries := make([]string, 0, len(transactions))
+	for _, tr := range transactions {
+		summaries = append(summaries, tr.Summary())
+	}
+	if !reflect.DeepEqual(expected, summaries) {
+		t.Errorf("expected: %v, got: %v", expected, summaries)
+	}
+
+	all, err := ctrl.ListLeagueTransactions(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error listing transactions: %v", err)
+	}
+	if len(all) != len(expected) {
+		t.Errorf("expected %d transactions for the season, got: %d", len(expected), len(all))
+	}
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+type mflAdapter struct {
+	c *controller
+}
+
+// MFL leagues are listed for the user that owns the configured api key.
+func (a *mflAdapter) getLeagues(ctx context.Context, user, year, stateToken string) ([]model.League, error) {
+	return a.c.mfl.GetLeaguesForUser(year)
+}
+
+func (a *mflAdapter) getLeagueName(ctx context.Context, leagueID, year, stateToken string) (string, error) {
+	return a.c.mfl.GetLeagueName(leagueID, year)
+}
+
+func (a *mflAdapter) getManagers(ctx context.Context, l *model.League) ([]model.LeagueManager, error) {
+	managers, err := a.c.mfl.GetLeagueManagers(l.ExternalID, l.Year)
+	if err != nil {
+		return nil, fmt.Errorf("error loading managers from mfl for %s: %w", l.ExternalID, err)
+	}
+	return managers, nil
+}
+
+func (a *mflAdapter) sortManagers(m []model.LeagueManager) {
+	a.c.mfl.SortManagers(m)
+}
+
+func (a *mflAdapter) getMatchupResults(ctx context.Context, l *model.League, week int) ([]model.Matchup, []model.PlayerScore, error) {
+	matchups, scores, err := a.c.mfl.GetMatchupResults(l.ExternalID, l.Year, week)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	players := make([]model.MFLPlayer, 0, len(scores))
+	for _, s := range scores {
+		players = append(players, s.Player)
+	}
+	ids, err := a.c.db.ConvertMFLPlayerIDs(ctx, players)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	playerScores := make([]model.PlayerScore, 0, len(scores))
+	for i, s := range scores {
+		playerScores = append(playerScores, model.PlayerScore{PlayerID: ids[i], Score: s.Score})
+	}
+	return matchups, playerScores, nil
+}
+
+func (a *mflAdapter) getRosters(ctx context.Context, l *model.League) ([]model.Roster, error) {
+	rosters, err := a.c.mfl.GetRosters(l.ExternalID, l.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.Roster, 0, len(rosters))
+	for _, r := range rosters {
+		ids, err := a.c.db.ConvertMFLPlayerIDs(ctx, r.Players)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Roster{TeamID: r.TeamID, PlayerIDs: ids})
+	}
+	return results, nil
+}
+
+func (a *mflAdapter) getStarters(ctx context.Context, l *model.League) ([]model.RosterSpot, error) {
+	return a.c.mfl.GetStarters(l.ExternalID, l.Year)
+}
+
+func (a *mflAdapter) getLeagueStandings(ctx context.Context, l *model.League) ([]model.LeagueStanding, error) {
+	return a.c.mfl.GetLeagueStandings(l.ExternalID, l.Year)
+}
+
+func (a *mflAdapter) getTransactions(ctx context.Context, l *model.League, week int) ([]model.Transaction, error) {
+	return nil, errTransactionsNotSupported
+}
+
+func (a *mflAdapter) getDrafts(ctx context.Context, l *model.League) ([]model.Draft, error) {
+	return nil, errDraftsNotSupported
+}
+
+func (a *mflAdapter) getPreviousLeague(ctx context.Context, l *model.League) (*model.League, error) {
+	return nil, errPreviousLeagueNotSupported
+}
+
+func (a *mflAdapter) getChampion(ctx context.Context, l *model.League) (string, error) {
+	return "", nil
+}
+
+func (a *mflAdapter) getSchedule(ctx context.Context, l *model.League, week int) ([]model.Matchup, error) {
+	return nil, errScheduleNotSupported
+}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+// The fake ESPN, MFL and Fleaflicker servers serve the same league with each
+// platform's ids, so their adapters are run through the same tests.
+var platformAdapterTests = []struct {
+	name     string
+	league   model.League
+	user     string // Who the leagues are listed for
+	leagues  int
+	managers []string // The team names, in the order the managers are returned
+	// The ids of the managers before and after sorting
+	unsorted   []string
+	sorted     []string
+	firstScore model.PlayerScore
+	rosters    []model.Roster
+	firstPlace string
+}{
+	{
+		name:       "espn",
+		league:     model.League{Platform: model.PlatformESPN, ExternalID: testutils.ESPNLeagueID, Name: "ESPN Fantasy Friends", Year: "2024"},
+		leagues:    2,
+		managers:   []string{"Hurts So Good", "Tucker Time", "Lockett Down", "Fourth Place Forever"},
+		unsorted:   []string{"3", "12", "1"},
+		sorted:     []string{"1", "3", "12"},
+		firstScore: model.PlayerScore{PlayerID: "2374", Score: 11400},
+		rosters: []model.Roster{
+			{TeamID: "1", PlayerIDs: []string{"6904", "9509", "SEA"}},
+			{TeamID: "2", PlayerIDs: []string{"1264", "2216", "1466"}},
+			{TeamID: "3", PlayerIDs: []string{"2374", "1379", "10222"}},
+			{TeamID: "4", PlayerIDs: []string{"1166", "2449", "3214"}},
+		},
+		firstPlace: "3",
+	},
+	{
+		name:       "mfl",
+		league:     model.League{Platform: model.PlatformMFL, ExternalID: testutils.MFLLeagueID, Name: "MFL Dynasty League", Year: "2024"},
+		leagues:    1,
+		managers:   []string{"Dynasty Dawgs", "Kicking It", "Lockett Smith", "Bottom Feeders"},
+		unsorted:   []string{"0003", "0012", "0001"},
+		sorted:     []string{"0001", "0003", "0012"},
+		firstScore: model.PlayerScore{PlayerID: "6904", Score: 24560},
+		rosters: []model.Roster{
+			{TeamID: "0001", PlayerIDs: []string{"6904", "9509", "SEA"}},
+			{TeamID: "0002", PlayerIDs: []string{"1264", "2216", "1466"}},
+			{TeamID: "0003", PlayerIDs: []string{"2374", "1379", "10222"}},
+			{TeamID: "0004", PlayerIDs: []string{"1166", "2449", "3214"}},
+		},
+		firstPlace: "0003",
+	},
+	{
+		name:       "fleaflicker",
+		league:     model.League{Platform: model.PlatformFleaflicker, ExternalID: testutils.FleaflickerLeagueID, Name: "Fleaflicker Friends League", Year: "2024"},
+		user:       testutils.FleaflickerEmail,
+		leagues:    2,
+		managers:   []string{"Flea Flickers", "Kicker Kings", "Bench Warmers", "Cellar Dwellers"},
+		unsorted:   []string{"1744003", "1744012", "1744001"},
+		sorted:     []string{"1744001", "1744003", "1744012"},
+		firstScore: model.PlayerScore{PlayerID: "6904", Score: 24560},
+		rosters: []model.Roster{
+			{TeamID: "1744001", PlayerIDs: []string{"6904", "9509", "SEA"}},
+			{TeamID: "1744002", PlayerIDs: []string{"1264", "2216", "1466"}},
+			{TeamID: "1744003", PlayerIDs: []string{"2374", "1379", "10222"}},
+			{TeamID: "1744004", PlayerIDs: []string{"1166", "2449", "3214"}},
+		},
+		firstPlace: "1744003",
+	},
+}
+
+func TestPlatformAdapterGetLeagues(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			leagues, err := adapter.getLeagues(context.Background(), tc.user, "2024", "")
+			if err != nil {
+				t.Fatalf("unexpected error getting leagues: %v", err)
+			}
+			if len(leagues) != tc.leagues || leagues[0].ExternalID != tc.league.ExternalID {
+				t.Errorf("leagues did not match expected value: %v", leagues)
+			}
+		})
+	}
+}
+
+func TestPlatformAdapterGetLeagueName(t *testing.T) {
+	ctx := context.Background()
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			name, err := adapter.getLeagueName(ctx, tc.league.ExternalID, "2024", "")
+			if err != nil {
+				t.Fatalf("unexpected error getting league name: %v", err)
+			}
+			if name != tc.league.Name {
+				t.Errorf("league name was not expected value: %s", name)
+			}
+
+			if _, err := adapter.getLeagueName(ctx, tc.league.ExternalID, "2023", ""); err == nil {
+				t.Errorf("expected an error for a season that does not exist")
+			}
+		})
+	}
+}
+
+func TestPlatformAdapterGetManagers(t *testing.T) {
+	ctx := context.Background()
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			l := tc.league
+			managers, err := adapter.getManagers(ctx, &l)
+			if err != nil {
+				t.Fatalf("unexpected error getting managers: %v", err)
+			}
+			if len(managers) != len(tc.managers) {
+				t.Fatalf("expected %d managers, got %d", len(tc.managers), len(managers))
+			}
+			for i := range managers {
+				if managers[i].TeamName != tc.managers[i] {
+					t.Errorf("expected managers[%d].TeamName to be %s, but was %s", i, tc.managers[i], managers[i].TeamName)
+				}
+			}
+
+			l.ExternalID = "987"
+			if _, err := adapter.getManagers(ctx, &l); err == nil {
+				t.Errorf("expected an error for an unknown league")
+			}
+		})
+	}
+}
+
+func TestPlatformAdapterSortManagers(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			managers := make([]model.LeagueManager, 0, len(tc.unsorted))
+			for _, id := range tc.unsorted {
+				managers = append(managers, model.LeagueManager{ExternalID: id})
+			}
+
+			adapter.sortManagers(managers)
+			ids := make([]string, 0, len(managers))
+			for _, m := range managers {
+				ids = append(ids, m.ExternalID)
+			}
+			if !reflect.DeepEqual(tc.sorted, ids) {
+				t.Errorf("expected: %v, got: %v", tc.sorted, ids)
+			}
+		})
+	}
+}
+
+func TestPlatformAdapterGetMatchupResults(t *testing.T) {
+	ctx := context.Background()
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			matchups, scores, err := adapter.getMatchupResults(ctx, &tc.league, 1)
+			if err != nil {
+				t.Fatalf("unexpected error in getMatchupResults: %v", err)
+			}
+			if len(matchups) != 2 {
+				t.Errorf("expected 2 matchups, got %d", len(matchups))
+			}
+			if len(scores) != 12 {
+				t.Fatalf("expected 12 player scores, got %d", len(scores))
+			}
+			if !reflect.DeepEqual(tc.firstScore, scores[0]) {
+				t.Errorf("expected: %v, got: %v", tc.firstScore, scores[0])
+			}
+		})
+	}
+}
+
+func TestPlatformAdapterGetRosters(t *testing.T) {
+	ctx := context.Background()
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			rosters, err := adapter.getRosters(ctx, &tc.league)
+			if err != nil {
+				t.Fatalf("unexpected error getting %s rosters: %v", tc.name, err)
+			}
+			if !reflect.DeepEqual(tc.rosters, rosters) {
+				t.Errorf("expected: %v, got: %v", tc.rosters, rosters)
+			}
+		})
+	}
+}
+
+func TestPlatformAdapterGetStarters(t *testing.T) {
+	ctx := context.Background()
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			starters, err := adapter.getStarters(ctx, &tc.league)
+			if err != nil {
+				t.Fatalf("unexpected error getting starters: %v", err)
+			}
+			if len(starters) != 9 {
+				t.Errorf("expected 9 starters, got %d: %v", len(starters), starters)
+			}
+		})
+	}
+}
+
+func TestPlatformAdapterGetLeagueStandings(t *testing.T) {
+	ctx := context.Background()
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	for _, tc := range platformAdapterTests {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := getPlatformAdapter(tc.league.Platform, ctrl.(*controller))
+			standings, err := adapter.getLeagueStandings(ctx, &tc.league)
+			if err != nil {
+				t.Fatalf("unexpected error getting standings: %v", err)
+			}
+			if len(standings) != 4 || standings[0].TeamID != tc.firstPlace {
+				t.Errorf("standings did not match expected value: %v", standings)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// The names of the components are saved with each score, so they shouldn't be
+// changed once they have been used. schema.sql also uses them to copy the scores
+// of power rankings saved before the components were added.
+const (
+	componentRoster        = "Roster"
+	componentPointsFor     = "Points For"
+	componentPointsAgainst = "Points Against"
+	componentRecord        = "Record"
+	componentStreak        = "Streak"
+	componentAllPlay       = "All-Play"
+	componentSchedule      = "Schedule"
+)
+
+// A powerRankingComponent calculates one part of each team's power ranking
+// score. A team's total score is the sum of the scores from every component.
+type powerRankingComponent interface {
+	name() string
+	// Optional components can be turned off in the league's config.
+	enabled(cfg *model.PowerRankingConfig) bool
+	// Get the score for each team, keyed by team id. Teams without a score get 0.
+	score(in *powerRankingInput) map[string]int32
+}
+
+// Everything a component might need to calculate a score.
+type powerRankingInput struct {
+	// The power ranking being calculated. The teams have their rosters sorted by
+	// the player rankings and the config has the weights to use.
+	pr            *model.PowerRanking
+	rosters       []model.Roster
+	ranking       *model.Ranking
+	starters      []model.RosterSpot
+	weeklyResults map[int][]model.Matchup // Results for every week up to and including week
+	week          int
+}
+
+// componentFunc turns a function into a powerRankingComponent. If isEnabled
+// is nil the component is always used.
+type componentFunc struct {
+	n         string
+	f         func(in *powerRankingInput) map[string]int32
+	isEnabled func(cfg *model.PowerRankingConfig) bool
+}
+
+func (c componentFunc) name() string {
+	return c.n
+}
+
+func (c componentFunc) enabled(cfg *model.PowerRankingConfig) bool {
+	return c.isEnabled == nil || c.isEnabled(cfg)
+}
+
+func (c componentFunc) score(in *powerRankingInput) map[string]int32 {
+	return c.f(in)
+}
+
+// The components used to calculate power rankings, in the order they are
+// calculated and displayed. New components only need to be added here.
+var powerRankingComponents = []powerRankingComponent{
+	componentFunc{n: componentRoster, f: func(in *powerRankingInput) map[string]int32 {
+		return calculateRosterScores(in.pr, in.starters)
+	}},
+	componentFunc{n: componentPointsFor, f: func(in *powerRankingInput) map[string]int32 {
+		pointsFor, _ := calculateFantasyPointsScore(in.pr, in.weeklyResults, in.week)
+		return pointsFor
+	}},
+	componentFunc{n: componentPointsAgainst, f: func(in *powerRankingInput) map[string]int32 {
+		_, pointsAgainst := calculateFantasyPointsScore(in.pr, in.weeklyResults, in.week)
+		return pointsAgainst
+	}},
+	componentFunc{n: componentRecord, f: func(in *powerRankingInput) map[string]int32 {
+		return calculateRecordScore(in.pr, in.weeklyResults, in.week)
+	}},
+	componentFunc{n: componentStreak, f: func(in *powerRankingInput) map[string]int32 {
+		return calculateStreakScore(in.pr, in.weeklyResults, in.week)
+	}},
+	componentFunc{
+		n: componentAllPlay,
+		f: func(in *powerRankingInput) map[string]int32 {
+			return calculateAllPlayScore(in.pr, in.weeklyResults, in.week)
+		},
+		isEnabled: func(cfg *model.PowerRankingConfig) bool { return cfg.AllPlayPoints > 0 },
+	},
+	componentFunc{
+		n: componentSchedule,
+		f: func(in *powerRankingInput) map[string]int32 {
+			return calculateScheduleScore(in.pr, in.weeklyResults, in.week)
+		},
+		isEnabled: func(cfg *model.PowerRankingConfig) bool { return cfg.SchedulePoints > 0 },
+	},
+}
+
+// Run each of the enabled components and save the scores with every team.
+func calculateComponentScores(in *powerRankingInput, components []powerRankingComponent) {
+	pr := in.pr
+	pr.Components = make([]string, 0, len(components))
+	for i := range pr.Teams {
+		pr.Teams[i].Scores = make(map[string]int32, len(components))
+	}
+
+	for _, c := range components {
+		if !c.enabled(&pr.Config) {
+			continue
+		}
+		scores := c.score(in)
+		pr.Components = append(pr.Components, c.name())
+		for i := range pr.Teams {
+			pr.Teams[i].Scores[c.name()] = scores[pr.Teams[i].TeamID]
+		}
+	}
+}
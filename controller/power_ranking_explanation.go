@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func (c *controller) GetPowerRankingExplanation(ctx context.Context, leagueID, powerRankingID int32) (*model.PowerRankingExplanation, error) {
+	pr, err := c.GetPowerRanking(ctx, leagueID, powerRankingID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting power ranking %d: %w", powerRankingID, err)
+	}
+
+	// Compare to the same power ranking the rank changes were calculated from, or if
+	// there wasn't one the most recent one for an earlier week.
+	prevID := pr.CompareID
+	if prevID == 0 {
+		// Ignore any errors from listing power rankings since it isn't required
+		list, _ := c.ListPowerRankings(ctx, leagueID)
+		prevID = previousPowerRankingID(list, int(pr.Week), pr.ID)
+	}
+	var prev *model.PowerRanking
+	if prevID != 0 {
+		prev, err = c.GetPowerRanking(ctx, leagueID, prevID)
+		if err != nil {
+			log.Printf("error getting previous power ranking %d for league %d: %v", prevID, leagueID, err)
+		}
+	}
+
+	weeklyResults, _, err := c.getAllResults(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	return explainPowerRanking(pr, prev, weeklyResults), nil
+}
+
+// Build the explanation of a saved power ranking. The component scores and the
+// rosters come from what was saved with it, the record, streak and points are
+// recalculated from the results through the power ranking's week. prev can be nil.
+func explainPowerRanking(pr, prev *model.PowerRanking, weeklyResults map[int][]model.Matchup) *model.PowerRankingExplanation {
+	e := &model.PowerRankingExplanation{
+		PowerRanking: pr,
+		Teams:        make([]model.TeamExplanation, 0, len(pr.Teams)),
+	}
+
+	prevTeams := make(map[string]*model.TeamPowerRanking)
+	if prev != nil {
+		e.PreviousID = prev.ID
+		e.PreviousWeek = prev.Week
+		for i := range prev.Teams {
+			prevTeams[prev.Teams[i].TeamID] = &prev.Teams[i]
+		}
+	}
+
+	week := int(pr.Week)
+	for _, t := range pr.Teams {
+		te := model.TeamExplanation{
+			TeamID:     t.TeamID,
+			TeamName:   t.TeamName,
+			Rank:       t.Rank,
+			RankChange: t.RankChange,
+			TotalScore: t.TotalScore,
+			Components: make([]model.ComponentExplanation, 0, len(pr.Components)),
+		}
+
+		p, hasPrevious := prevTeams[t.TeamID]
+		if hasPrevious {
+			te.TotalChange = t.TotalScore - p.TotalScore
+		}
+		for _, name := range pr.Components {
+			ce := model.ComponentExplanation{Name: name, Score: t.Scores[name]}
+			if hasPrevious {
+				if s, found := p.Scores[name]; found {
+					ce.Previous = s
+					ce.Change = ce.Score - s
+					ce.HasPrevious = true
+				}
+			}
+			te.Components = append(te.Components, ce)
+		}
+
+		for _, player := range t.Roster {
+			if player.IsStarter {
+				te.Starters = append(te.Starters, player)
+				te.StarterValue += player.PowerRankingPoints
+			} else {
+				te.Bench = append(te.Bench, player)
+				te.BenchValue += player.PowerRankingPoints
+			}
+		}
+
+		te.Wins, te.Losses, te.Draws = getRecord(t.TeamID, weeklyResults, week)
+		te.Streak, _ = getStreak(t.TeamID, weeklyResults, week)
+		te.AveragePointsFor, te.AveragePointsAgainst = averagePoints(t.TeamID, weeklyResults, week, pr.Config.PointsWeeks)
+
+		e.Teams = append(e.Teams, te)
+	}
+	return e
+}
+
+// Get the team's average points for and against over the weeks before and including
+// week, rounded to two decimal places.
+func averagePoints(teamID string, weeklyResults map[int][]model.Matchup, week, weeks int) (float64, float64) {
+	var pointsFor, pointsAgainst, games int64
+	for w := week; w > max(week-weeks, 0); w-- {
+		for _, m := range weeklyResults[w] {
+			if m.TeamA == nil || m.TeamB == nil {
+				continue
+			}
+			if m.TeamA.TeamID == teamID {
+				pointsFor += int64(m.TeamA.Score)
+				pointsAgainst += int64(m.TeamB.Score)
+				games++
+			} else if m.TeamB.TeamID == teamID {
+				pointsFor += int64(m.TeamB.Score)
+				pointsAgainst += int64(m.TeamA.Score)
+				games++
+			}
+		}
+	}
+	if games == 0 {
+		return 0, 0
+	}
+	// The scores are points * 1000
+	round := func(total int64) float64 {
+		return math.Round(float64(total)/float64(games)/10) / 100
+	}
+	return round(pointsFor), round(pointsAgainst)
+}
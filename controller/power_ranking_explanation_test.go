@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestExplainPowerRanking(t *testing.T) {
+	pr, weeklyResults := getDataForTest()
+	pr.ID = 2
+	pr.Week = 5
+	pr.Components = []string{componentRoster, componentRecord}
+	for i := range pr.Teams {
+		pr.Teams[i].Rank = i + 1
+		pr.Teams[i].Scores = map[string]int32{componentRoster: int32(100 - i), componentRecord: int32(10 * i)}
+		pr.Teams[i].TotalScore = int32(100 + 9*i)
+	}
+	pr.Teams[0].Roster = []model.PowerRankingPlayer{
+		{PlayerID: "a", Rank: 1, PowerRankingPoints: 9800, IsStarter: true, RosterSpot: "QB"},
+		{PlayerID: "b", Rank: 2, PowerRankingPoints: 3800},
+		{PlayerID: "c", Rank: 3, PowerRankingPoints: 9500, IsStarter: true, RosterSpot: "RB/WR/TE"},
+	}
+
+	// Team 3 wasn't in the previous power ranking, and it didn't have the record component
+	prev := &model.PowerRanking{
+		ID:         1,
+		Week:       4,
+		Components: []string{componentRoster},
+		Teams: []model.TeamPowerRanking{
+			{TeamID: "1", TotalScore: 90, Scores: map[string]int32{componentRoster: 90}},
+			{TeamID: "2", TotalScore: 110, Scores: map[string]int32{componentRoster: 110}},
+			{TeamID: "4", TotalScore: 95, Scores: map[string]int32{componentRoster: 95}},
+		},
+	}
+
+	e := explainPowerRanking(pr, prev, weeklyResults)
+	if e.PowerRanking != pr || e.PreviousID != 1 || e.PreviousWeek != 4 || len(e.Teams) != 4 {
+		t.Fatalf("unexpected explanation: %v", e)
+	}
+
+	team := e.Teams[0]
+	expectedComponents := []model.ComponentExplanation{
+		{Name: componentRoster, Score: 100, Previous: 90, Change: 10, HasPrevious: true},
+		{Name: componentRecord, Score: 0},
+	}
+	if !reflect.DeepEqual(expectedComponents, team.Components) {
+		t.Errorf("expected components: %v, got: %v", expectedComponents, team.Components)
+	}
+	if team.TotalChange != 10 {
+		t.Errorf("expected a total change of 10, got: %d", team.TotalChange)
+	}
+	if len(team.Starters) != 2 || team.Starters[0].PlayerID != "a" || team.Starters[1].PlayerID != "c" {
+		t.Errorf("unexpected starters: %v", team.Starters)
+	}
+	if len(team.Bench) != 1 || team.Bench[0].PlayerID != "b" {
+		t.Errorf("unexpected bench: %v", team.Bench)
+	}
+	if team.StarterValue != 19300 || team.BenchValue != 3800 {
+		t.Errorf("expected a starter value of 19300 and a bench value of 3800, got: %d and %d", team.StarterValue, team.BenchValue)
+	}
+
+	// Team 1 won the first 3 weeks and lost the last 2
+	if team.Wins != 3 || team.Losses != 2 || team.Draws != 0 || team.Streak != -2 {
+		t.Errorf("expected a 3-2 record and a 2 game losing streak, got: %d-%d-%d and %d", team.Wins, team.Losses, team.Draws, team.Streak)
+	}
+	if team.AveragePointsFor != 100 || team.AveragePointsAgainst != 91 {
+		t.Errorf("expected to average 100 points for and 91 against, got: %f and %f", team.AveragePointsFor, team.AveragePointsAgainst)
+	}
+
+	team = e.Teams[2]
+	for _, c := range team.Components {
+		if c.HasPrevious || c.Change != 0 {
+			t.Errorf("expected no change for a team that wasn't in the previous power ranking, got: %v", c)
+		}
+	}
+	if team.TotalChange != 0 || team.Wins != 4 || team.Losses != 1 || team.Streak != 4 {
+		t.Errorf("unexpected explanation for team 3: %v", team)
+	}
+	if team.AveragePointsFor != 110 || team.AveragePointsAgainst != 100 {
+		t.Errorf("expected to average 110 points for and 100 against, got: %f and %f", team.AveragePointsFor, team.AveragePointsAgainst)
+	}
+
+	// Without a previous power ranking nothing has changed
+	e = explainPowerRanking(pr, nil, weeklyResults)
+	if e.PreviousID != 0 || e.Teams[0].TotalChange != 0 || e.Teams[0].Components[0].HasPrevious {
+		t.Errorf("expected no changes without a previous power ranking, got: %v", e.Teams[0])
+	}
+}
+
+func TestGetPowerRankingExplanation(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	ctx := context.Background()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding a new league: %v", err)
+	}
+	defer func() {
+		if err := ctrl.ArchiveLeague(ctx, l.ID); err != nil {
+			t.Fatalf("error archiving league: %v", err)
+		}
+	}()
+
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	rankingDate, err := time.ParseInLocation(time.DateOnly, "2018-09-03", time.UTC)
+	if err != nil {
+		t.Fatalf("error parsing ranking date: %v", err)
+	}
+	rankingID, err := ctrl.AddRanking(ctx, getRankingsData(), rankingDate, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding ranking: %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, i); err != nil {
+			t.Fatalf("error getting week %d results: %v", i, err)
+		}
+	}
+
+	week2, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, 2, 0 /* compareID */)
+	if err != nil {
+		t.Fatalf("error calculating week 2 power ranking: %v", err)
+	}
+	week3, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, 3, 0 /* compareID */)
+	if err != nil {
+		t.Fatalf("error calculating week 3 power ranking: %v", err)
+	}
+
+	e, err := ctrl.GetPowerRankingExplanation(ctx, l.ID, week3)
+	if err != nil {
+		t.Fatalf("error getting power ranking explanation: %v", err)
+	}
+	if e.PreviousID != week2 || e.PreviousWeek != 2 || len(e.Teams) != len(e.PowerRanking.Teams) {
+		t.Fatalf("unexpected explanation: %v", e)
+	}
+	for i, team := range e.Teams {
+		pr := e.PowerRanking.Teams[i]
+		if team.TeamID != pr.TeamID || team.TotalScore != pr.TotalScore || len(team.Components) != len(e.PowerRanking.Components) {
+			t.Errorf("expected the explanation to match the power ranking, got: %v", team)
+		}
+		if team.Wins+team.Losses+team.Draws != 3 {
+			t.Errorf("expected 3 games for team %s, got: %d-%d-%d", team.TeamID, team.Wins, team.Losses, team.Draws)
+		}
+		if len(team.Starters) == 0 || len(team.Starters)+len(team.Bench) != len(pr.Roster) {
+			t.Errorf("expected the roster to be split into starters and bench, got: %v and %v", team.Starters, team.Bench)
+		}
+		for _, p := range team.Starters {
+			if p.RosterSpot == "" {
+				t.Errorf("expected starter %s to have a roster spot", p.PlayerID)
+			}
+		}
+
+		var roster int32
+		for _, c := range team.Components {
+			if !c.HasPrevious {
+				t.Errorf("expected component %s to have a previous score for team %s", c.Name, team.TeamID)
+			}
+			if c.Name == componentRoster {
+				roster = c.Score
+			}
+		}
+		if roster != (team.StarterValue+team.BenchValue)/100 {
+			t.Errorf("expected the roster score to come from the starter and bench values, got: %d, %d and %d", roster, team.StarterValue, team.BenchValue)
+		}
+	}
+
+	// Week 2 was the first power ranking, so there isn't anything to compare it to
+	e, err = ctrl.GetPowerRankingExplanation(ctx, l.ID, week2)
+	if err != nil {
+		t.Fatalf("error getting power ranking explanation: %v", err)
+	}
+	if e.PreviousID != 0 || e.Teams[0].Components[0].HasPrevious {
+		t.Errorf("expected no previous power ranking for week 2, got: %v", e)
+	}
+
+	if _, err := ctrl.GetPowerRankingExplanation(ctx, l.ID, week3+1000); err == nil {
+		t.Errorf("expected an error explaining a power ranking that doesn't exist")
+	}
+}
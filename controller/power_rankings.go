@@ -1,9 +1,11 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log"
+	"maps"
 	"math"
 	"slices"
 
@@ -18,10 +20,212 @@ func (c *controller) GetPowerRanking(ctx context.Context, leagueID, powerRanking
 	return c.db.GetPowerRanking(ctx, leagueID, powerRankingID)
 }
 
-func (c *controller) CalculatePowerRanking(ctx context.Context, leagueID, rankingID int32, week int) (int32, error) {
+func (c *controller) GetPowerRankingConfig(ctx context.Context, leagueID int32) (*model.PowerRankingConfig, error) {
+	return c.db.GetPowerRankingConfig(ctx, leagueID)
+}
+
+func (c *controller) UpdatePowerRankingConfig(ctx context.Context, leagueID int32, cfg *model.PowerRankingConfig) error {
+	if err := validatePowerRankingConfig(cfg); err != nil {
+		return err
+	}
+	if _, err := c.db.GetLeague(ctx, leagueID); err != nil {
+		return fmt.Errorf("error getting league with id %d: %w", leagueID, err)
+	}
+	return c.db.SavePowerRankingConfig(ctx, leagueID, cfg)
+}
+
+func validatePowerRankingConfig(cfg *model.PowerRankingConfig) error {
+	if cfg.BenchPercent < 0 || cfg.BenchPercent > 100 {
+		return fmt.Errorf("bench percent must be between 0 and 100, got: %d", cfg.BenchPercent)
+	}
+	if cfg.PointsAgainstPercent < 0 || cfg.PointsAgainstPercent > 100 {
+		return fmt.Errorf("points against percent must be between 0 and 100, got: %d", cfg.PointsAgainstPercent)
+	}
+	if cfg.WinPoints < 0 || cfg.WinPoints > 100 {
+		return fmt.Errorf("win points must be between 0 and 100, got: %d", cfg.WinPoints)
+	}
+	if cfg.StreakPoints < 0 || cfg.StreakPoints > 100 {
+		return fmt.Errorf("streak points must be between 0 and 100, got: %d", cfg.StreakPoints)
+	}
+	if cfg.PointsWeeks < 1 || cfg.PointsWeeks > 18 {
+		return fmt.Errorf("points weeks must be between 1 and 18, got: %d", cfg.PointsWeeks)
+	}
+	if cfg.AllPlayPoints < 0 || cfg.AllPlayPoints > 100 {
+		return fmt.Errorf("all-play points must be between 0 and 100, got: %d", cfg.AllPlayPoints)
+	}
+	if cfg.SchedulePoints < 0 || cfg.SchedulePoints > 100 {
+		return fmt.Errorf("schedule points must be between 0 and 100, got: %d", cfg.SchedulePoints)
+	}
+	used := make(map[string]bool)
+	for _, tb := range cfg.TieBreaks {
+		if !slices.Contains(model.DefaultTieBreaks(), tb) {
+			return fmt.Errorf("unknown tie-break: %s", tb)
+		}
+		if used[tb] {
+			return fmt.Errorf("tie-break %s is used more than once", tb)
+		}
+		used[tb] = true
+	}
+	return nil
+}
+
+func (c *controller) CalculatePowerRanking(ctx context.Context, leagueID, rankingID int32, week int, compareID int32) (int32, error) {
+	cfg, err := c.db.GetPowerRankingConfig(ctx, leagueID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting power ranking config for league %d: %w", leagueID, err)
+	}
+
+	powerRanking, err := c.calculatePowerRanking(ctx, leagueID, rankingID, week, cfg, 0, compareID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := c.db.SavePowerRanking(ctx, leagueID, powerRanking)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (c *controller) PreviewPowerRanking(ctx context.Context, leagueID, rankingID int32, week int, compareID int32) (*model.PowerRanking, error) {
+	l, err := c.GetLeague(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting league with id %d: %w", leagueID, err)
+	}
+
+	cfg, err := c.db.GetPowerRankingConfig(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting power ranking config for league %d: %w", leagueID, err)
+	}
+
+	powerRanking, err := c.calculatePowerRanking(ctx, leagueID, rankingID, week, cfg, 0, compareID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Saved power rankings get the team names when they are loaded, so add them here
+	names := make(map[string]string)
+	for _, m := range l.Managers {
+		names[m.ExternalID] = m.TeamName
+		if m.TeamName == "" {
+			names[m.ExternalID] = m.ManagerName
+		}
+	}
+	for i := range powerRanking.Teams {
+		powerRanking.Teams[i].TeamName = names[powerRanking.Teams[i].TeamID]
+	}
+	return powerRanking, nil
+}
+
+func (c *controller) RecomputePowerRanking(ctx context.Context, leagueID, powerRankingID, rankingID int32, week int, compareID int32) error {
+	existing, err := c.GetPowerRanking(ctx, leagueID, powerRankingID)
+	if err != nil {
+		return fmt.Errorf("error getting power ranking %d: %w", powerRankingID, err)
+	}
+
+	powerRanking, err := c.calculatePowerRanking(ctx, leagueID, rankingID, week, &existing.Config, powerRankingID, compareID)
+	if err != nil {
+		return err
+	}
+	powerRanking.ID = powerRankingID
+
+	return c.db.ReplacePowerRanking(ctx, leagueID, powerRanking)
+}
+
+func (c *controller) DeletePowerRanking(ctx context.Context, leagueID, powerRankingID int32) error {
+	return c.db.DeletePowerRanking(ctx, leagueID, powerRankingID)
+}
+
+func (c *controller) GetPowerRankingHistory(ctx context.Context, leagueID int32) (*model.PowerRankingHistory, error) {
+	list, err := c.ListPowerRankings(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The list has the newest power ranking for each week first
+	ids := make(map[int]int32)
+	for _, pr := range list {
+		if _, found := ids[int(pr.Week)]; !found {
+			ids[int(pr.Week)] = pr.ID
+		}
+	}
+
+	rankings := make([]*model.PowerRanking, 0, len(ids))
+	for _, w := range slices.Sorted(maps.Keys(ids)) {
+		pr, err := c.GetPowerRanking(ctx, leagueID, ids[w])
+		if err != nil {
+			return nil, fmt.Errorf("error getting power ranking %d: %w", ids[w], err)
+		}
+		rankings = append(rankings, pr)
+	}
+	return powerRankingHistory(rankings), nil
+}
+
+// Build the rank history from power rankings sorted by week. The teams are sorted by
+// their most recent rank, with teams missing from the later power rankings last.
+func powerRankingHistory(rankings []*model.PowerRanking) *model.PowerRankingHistory {
+	h := &model.PowerRankingHistory{
+		Weeks:           make([]int, 0, len(rankings)),
+		PowerRankingIDs: make([]int32, 0, len(rankings)),
+	}
+
+	teams := make(map[string]*model.TeamRankHistory)
+	for i, pr := range rankings {
+		h.Weeks = append(h.Weeks, int(pr.Week))
+		h.PowerRankingIDs = append(h.PowerRankingIDs, pr.ID)
+		for _, t := range pr.Teams {
+			th, found := teams[t.TeamID]
+			if !found {
+				th = &model.TeamRankHistory{
+					TeamID:       t.TeamID,
+					Ranks:        make([]int, len(rankings)),
+					RosterValues: make([]int32, len(rankings)),
+				}
+				teams[t.TeamID] = th
+			}
+			th.TeamName = t.TeamName
+			th.Ranks[i] = t.Rank
+			for _, p := range t.Roster {
+				th.RosterValues[i] += p.PowerRankingPoints
+			}
+		}
+	}
+
+	// Get the last week the team was ranked and their rank that week
+	lastRank := func(t *model.TeamRankHistory) (int, int) {
+		for i := len(t.Ranks) - 1; i >= 0; i-- {
+			if t.Ranks[i] != 0 {
+				return i, t.Ranks[i]
+			}
+		}
+		return -1, 0
+	}
+	h.Teams = make([]model.TeamRankHistory, 0, len(teams))
+	for _, id := range slices.SortedFunc(maps.Keys(teams), func(a, b string) int {
+		weekA, rankA := lastRank(teams[a])
+		weekB, rankB := lastRank(teams[b])
+		if weekA != weekB {
+			return cmp.Compare(weekB, weekA)
+		}
+		if rankA != rankB {
+			return cmp.Compare(rankA, rankB)
+		}
+		return cmp.Compare(a, b)
+	}) {
+		h.Teams = append(h.Teams, *teams[id])
+	}
+	return h
+}
+
+// Calculate a power ranking without saving it. If the power ranking will replace a
+// saved one, replacingID is the id of the saved one so the ranks aren't compared to it.
+// The rank changes are calculated from the compareID power ranking, or if it is 0 the
+// most recent one for an earlier week.
+func (c *controller) calculatePowerRanking(ctx context.Context, leagueID, rankingID int32, week int, cfg *model.PowerRankingConfig, replacingID, compareID int32) (*model.PowerRanking, error) {
 	l, err := c.GetLeague(ctx, leagueID)
 	if err != nil {
-		return 0, fmt.Errorf("error getting league with id %d: %w", leagueID, err)
+		return nil, fmt.Errorf("error getting league with id %d: %w", leagueID, err)
 	}
 	log.Printf("calculating power ranking for league %d (%s)", l.ID, l.Name)
 
@@ -29,26 +233,36 @@ func (c *controller) CalculatePowerRanking(ctx context.Context, leagueID, rankin
 
 	rosters, err := adaptor.getRosters(ctx, l)
 	if err != nil {
-		return 0, fmt.Errorf("error getting league rosters: %w", err)
+		return nil, fmt.Errorf("error getting league rosters: %w", err)
 	}
 
 	ranking, err := c.GetRanking(ctx, rankingID)
 	if err != nil {
-		return 0, fmt.Errorf("error getting ranking with id %d: %w", rankingID, err)
+		return nil, fmt.Errorf("error getting ranking with id %d: %w", rankingID, err)
 	}
 
 	starters, err := adaptor.getStarters(ctx, l)
 	if err != nil {
-		return 0, fmt.Errorf("error getting starters list for league %d: %w", l.ID, err)
+		return nil, fmt.Errorf("error getting starters list for league %d: %w", l.ID, err)
 	}
 
-	// Ignore any errors from listing power rankings since it isn't required
 	var prev *model.PowerRanking
-	list, _ := c.ListPowerRankings(ctx, leagueID)
-	if len(list) > 0 {
-		prev, err = c.GetPowerRanking(ctx, leagueID, list[0].ID)
+	if compareID != 0 {
+		if compareID == replacingID {
+			return nil, fmt.Errorf("power ranking %d can't be compared to itself", compareID)
+		}
+		prev, err = c.GetPowerRanking(ctx, leagueID, compareID)
 		if err != nil {
-			log.Printf("error getting previous power ranking %d for league %d: %v", list[0].ID, leagueID, err)
+			return nil, fmt.Errorf("error getting power ranking %d to compare to: %w", compareID, err)
+		}
+	} else {
+		// Ignore any errors from listing power rankings since it isn't required
+		list, _ := c.ListPowerRankings(ctx, leagueID)
+		if id := previousPowerRankingID(list, week, replacingID); id != 0 {
+			prev, err = c.GetPowerRanking(ctx, leagueID, id)
+			if err != nil {
+				log.Printf("error getting previous power ranking %d for league %d: %v", id, leagueID, err)
+			}
 		}
 	}
 
@@ -62,34 +276,30 @@ func (c *controller) CalculatePowerRanking(ctx context.Context, leagueID, rankin
 		weeklyResults[w] = results
 	}
 
-	powerRanking := initializePowerRankings(rosters, ranking, week)
-	calculateRosterScores(powerRanking, starters)
-	calculateFantasyPointsScore(powerRanking, weeklyResults, week)
-	calculateRecordScore(powerRanking, weeklyResults, week)
-	calculateStreakScore(powerRanking, weeklyResults, week)
+	powerRanking := initializePowerRankings(rosters, ranking, week, cfg)
+	in := &powerRankingInput{
+		pr:            powerRanking,
+		rosters:       rosters,
+		ranking:       ranking,
+		starters:      starters,
+		weeklyResults: weeklyResults,
+		week:          week,
+	}
+	calculateComponentScores(in, powerRankingComponents)
 	sumFinalScore(powerRanking)
 
-	// Sort by score
-	slices.SortFunc(powerRanking.Teams, func(a, b model.TeamPowerRanking) int {
-		return int(b.TotalScore - a.TotalScore)
-	})
-	// Assign a rank value
-	for i := range powerRanking.Teams {
-		powerRanking.Teams[i].Rank = i + 1
-	}
+	rankTeams(powerRanking, weeklyResults, week)
 	calculateRankChange(powerRanking, prev)
 
-	id, err := c.db.SavePowerRanking(ctx, leagueID, powerRanking)
-	if err != nil {
-		return 0, err
-	}
-
-	return id, nil
+	return powerRanking, nil
 }
 
-func initializePowerRankings(rosters []model.Roster, ranking *model.Ranking, week int) *model.PowerRanking {
+// The config is saved with the power ranking, and all of the calculate functions
+// read their weights from it.
+func initializePowerRankings(rosters []model.Roster, ranking *model.Ranking, week int, cfg *model.PowerRankingConfig) *model.PowerRanking {
 	powerRanking := &model.PowerRanking{
 		RankingID: ranking.ID,
+		Config:    *cfg,
 		Teams:     make([]model.TeamPowerRanking, 0, len(rosters)),
 		Week:      int16(week),
 	}
@@ -120,19 +330,32 @@ func initializePowerRankings(rosters []model.Roster, ranking *model.Ranking, wee
 	return powerRanking
 }
 
-func calculateRosterScores(powerRanking *model.PowerRanking, starters []model.RosterSpot) {
+// Get the roster score for each team, and set the points for each player on the roster.
+func calculateRosterScores(powerRanking *model.PowerRanking, starters []model.RosterSpot) map[string]int32 {
+	scores := make(map[string]int32)
+	benchWeight := float64(powerRanking.Config.BenchPercent) / 100
+
+	// Fill the most restrictive roster spots first, so that a flex spot doesn't use
+	// a player that is needed for a spot only their position can fill.
+	spots := slices.Clone(starters)
+	slices.SortStableFunc(spots, func(a, b model.RosterSpot) int {
+		return cmp.Compare(len(a.Allowed), len(b.Allowed))
+	})
+
 	for i := range powerRanking.Teams {
+		score := int32(0)
 		usedPlayers := make(map[string]bool)
 		// Go through all the starters and select the highest ranked player on the roster that matches
 		// the roster spot and hasn't already been used.
-		for _, s := range starters {
+		for _, s := range spots {
 			for j, p := range powerRanking.Teams[i].Roster {
 				if s.IsAllowed(p.Position) {
 					if _, used := usedPlayers[p.PlayerID]; !used {
 						v := calculatePlayerValue(p.Rank)
 						powerRanking.Teams[i].Roster[j].PowerRankingPoints = v
 						powerRanking.Teams[i].Roster[j].IsStarter = true
-						powerRanking.Teams[i].RosterScore += v
+						powerRanking.Teams[i].Roster[j].RosterSpot = s.String()
+						score += v
 						usedPlayers[p.PlayerID] = true
 						break
 					}
@@ -143,18 +366,19 @@ func calculateRosterScores(powerRanking *model.PowerRanking, starters []model.Ro
 		// Once all the starters are selected, put the rest of the players on the bench
 		for j, p := range powerRanking.Teams[i].Roster {
 			if !powerRanking.Teams[i].Roster[j].IsStarter {
-				v := int32(float64(calculatePlayerValue(p.Rank)) * 0.4)
+				v := int32(float64(calculatePlayerValue(p.Rank)) * benchWeight)
 				powerRanking.Teams[i].Roster[j].PowerRankingPoints = v
-				powerRanking.Teams[i].RosterScore += v
+				score += v
 			}
 		}
 
-		powerRanking.Teams[i].RosterScore = powerRanking.Teams[i].RosterScore / 100
+		scores[powerRanking.Teams[i].TeamID] = score / 100
 	}
+	return scores
 }
 
 // Get the score for both points for and points against scored.
-func calculateFantasyPointsScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) {
+func calculateFantasyPointsScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) (map[string]int32, map[string]int32) {
 	type points struct {
 		pointsFor     int32
 		pointsAgainst int32
@@ -162,7 +386,7 @@ func calculateFantasyPointsScore(pr *model.PowerRanking, weeklyResults map[int][
 	}
 	data := make(map[string]*points)
 
-	stop := week - 3
+	stop := week - pr.Config.PointsWeeks
 	if stop < 0 {
 		stop = 0
 	}
@@ -194,106 +418,139 @@ func calculateFantasyPointsScore(pr *model.PowerRanking, weeklyResults map[int][
 		}
 	}
 
-	for i := range pr.Teams {
-		p, found := data[pr.Teams[i].TeamID]
+	pointsFor := make(map[string]int32)
+	pointsAgainst := make(map[string]int32)
+	pointsAgainstWeight := float64(pr.Config.PointsAgainstPercent) / 100
+	for _, t := range pr.Teams {
+		p, found := data[t.TeamID]
 		if !found {
-			log.Printf("did not find points data for team %s (%s)", pr.Teams[i].TeamID, pr.Teams[i].TeamName)
+			log.Printf("did not find points data for team %s (%s)", t.TeamID, t.TeamName)
 			continue
 		}
-		pr.Teams[i].PointsForScore = (p.pointsFor / p.matches)
-		pr.Teams[i].PointsAgainstScore = int32(math.Round(0.3 * float64(p.pointsAgainst/p.matches))) // take 30% points against
 
 		// Since we store points * 1000 in the DB, divid by 1000 here to get back to normal
-		pr.Teams[i].PointsForScore /= 1000
-		pr.Teams[i].PointsAgainstScore /= 1000
+		pointsFor[t.TeamID] = (p.pointsFor / p.matches) / 1000
+		pointsAgainst[t.TeamID] = int32(math.Round(pointsAgainstWeight*float64(p.pointsAgainst/p.matches))) / 1000
 	}
+	return pointsFor, pointsAgainst
 }
 
-func calculateRecordScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) {
-	for i := range pr.Teams {
-		t := pr.Teams[i]
+func calculateRecordScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) map[string]int32 {
+	scores := make(map[string]int32)
+	for _, t := range pr.Teams {
+		wins, losses, draws := getRecord(t.TeamID, weeklyResults, week)
+		log.Printf("team %s (%s) record: (%d-%d-%d)", t.TeamName, t.TeamID, wins, losses, draws)
+		scores[t.TeamID] = int32((wins - losses) * pr.Config.WinPoints)
+	}
+	return scores
+}
 
-		wins := 0
-		losses := 0
-		draws := 0
+// Get the team's wins, losses and draws from week 1 through week.
+func getRecord(teamID string, weeklyResults map[int][]model.Matchup, week int) (int, int, int) {
+	wins := 0
+	losses := 0
+	draws := 0
 
-		for w := 1; w <= week; w++ {
-			matchups, ok := weeklyResults[w]
-			if !ok {
-				continue
-			}
-			result := getMatchResult(t.TeamID, matchups)
-			switch result {
-			case 1:
-				wins++
-			case -1:
-				losses++
-			case 0:
-				draws++
-			default:
-				log.Printf("unexpected result for team %s in week %d", t.TeamID, w)
-			}
+	for w := 1; w <= week; w++ {
+		matchups, ok := weeklyResults[w]
+		if !ok {
+			continue
+		}
+		result := getMatchResult(teamID, matchups)
+		switch result {
+		case 1:
+			wins++
+		case -1:
+			losses++
+		case 0:
+			draws++
+		default:
+			log.Printf("unexpected result for team %s in week %d", teamID, w)
 		}
-
-		log.Printf("team %s (%s) record: (%d-%d-%d)", t.TeamName, t.TeamID, wins, losses, draws)
-		pr.Teams[i].RecordScore = int32((wins - losses) * 10)
 	}
+	return wins, losses, draws
 }
 
-func calculateStreakScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) {
-	currentWeek, ok := weeklyResults[week]
-	if !ok {
+func calculateStreakScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) map[string]int32 {
+	scores := make(map[string]int32)
+	if _, ok := weeklyResults[week]; !ok {
 		log.Printf("no results for current week %d, aborting streak calculation", week)
-		return
+		return scores
 	}
 
-	for i := range pr.Teams {
-		t := pr.Teams[i]
-
-		streak := getMatchResult(t.TeamID, currentWeek)
-		if streak == -2 {
+	for _, t := range pr.Teams {
+		streak, found := getStreak(t.TeamID, weeklyResults, week)
+		if !found {
 			log.Printf("no streak found for %s starting with week %d", t.TeamID, week)
 			continue
 		}
 
-		for w := week - 1; w > 0; w-- {
-			matchups, ok := weeklyResults[w]
-			if !ok {
-				continue
+		log.Printf("team %s (%s) streak: %d", t.TeamName, t.TeamID, streak)
+		scores[t.TeamID] = int32(streak * pr.Config.StreakPoints)
+	}
+	return scores
+}
+
+// Get the team's streak through week, positive for a winning streak and negative
+// for a losing streak. Returns false if the team didn't play in week.
+func getStreak(teamID string, weeklyResults map[int][]model.Matchup, week int) (int, bool) {
+	currentWeek, ok := weeklyResults[week]
+	if !ok {
+		return 0, false
+	}
+	streak := getMatchResult(teamID, currentWeek)
+	if streak == -2 {
+		return 0, false
+	}
+
+	for w := week - 1; w > 0; w-- {
+		matchups, ok := weeklyResults[w]
+		if !ok {
+			continue
+		}
+		r := getMatchResult(teamID, matchups)
+		done := false
+		switch r {
+		case 1:
+			if streak > 0 {
+				streak++
+			} else {
+				done = true
 			}
-			r := getMatchResult(t.TeamID, matchups)
-			done := false
-			switch r {
-			case 1:
-				if streak > 0 {
-					streak++
-				} else {
-					done = true
-				}
-			case -1:
-				if streak < 1 {
-					streak--
-				} else {
-					done = true
-				}
-			default:
+		case -1:
+			if streak < 1 {
+				streak--
+			} else {
 				done = true
 			}
+		default:
+			done = true
+		}
 
-			if done {
-				break
-			}
+		if done {
+			break
 		}
+	}
+	return streak, true
+}
 
-		log.Printf("team %s (%s) streak: %d", t.TeamName, t.TeamID, streak)
-		pr.Teams[i].StreakScore = int32(streak * 5)
+// Get the id of the power ranking to compare a new power ranking for week to, the
+// most recent one for an earlier week. The list must be sorted the same way
+// ListPowerRankings sorts it. Returns 0 if there isn't one.
+func previousPowerRankingID(list []model.PowerRanking, week int, replacingID int32) int32 {
+	for _, pr := range list {
+		if int(pr.Week) < week && pr.ID != replacingID {
+			return pr.ID
+		}
 	}
+	return 0
 }
 
 func calculateRankChange(pr, prev *model.PowerRanking) {
 	if prev == nil {
 		return
 	}
+	pr.CompareID = prev.ID
 
 	prevRanks := make(map[string]int)
 	for _, t := range prev.Teams {
@@ -343,7 +600,10 @@ func getMatchResult(teamID string, matchups []model.Matchup) int {
 
 func sumFinalScore(pr *model.PowerRanking) {
 	for i, t := range pr.Teams {
-		pr.Teams[i].TotalScore = t.RosterScore + t.RecordScore + t.StreakScore + t.PointsForScore + t.PointsAgainstScore
+		pr.Teams[i].TotalScore = 0
+		for _, s := range t.Scores {
+			pr.Teams[i].TotalScore += s
+		}
 		log.Printf("team %s (%s) power ranking score: %d", pr.Teams[i].TeamName, pr.Teams[i].TeamID, pr.Teams[i].TotalScore)
 	}
 }
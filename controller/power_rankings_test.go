@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -53,7 +54,8 @@ func TestInitalizePowerRankings(t *testing.T) {
 	}
 	week := 3
 
-	pr := initializePowerRankings(rosters, ranking, week)
+	cfg := model.DefaultPowerRankingConfig()
+	pr := initializePowerRankings(rosters, ranking, week, &cfg)
 	if len(pr.Teams) != len(rosters) {
 		t.Errorf("expected result to have %d teams, but was %d", len(rosters), len(pr.Teams))
 	}
@@ -61,6 +63,7 @@ func TestInitalizePowerRankings(t *testing.T) {
 	expected := &model.PowerRanking{
 		RankingID: ranking.ID,
 		Week:      int16(week),
+		Config:    cfg,
 		Teams: []model.TeamPowerRanking{
 			{
 				TeamID: r1.TeamID,
@@ -92,6 +95,7 @@ func TestInitalizePowerRankings(t *testing.T) {
 
 func TestCalculateRosterScores(t *testing.T) {
 	pr := &model.PowerRanking{
+		Config: model.DefaultPowerRankingConfig(),
 		Teams: []model.TeamPowerRanking{
 			{
 				TeamID: "1",
@@ -116,95 +120,299 @@ func TestCalculateRosterScores(t *testing.T) {
 		model.GetRosterSpot("FLEX"),
 	}
 
-	calculateRosterScores(pr, starters)
+	scores := calculateRosterScores(pr, starters)
 	if len(pr.Teams) != 1 {
 		t.Fatalf("wrong number of results returned, expected 1 got: %d", len(pr.Teams))
 	}
 	team := pr.Teams[0]
 
-	expectedStarters := map[string]bool{
-		"21": true, // QB
-		"1":  true, // RB
-		"4":  true, // WR
-		"2":  true, // FLEX
+	expectedStarters := map[string]string{
+		"21": "QB",
+		"1":  "RB",
+		"4":  "WR",
+		"2":  "RB/WR/TE",
 	}
 	for _, p := range team.Roster {
-		_, isStarter := expectedStarters[p.PlayerID]
+		spot, isStarter := expectedStarters[p.PlayerID]
 		if isStarter != p.IsStarter {
 			t.Errorf("player %s has isStarter: %v, but expected: %v - %v", p.PlayerID, p.IsStarter, isStarter, team)
 		}
+		if spot != p.RosterSpot {
+			t.Errorf("player %s has roster spot: %s, but expected: %s", p.PlayerID, p.RosterSpot, spot)
+		}
 		if p.PowerRankingPoints <= 0 {
 			t.Errorf("player %s has power ranking points <= 0: %d", p.PlayerID, p.PowerRankingPoints)
 		}
 	}
-	if team.RosterScore <= 0 {
-		t.Errorf("expected roster to have a score > 0, got: %d", team.RosterScore)
+	if scores[team.TeamID] <= 0 {
+		t.Errorf("expected roster to have a score > 0, got: %d", scores[team.TeamID])
+	}
+}
+
+func TestCalculateRosterScoresFillsRestrictiveSpotsFirst(t *testing.T) {
+	pr := &model.PowerRanking{
+		Config: model.DefaultPowerRankingConfig(),
+		Teams: []model.TeamPowerRanking{
+			{
+				TeamID: "1",
+				Roster: []model.PowerRankingPlayer{
+					{PlayerID: "1", Rank: 1, Position: model.POS_QB},
+					{PlayerID: "2", Rank: 2, Position: model.POS_RB},
+					{PlayerID: "3", Rank: 3, Position: model.POS_QB},
+					{PlayerID: "4", Rank: 4, Position: model.POS_WR},
+					{PlayerID: "5", Rank: 5, Position: model.POS_RB},
+					{PlayerID: "6", Rank: 6, Position: model.POS_LB},
+					{PlayerID: "7", Rank: 7, Position: model.POS_DB},
+					{PlayerID: "8", Rank: 8, Position: model.POS_LB},
+				},
+			},
+		},
+	}
+	// Filling these in order would put the best QB and RB in the flex spots, and
+	// leave the QB, RB and LB spots with worse players.
+	starters := []model.RosterSpot{
+		model.GetRosterSpot("SUPER_FLEX"),
+		model.GetRosterSpot("FLEX"),
+		model.GetRosterSpot("IDP_FLEX"),
+		model.GetRosterSpot("QB"),
+		model.GetRosterSpot("RB"),
+		model.GetRosterSpot("LB"),
+	}
+
+	calculateRosterScores(pr, starters)
+
+	expectedStarters := map[string]string{
+		"1": "QB",
+		"2": "RB",
+		"6": "LB",
+		"3": "QB/RB/WR/TE",
+		"4": "RB/WR/TE",
+		"7": "DL/LB/DB",
+	}
+	for _, p := range pr.Teams[0].Roster {
+		spot, isStarter := expectedStarters[p.PlayerID]
+		if isStarter != p.IsStarter || spot != p.RosterSpot {
+			t.Errorf("player %s has isStarter: %v and roster spot: %s, but expected: %v and %s", p.PlayerID, p.IsStarter, p.RosterSpot, isStarter, spot)
+		}
 	}
 }
 
 func TestCalculateFantasyPointsScore(t *testing.T) {
 	pr, weeklyResults := getDataForTest()
-	calculateFantasyPointsScore(pr, weeklyResults, 5)
+	pointsFor, pointsAgainst := calculateFantasyPointsScore(pr, weeklyResults, 5)
 
-	if pr.Teams[0].PointsForScore != 100 {
-		t.Errorf("expected team 1 to have points for of 100, got %d", pr.Teams[0].PointsForScore)
+	if pointsFor["1"] != 100 {
+		t.Errorf("expected team 1 to have points for of 100, got %d", pointsFor["1"])
 	}
-	if pr.Teams[0].PointsAgainstScore != 27 {
-		t.Errorf("expected team 1 to have points against of 18, got %d", pr.Teams[0].PointsAgainstScore)
+	if pointsAgainst["1"] != 27 {
+		t.Errorf("expected team 1 to have points against of 18, got %d", pointsAgainst["1"])
 	}
-	if pr.Teams[1].PointsForScore != 105 {
-		t.Errorf("expected team 2 to have points for of 105, got: %d", pr.Teams[1].PointsForScore)
+	if pointsFor["2"] != 105 {
+		t.Errorf("expected team 2 to have points for of 105, got: %d", pointsFor["2"])
 	}
-	if pr.Teams[1].PointsAgainstScore != 33 {
-		t.Errorf("expected team 2 to have points against of 33, got %d", pr.Teams[1].PointsAgainstScore)
+	if pointsAgainst["2"] != 33 {
+		t.Errorf("expected team 2 to have points against of 33, got %d", pointsAgainst["2"])
 	}
-	if pr.Teams[2].PointsForScore != 110 {
-		t.Errorf("expected team 3 to have points for of 110, got: %d", pr.Teams[2].PointsForScore)
+	if pointsFor["3"] != 110 {
+		t.Errorf("expected team 3 to have points for of 110, got: %d", pointsFor["3"])
 	}
-	if pr.Teams[2].PointsAgainstScore != 30 {
-		t.Errorf("expected team 3 to have points against of 30, got %d", pr.Teams[2].PointsAgainstScore)
+	if pointsAgainst["3"] != 30 {
+		t.Errorf("expected team 3 to have points against of 30, got %d", pointsAgainst["3"])
 	}
-	if pr.Teams[3].PointsForScore != 90 {
-		t.Errorf("expected team 4 to have points for of 90, got: %d", pr.Teams[3].PointsForScore)
+	if pointsFor["4"] != 90 {
+		t.Errorf("expected team 4 to have points for of 90, got: %d", pointsFor["4"])
 	}
-	if pr.Teams[3].PointsAgainstScore != 31 {
-		t.Errorf("expected team 4 to have points against of 31, got %d", pr.Teams[3].PointsAgainstScore)
+	if pointsAgainst["4"] != 31 {
+		t.Errorf("expected team 4 to have points against of 31, got %d", pointsAgainst["4"])
 	}
 }
 
 func TestCalculateRecordScore(t *testing.T) {
 	pr, weeklyResults := getDataForTest()
-	calculateRecordScore(pr, weeklyResults, 5)
+	scores := calculateRecordScore(pr, weeklyResults, 5)
 
-	if pr.Teams[0].RecordScore != 10 {
-		t.Errorf("expected team 1 to have a record score of 10, got: %d", pr.Teams[0].RecordScore)
+	if scores["1"] != 10 {
+		t.Errorf("expected team 1 to have a record score of 10, got: %d", scores["1"])
 	}
-	if pr.Teams[1].RecordScore != -30 {
-		t.Errorf("expected team 2 to have a record score of -30, got: %d", pr.Teams[1].RecordScore)
+	if scores["2"] != -30 {
+		t.Errorf("expected team 2 to have a record score of -30, got: %d", scores["2"])
 	}
-	if pr.Teams[2].RecordScore != 30 {
-		t.Errorf("expected team 3 to have a record score of 30, got: %d", pr.Teams[2].RecordScore)
+	if scores["3"] != 30 {
+		t.Errorf("expected team 3 to have a record score of 30, got: %d", scores["3"])
 	}
-	if pr.Teams[3].RecordScore != -10 {
-		t.Errorf("expected team 4 to have a record score of -10, got: %d", pr.Teams[3].RecordScore)
+	if scores["4"] != -10 {
+		t.Errorf("expected team 4 to have a record score of -10, got: %d", scores["4"])
 	}
 }
 
 func TestCalculateStreakScore(t *testing.T) {
 	pr, weeklyResults := getDataForTest()
-	calculateStreakScore(pr, weeklyResults, 5)
+	scores := calculateStreakScore(pr, weeklyResults, 5)
+
+	if scores["1"] != -10 {
+		t.Errorf("expected team 1 to have a streak score of -10, got: %d", scores["1"])
+	}
+	if scores["2"] != 5 {
+		t.Errorf("expected team 2 to have a streak score of 5, got: %d", scores["2"])
+	}
+	if scores["3"] != 20 {
+		t.Errorf("expected team 3 to have a streak score of 20, got: %d", scores["3"])
+	}
+	if scores["4"] != -5 {
+		t.Errorf("expected team 4 to have a streak score of -5, got: %d", scores["4"])
+	}
+}
+
+func TestCalculateScoresWithConfig(t *testing.T) {
+	pr, weeklyResults := getDataForTest()
+	pr.Config = model.PowerRankingConfig{
+		BenchPercent:         0,
+		PointsAgainstPercent: 0,
+		WinPoints:            3,
+		StreakPoints:         1,
+		PointsWeeks:          1,
+	}
+	pointsFor, pointsAgainst := calculateFantasyPointsScore(pr, weeklyResults, 5)
+	record := calculateRecordScore(pr, weeklyResults, 5)
+	streak := calculateStreakScore(pr, weeklyResults, 5)
+
+	// Only week 5 is used for points, and points against are ignored
+	if pointsFor["1"] != 100 || pointsAgainst["1"] != 0 {
+		t.Errorf("expected team 1 to have points for of 100 and points against of 0, got: %d and %d",
+			pointsFor["1"], pointsAgainst["1"])
+	}
+	if pointsFor["4"] != 90 || pointsAgainst["4"] != 0 {
+		t.Errorf("expected team 4 to have points for of 90 and points against of 0, got: %d and %d",
+			pointsFor["4"], pointsAgainst["4"])
+	}
+	if record["3"] != 9 {
+		t.Errorf("expected team 3 to have a record score of 9, got: %d", record["3"])
+	}
+	if streak["3"] != 4 {
+		t.Errorf("expected team 3 to have a streak score of 4, got: %d", streak["3"])
+	}
+
+	// With no weight for the bench, only the starters count toward the roster score
+	pr = &model.PowerRanking{
+		Teams: []model.TeamPowerRanking{
+			{
+				TeamID: "1",
+				Roster: []model.PowerRankingPlayer{
+					{PlayerID: "1", Rank: 1, Position: model.POS_QB},
+					{PlayerID: "2", Rank: 2, Position: model.POS_QB},
+				},
+			},
+		},
+	}
+	roster := calculateRosterScores(pr, []model.RosterSpot{model.GetRosterSpot("QB")})
+	if pr.Teams[0].Roster[1].PowerRankingPoints != 0 {
+		t.Errorf("expected the bench player to have 0 points, got: %d", pr.Teams[0].Roster[1].PowerRankingPoints)
+	}
+	if roster["1"] != calculatePlayerValue(1)/100 {
+		t.Errorf("expected roster score to only include the starter, got: %d", roster["1"])
+	}
+}
+
+func TestCalculateComponentScores(t *testing.T) {
+	pr, weeklyResults := getDataForTest()
+	in := &powerRankingInput{pr: pr, weeklyResults: weeklyResults, week: 5}
+
+	// A component that gives every team a score based on their team id
+	teamID := componentFunc{n: "Team ID", f: func(in *powerRankingInput) map[string]int32 {
+		scores := make(map[string]int32)
+		for _, t := range in.pr.Teams {
+			id, _ := strconv.Atoi(t.TeamID)
+			scores[t.TeamID] = int32(id)
+		}
+		return scores
+	}}
+	record := componentFunc{n: componentRecord, f: func(in *powerRankingInput) map[string]int32 {
+		return calculateRecordScore(in.pr, in.weeklyResults, in.week)
+	}}
+
+	calculateComponentScores(in, []powerRankingComponent{teamID, record})
+	sumFinalScore(pr)
+
+	if !reflect.DeepEqual([]string{"Team ID", componentRecord}, pr.Components) {
+		t.Errorf("unexpected components: %v", pr.Components)
+	}
+	expected := map[string]int32{"Team ID": 3, componentRecord: 30}
+	if !reflect.DeepEqual(expected, pr.Teams[2].Scores) {
+		t.Errorf("expected: %v, got: %v", expected, pr.Teams[2].Scores)
+	}
+	if pr.Teams[2].TotalScore != 33 {
+		t.Errorf("expected team 3 to have a total score of 33, got: %d", pr.Teams[2].TotalScore)
+	}
+	if pr.Teams[1].TotalScore != -28 {
+		t.Errorf("expected team 2 to have a total score of -28, got: %d", pr.Teams[1].TotalScore)
+	}
+}
+
+func TestValidatePowerRankingConfig(t *testing.T) {
+	tests := map[string]struct {
+		update func(c *model.PowerRankingConfig)
+		valid  bool
+	}{
+		"default":             {update: func(c *model.PowerRankingConfig) {}, valid: true},
+		"no bench":            {update: func(c *model.PowerRankingConfig) { c.BenchPercent = 0 }, valid: true},
+		"negative bench":      {update: func(c *model.PowerRankingConfig) { c.BenchPercent = -1 }, valid: false},
+		"bench over 100":      {update: func(c *model.PowerRankingConfig) { c.BenchPercent = 101 }, valid: false},
+		"points against":      {update: func(c *model.PowerRankingConfig) { c.PointsAgainstPercent = 101 }, valid: false},
+		"negative win points": {update: func(c *model.PowerRankingConfig) { c.WinPoints = -10 }, valid: false},
+		"negative streak":     {update: func(c *model.PowerRankingConfig) { c.StreakPoints = -5 }, valid: false},
+		"no points weeks":     {update: func(c *model.PowerRankingConfig) { c.PointsWeeks = 0 }, valid: false},
+		"whole season":        {update: func(c *model.PowerRankingConfig) { c.PointsWeeks = 18 }, valid: true},
+		"no tie-breaks":       {update: func(c *model.PowerRankingConfig) { c.TieBreaks = nil }, valid: true},
+		"unknown tie-break":   {update: func(c *model.PowerRankingConfig) { c.TieBreaks = []string{"coin_flip"} }, valid: false},
+		"repeated tie-break": {update: func(c *model.PowerRankingConfig) {
+			c.TieBreaks = []string{model.TieBreakRecord, model.TieBreakRecord}
+		}, valid: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := model.DefaultPowerRankingConfig()
+			tc.update(&c)
+			err := validatePowerRankingConfig(&c)
+			if tc.valid && err != nil {
+				t.Errorf("expected config to be valid, got: %v", err)
+			} else if !tc.valid && err == nil {
+				t.Error("expected config to be invalid")
+			}
+		})
+	}
+}
+
+func TestUpdatePowerRankingConfig(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	ctx := context.Background()
 
-	if pr.Teams[0].StreakScore != -10 {
-		t.Errorf("expected team 1 to have a streak score of -10, got: %d", pr.Teams[0].StreakScore)
+	l := &model.League{Platform: model.PlatformSleeper, ExternalID: testutils.SleeperLeagueID, Name: "test", Year: "2024"}
+	if err := testDB.DB.AddLeague(ctx, l); err != nil {
+		t.Fatalf("error adding league: %v", err)
 	}
-	if pr.Teams[1].StreakScore != 5 {
-		t.Errorf("expected team 2 to have a streak score of 5, got: %d", pr.Teams[1].StreakScore)
+	defer func() {
+		ctrl.ArchiveLeague(ctx, l.ID)
+	}()
+
+	cfg := &model.PowerRankingConfig{BenchPercent: 150, PointsAgainstPercent: 30, WinPoints: 10, StreakPoints: 5, PointsWeeks: 3}
+	if err := ctrl.UpdatePowerRankingConfig(ctx, l.ID, cfg); err == nil {
+		t.Error("expected an error saving an invalid config")
 	}
-	if pr.Teams[2].StreakScore != 20 {
-		t.Errorf("expected team 3 to have a streak score of 20, got: %d", pr.Teams[2].StreakScore)
+
+	cfg.BenchPercent = 20
+	if err := ctrl.UpdatePowerRankingConfig(ctx, l.ID, cfg); err != nil {
+		t.Fatalf("error saving config: %v", err)
+	}
+
+	res, err := ctrl.GetPowerRankingConfig(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting config: %v", err)
 	}
-	if pr.Teams[3].StreakScore != -5 {
-		t.Errorf("expected team 4 to have a streak score of -5, got: %d", pr.Teams[3].StreakScore)
+	if !reflect.DeepEqual(cfg, res) {
+		t.Errorf("expected: %v, got: %v", cfg, res)
 	}
 }
 
@@ -219,6 +427,7 @@ func TestCalculateRankChange(t *testing.T) {
 	}
 
 	prev := &model.PowerRanking{
+		ID: 7,
 		Teams: []model.TeamPowerRanking{
 			{TeamID: "2", TeamName: "BBB", Rank: 1},
 			{TeamID: "4", TeamName: "DDD", Rank: 2},
@@ -240,6 +449,83 @@ func TestCalculateRankChange(t *testing.T) {
 	if pr.Teams[3].RankChange != -2 {
 		t.Errorf("expected team 3 to have rank change of -2, got: %d", pr.Teams[3].RankChange)
 	}
+	if pr.CompareID != 7 {
+		t.Errorf("expected the power ranking to be compared to 7, got: %d", pr.CompareID)
+	}
+}
+
+func TestPreviousPowerRankingID(t *testing.T) {
+	// Sorted the same as ListPowerRankings, by week and then the newest first
+	list := []model.PowerRanking{
+		{ID: 5, Week: 6},
+		{ID: 4, Week: 5},
+		{ID: 3, Week: 5},
+		{ID: 2, Week: 4},
+		{ID: 1, Week: 0},
+	}
+
+	tests := []struct {
+		name        string
+		week        int
+		replacingID int32
+		expected    int32
+	}{
+		{name: "after the newest week", week: 7, expected: 5},
+		{name: "same week as the newest", week: 6, expected: 4},
+		{name: "earlier week", week: 5, expected: 2},
+		{name: "replacing the newest of the earlier week", week: 6, replacingID: 4, expected: 3},
+		{name: "preseason", week: 0, expected: 0},
+		{name: "nothing earlier", week: 1, replacingID: 1, expected: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if id := previousPowerRankingID(list, tc.week, tc.replacingID); id != tc.expected {
+				t.Errorf("expected power ranking %d, got: %d", tc.expected, id)
+			}
+		})
+	}
+}
+
+func TestPowerRankingHistory(t *testing.T) {
+	roster := func(points ...int32) []model.PowerRankingPlayer {
+		r := make([]model.PowerRankingPlayer, 0, len(points))
+		for _, p := range points {
+			r = append(r, model.PowerRankingPlayer{PowerRankingPoints: p})
+		}
+		return r
+	}
+
+	rankings := []*model.PowerRanking{
+		{ID: 3, Week: 1, Teams: []model.TeamPowerRanking{
+			{TeamID: "1", TeamName: "AAA", Rank: 1, Roster: roster(900, 800, 100)},
+			{TeamID: "2", TeamName: "BBB", Rank: 2, Roster: roster(700)},
+		}},
+		{ID: 5, Week: 2, Teams: []model.TeamPowerRanking{
+			{TeamID: "2", TeamName: "BBB", Rank: 1, Roster: roster(700, 600)},
+			{TeamID: "1", TeamName: "AAA", Rank: 2, Roster: roster(900, 200)},
+			{TeamID: "3", TeamName: "CCC", Rank: 3},
+		}},
+		{ID: 6, Week: 4, Teams: []model.TeamPowerRanking{
+			{TeamID: "3", TeamName: "CCC", Rank: 1, Roster: roster(2000)},
+			{TeamID: "2", TeamName: "BBB 2", Rank: 2, Roster: roster(500, 500)},
+		}},
+	}
+
+	expected := &model.PowerRankingHistory{
+		Weeks:           []int{1, 2, 4},
+		PowerRankingIDs: []int32{3, 5, 6},
+		Teams: []model.TeamRankHistory{
+			{TeamID: "3", TeamName: "CCC", Ranks: []int{0, 3, 1}, RosterValues: []int32{0, 0, 2000}},
+			{TeamID: "2", TeamName: "BBB 2", Ranks: []int{2, 1, 2}, RosterValues: []int32{700, 1300, 1000}},
+			{TeamID: "1", TeamName: "AAA", Ranks: []int{1, 2, 0}, RosterValues: []int32{1800, 1100, 0}},
+		},
+	}
+
+	h := powerRankingHistory(rankings)
+	if !reflect.DeepEqual(expected, h) {
+		t.Errorf("expected: %v, got: %v", expected, h)
+	}
 }
 
 func TestCalculateAndGetPowerRanking(t *testing.T) {
@@ -270,7 +556,7 @@ func TestCalculateAndGetPowerRanking(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error parsing ranking date: %v", err)
 	}
-	rankingID, err := ctrl.AddRanking(ctx, getRankingsData(), rankingDate)
+	rankingID, err := ctrl.AddRanking(ctx, getRankingsData(), rankingDate, model.RankingImportOptions{})
 	if err != nil {
 		t.Fatalf("error adding ranking: %v", err)
 	}
@@ -284,7 +570,7 @@ func TestCalculateAndGetPowerRanking(t *testing.T) {
 
 	const week = 5
 	// Now that all of the setup is done, calculate and verify the power rankings.
-	prID, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, week)
+	prID, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, week, 0 /* compareID */)
 	if err != nil {
 		t.Fatalf("error calculating power ranking: %v", err)
 	}
@@ -354,6 +640,13 @@ func TestCalculateAndGetPowerRanking(t *testing.T) {
 	if pr.Week != week {
 		t.Errorf("expected pr.Week to be %d, but was %d", week, pr.Week)
 	}
+	if !reflect.DeepEqual(model.DefaultPowerRankingConfig(), pr.Config) {
+		t.Errorf("expected the default config to be used, but was %v", pr.Config)
+	}
+	expectedComponents := []string{componentRoster, componentPointsFor, componentPointsAgainst, componentRecord, componentStreak}
+	if !reflect.DeepEqual(expectedComponents, pr.Components) {
+		t.Errorf("expected components to be %v, but was %v", expectedComponents, pr.Components)
+	}
 
 	for i := range expected.Teams {
 		e := expected.Teams[i]
@@ -368,16 +661,16 @@ func TestCalculateAndGetPowerRanking(t *testing.T) {
 		if e.Rank != a.Rank {
 			t.Errorf("expected Rank to be %d, but was %d", e.Rank, a.Rank)
 		}
-		if a.PointsForScore < 90 || a.PointsForScore > 120 {
-			t.Errorf("points for value is outside of expected range for team %s, got: %d", a.TeamID, a.PointsForScore)
+		if a.Scores[componentPointsFor] < 90 || a.Scores[componentPointsFor] > 120 {
+			t.Errorf("points for value is outside of expected range for team %s, got: %d", a.TeamID, a.Scores[componentPointsFor])
 		}
-		if a.PointsAgainstScore < 25 || a.PointsAgainstScore > 35 {
-			t.Errorf("points against value is outside of expected range for team %s, got: %d", a.TeamID, a.PointsAgainstScore)
+		if a.Scores[componentPointsAgainst] < 25 || a.Scores[componentPointsAgainst] > 35 {
+			t.Errorf("points against value is outside of expected range for team %s, got: %d", a.TeamID, a.Scores[componentPointsAgainst])
 		}
-		if a.RecordScore == 0 {
+		if a.Scores[componentRecord] == 0 {
 			t.Errorf("record score is 0, should have a value for team %s", a.TeamID)
 		}
-		if a.StreakScore == 0 {
+		if a.Scores[componentStreak] == 0 {
 			t.Errorf("streak score is 0, should have a value for team %s", a.TeamID)
 		}
 
@@ -395,6 +688,199 @@ func TestCalculateAndGetPowerRanking(t *testing.T) {
 	}
 }
 
+func TestPreviewRecomputeAndDeletePowerRanking(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	ctx := context.Background()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding a new league: %v", err)
+	}
+	defer func() {
+		if err := ctrl.ArchiveLeague(ctx, l.ID); err != nil {
+			t.Fatalf("error archiving league: %v", err)
+		}
+	}()
+
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	rankingDate, err := time.ParseInLocation(time.DateOnly, "2018-09-02", time.UTC)
+	if err != nil {
+		t.Fatalf("error parsing ranking date: %v", err)
+	}
+	rankingID, err := ctrl.AddRanking(ctx, getRankingsData(), rankingDate, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding ranking: %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, i); err != nil {
+			t.Fatalf("error getting week %d results: %v", i, err)
+		}
+	}
+
+	// A preview isn't saved
+	preview, err := ctrl.PreviewPowerRanking(ctx, l.ID, rankingID, 2, 0 /* compareID */)
+	if err != nil {
+		t.Fatalf("error previewing power ranking: %v", err)
+	}
+	if preview.ID != 0 || preview.Week != 2 || len(preview.Teams) != 4 || preview.Teams[0].TeamName == "" {
+		t.Errorf("unexpected power ranking preview: %v", preview)
+	}
+	if list, err := ctrl.ListPowerRankings(ctx, l.ID); err != nil || len(list) != 0 {
+		t.Errorf("expected no saved power rankings after a preview, got: %v, %v", list, err)
+	}
+
+	prID, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, 2, 0 /* compareID */)
+	if err != nil {
+		t.Fatalf("error calculating power ranking: %v", err)
+	}
+	pr, err := ctrl.GetPowerRanking(ctx, l.ID, prID)
+	if err != nil {
+		t.Fatalf("error getting power ranking: %v", err)
+	}
+	for i := range pr.Teams {
+		if pr.Teams[i].TeamID != preview.Teams[i].TeamID || pr.Teams[i].TotalScore != preview.Teams[i].TotalScore {
+			t.Errorf("expected the saved power ranking to match the preview, got: %v and %v", pr.Teams[i], preview.Teams[i])
+		}
+	}
+
+	// Recompute it for a later week, it keeps the same id and isn't compared to itself
+	if err := ctrl.RecomputePowerRanking(ctx, l.ID, prID, rankingID, 3, 0 /* compareID */); err != nil {
+		t.Fatalf("error recomputing power ranking: %v", err)
+	}
+	recomputed, err := ctrl.GetPowerRanking(ctx, l.ID, prID)
+	if err != nil {
+		t.Fatalf("error getting recomputed power ranking: %v", err)
+	}
+	if recomputed.Week != 3 || len(recomputed.Teams) != 4 || !recomputed.Created.Equal(pr.Created) {
+		t.Errorf("unexpected recomputed power ranking: %v", recomputed)
+	}
+	for _, team := range recomputed.Teams {
+		if team.RankChange != 0 {
+			t.Errorf("expected no rank change for team %s, got: %d", team.TeamID, team.RankChange)
+		}
+	}
+	if list, err := ctrl.ListPowerRankings(ctx, l.ID); err != nil || len(list) != 1 {
+		t.Errorf("expected only 1 saved power ranking, got: %v, %v", list, err)
+	}
+
+	if err := ctrl.RecomputePowerRanking(ctx, l.ID, prID+1000, rankingID, 3, 0 /* compareID */); err == nil {
+		t.Errorf("expected an error recomputing a power ranking that doesn't exist")
+	}
+
+	if err := ctrl.DeletePowerRanking(ctx, l.ID, prID); err != nil {
+		t.Fatalf("error deleting power ranking: %v", err)
+	}
+	if list, err := ctrl.ListPowerRankings(ctx, l.ID); err != nil || len(list) != 0 {
+		t.Errorf("expected no saved power rankings after deleting it, got: %v, %v", list, err)
+	}
+}
+
+func TestPowerRankingRankChangeAndHistory(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	ctx := context.Background()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding a new league: %v", err)
+	}
+	defer func() {
+		if err := ctrl.ArchiveLeague(ctx, l.ID); err != nil {
+			t.Fatalf("error archiving league: %v", err)
+		}
+	}()
+
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	rankingDate, err := time.ParseInLocation(time.DateOnly, "2018-09-03", time.UTC)
+	if err != nil {
+		t.Fatalf("error parsing ranking date: %v", err)
+	}
+	rankingID, err := ctrl.AddRanking(ctx, getRankingsData(), rankingDate, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding ranking: %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, i); err != nil {
+			t.Fatalf("error getting week %d results: %v", i, err)
+		}
+	}
+
+	calculate := func(week int, compareID int32) *model.PowerRanking {
+		t.Helper()
+		id, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, week, compareID)
+		if err != nil {
+			t.Fatalf("error calculating week %d power ranking: %v", week, err)
+		}
+		pr, err := ctrl.GetPowerRanking(ctx, l.ID, id)
+		if err != nil {
+			t.Fatalf("error getting week %d power ranking: %v", week, err)
+		}
+		return pr
+	}
+
+	week2 := calculate(2, 0)
+	week3 := calculate(3, 0)
+	if week2.CompareID != 0 || week3.CompareID != week2.ID {
+		t.Errorf("expected week 3 to be compared to week 2, got: %d and %d", week2.CompareID, week3.CompareID)
+	}
+
+	// Doing week 2 again after week 3 doesn't compare it to week 3
+	again := calculate(2, 0)
+	if again.CompareID != 0 {
+		t.Errorf("expected the second week 2 power ranking to not be compared, got: %d", again.CompareID)
+	}
+	for _, team := range again.Teams {
+		if team.RankChange != 0 {
+			t.Errorf("expected no rank change for team %s, got: %d", team.TeamID, team.RankChange)
+		}
+	}
+
+	// The newest week 2 power ranking is used for later weeks
+	if pr := calculate(3, 0); pr.CompareID != again.ID {
+		t.Errorf("expected week 3 to be compared to %d, got: %d", again.ID, pr.CompareID)
+	}
+
+	// Or compare to a specific power ranking
+	if pr := calculate(3, week2.ID); pr.CompareID != week2.ID {
+		t.Errorf("expected week 3 to be compared to %d, got: %d", week2.ID, pr.CompareID)
+	}
+	if _, err := ctrl.CalculatePowerRanking(ctx, l.ID, rankingID, 3, week2.ID+1000); err == nil {
+		t.Errorf("expected an error comparing to a power ranking that doesn't exist")
+	}
+
+	h, err := ctrl.GetPowerRankingHistory(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting power ranking history: %v", err)
+	}
+	if !reflect.DeepEqual([]int{2, 3}, h.Weeks) || h.PowerRankingIDs[0] != again.ID || len(h.Teams) != 4 {
+		t.Fatalf("unexpected power ranking history: %v", h)
+	}
+	for _, team := range h.Teams {
+		if team.TeamName == "" || team.Ranks[0] == 0 || team.Ranks[1] == 0 {
+			t.Errorf("unexpected rank history: %v", team)
+		}
+	}
+}
+
 func getRankingsData() io.Reader {
 	// Rondale Moore is intentionally missing from this list, to be someone
 	// without a ranking.
@@ -445,6 +931,7 @@ func getRankingsData() io.Reader {
 
 func getDataForTest() (*model.PowerRanking, map[int][]model.Matchup) {
 	pr := &model.PowerRanking{
+		Config: model.DefaultPowerRankingConfig(),
 		Teams: []model.TeamPowerRanking{
 			{TeamID: "1", TeamName: "AAA"},
 			{TeamID: "2", TeamName: "BBB"},
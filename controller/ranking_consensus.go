@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// Blend the rankings into a consensus ranking and save it. If the scoring format isn't
+// set and all the rankings have the same one, it is used. Returns the id of the new ranking.
+func (c *controller) AddConsensusRanking(ctx context.Context, opts model.ConsensusOptions) (int32, error) {
+	if len(opts.Sources) < 2 {
+		return 0, errors.New("a consensus ranking needs at least two rankings")
+	}
+
+	for i, s := range opts.Sources {
+		if s.Weight <= 0 {
+			return 0, fmt.Errorf("the weight of ranking %d must be more than 0, got: %v", s.RankingID, s.Weight)
+		}
+		if s.MissingRank < 0 {
+			return 0, fmt.Errorf("the missing rank of ranking %d can't be negative, got: %d", s.RankingID, s.MissingRank)
+		}
+		if slices.ContainsFunc(opts.Sources[:i], func(o model.ConsensusSource) bool { return o.RankingID == s.RankingID }) {
+			return 0, fmt.Errorf("ranking %d can only be used once", s.RankingID)
+		}
+	}
+
+	sources := make([]model.ConsensusSource, 0, len(opts.Sources))
+	for _, s := range opts.Sources {
+		r, err := c.GetRanking(ctx, s.RankingID)
+		if err != nil {
+			return 0, fmt.Errorf("error getting ranking %d: %w", s.RankingID, err)
+		}
+		s.Date = r.Date
+		s.Source = r.Source
+		s.Scoring = r.Scoring
+		s.Ranks = make(map[string]int32, len(r.Players))
+		worst := int32(0)
+		for id, p := range r.Players {
+			s.Ranks[id] = p.Rank
+			worst = max(worst, p.Rank)
+		}
+		if s.MissingRank == 0 {
+			s.MissingRank = worst + 1
+		}
+		sources = append(sources, s)
+	}
+
+	scoring := opts.Scoring
+	if scoring == model.SCORING_UNKNOWN {
+		if !slices.ContainsFunc(sources, func(s model.ConsensusSource) bool { return s.Scoring != sources[0].Scoring }) {
+			scoring = sources[0].Scoring
+		}
+	}
+
+	ranking, err := c.db.AddConsensusRanking(ctx, opts.Date, scoring, blendRankings(sources), sources)
+	if err != nil {
+		return 0, err
+	}
+	return ranking.ID, nil
+}
+
+func (c *controller) GetConsensusRanking(ctx context.Context, id int32) (*model.ConsensusRanking, error) {
+	r, err := c.GetRanking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := c.db.GetConsensusSources(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("ranking %d is not a consensus ranking", id)
+	}
+	return consensusRanking(r, sources), nil
+}
+
+// Rank every player that is in any of the sources by their weighted average rank. Ties
+// are broken by the player id so the ranks are always the same.
+func blendRankings(sources []model.ConsensusSource) map[string]int32 {
+	averages := make(map[string]float64)
+	for _, s := range sources {
+		for id := range s.Ranks {
+			if _, found := averages[id]; !found {
+				averages[id], _, _ = weightedRank(id, sources)
+			}
+		}
+	}
+
+	ids := slices.SortedFunc(maps.Keys(averages), func(a, b string) int {
+		if averages[a] == averages[b] {
+			return cmp.Compare(a, b)
+		}
+		return cmp.Compare(averages[a], averages[b])
+	})
+
+	result := make(map[string]int32, len(ids))
+	for i, id := range ids {
+		result[id] = int32(i + 1)
+	}
+	return result
+}
+
+// Build the consensus ranking with every player's rank from each source, in the order of
+// the consensus ranking.
+func consensusRanking(r *model.Ranking, sources []model.ConsensusSource) *model.ConsensusRanking {
+	cr := &model.ConsensusRanking{
+		Ranking: r,
+		Sources: sources,
+		Players: make([]model.ConsensusPlayer, 0, len(r.Players)),
+	}
+	for _, p := range r.Players {
+		avg, stdDev, ranks := weightedRank(p.ID, sources)
+		cr.Players = append(cr.Players, model.ConsensusPlayer{Player: p, Ranks: ranks, Average: avg, StdDev: stdDev})
+	}
+	slices.SortFunc(cr.Players, func(a, b model.ConsensusPlayer) int {
+		return cmp.Compare(a.Player.Rank, b.Player.Rank)
+	})
+	return cr
+}
+
+// Get the weighted average and standard deviation of the player's rank from the sources,
+// using the missing rank of the sources the player isn't in. The ranks from each source
+// are also returned, with 0 for the sources the player isn't in.
+func weightedRank(playerID string, sources []model.ConsensusSource) (float64, float64, []int32) {
+	ranks := make([]int32, len(sources))
+	var sum, totalWeight float64
+	for i, s := range sources {
+		rank, found := s.Ranks[playerID]
+		if found {
+			ranks[i] = rank
+		} else {
+			rank = s.MissingRank
+		}
+		sum += s.Weight * float64(rank)
+		totalWeight += s.Weight
+	}
+	if totalWeight == 0 {
+		return 0, 0, ranks
+	}
+	avg := sum / totalWeight
+
+	var variance float64
+	for i, s := range sources {
+		rank := ranks[i]
+		if rank == 0 {
+			rank = s.MissingRank
+		}
+		variance += s.Weight * math.Pow(float64(rank)-avg, 2)
+	}
+	return avg, math.Sqrt(variance / totalWeight), ranks
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestBlendRankings(t *testing.T) {
+	sources := func(weightB float64) []model.ConsensusSource {
+		return []model.ConsensusSource{
+			{RankingID: 1, Weight: 2, MissingRank: 4, Ranks: map[string]int32{"1": 1, "2": 2, "3": 3}},
+			{RankingID: 2, Weight: weightB, MissingRank: 3, Ranks: map[string]int32{"2": 1, "4": 2}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sources  []model.ConsensusSource
+		expected map[string]int32
+	}{
+		{
+			// Players 1 and 2 have the same average, so the tie is broken by id
+			name:     "missing players",
+			sources:  sources(1),
+			expected: map[string]int32{"1": 1, "2": 2, "3": 3, "4": 4},
+		},
+		{
+			name:     "weighted",
+			sources:  sources(10),
+			expected: map[string]int32{"2": 1, "4": 2, "1": 3, "3": 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := blendRankings(tc.sources)
+			if !reflect.DeepEqual(tc.expected, a) {
+				t.Errorf("expected: %v, got: %v", tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestWeightedRank(t *testing.T) {
+	sources := []model.ConsensusSource{
+		{RankingID: 1, Weight: 2, MissingRank: 4, Ranks: map[string]int32{"1": 1, "3": 3}},
+		{RankingID: 2, Weight: 1, MissingRank: 3, Ranks: map[string]int32{"4": 2}},
+	}
+
+	tests := []struct {
+		id     string
+		avg    float64
+		stdDev float64
+		ranks  []int32
+	}{
+		{id: "1", avg: 5.0 / 3, stdDev: math.Sqrt(24.0 / 27), ranks: []int32{1, 0}},
+		{id: "3", avg: 3, stdDev: 0, ranks: []int32{3, 0}},
+		{id: "4", avg: 10.0 / 3, stdDev: math.Sqrt(8.0 / 9), ranks: []int32{0, 2}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			avg, stdDev, ranks := weightedRank(tc.id, sources)
+			if math.Abs(tc.avg-avg) > 0.0001 || math.Abs(tc.stdDev-stdDev) > 0.0001 {
+				t.Errorf("expected average %.4f and std dev %.4f, got %.4f and %.4f", tc.avg, tc.stdDev, avg, stdDev)
+			}
+			if !reflect.DeepEqual(tc.ranks, ranks) {
+				t.Errorf("expected ranks: %v, got: %v", tc.ranks, ranks)
+			}
+		})
+	}
+}
+
+func TestAddConsensusRanking_errors(t *testing.T) {
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	tests := []struct {
+		name    string
+		sources []model.ConsensusSource
+		err     string
+	}{
+		{
+			name:    "one source",
+			sources: []model.ConsensusSource{{RankingID: 1, Weight: 1}},
+			err:     "a consensus ranking needs at least two rankings",
+		},
+		{
+			name:    "zero weight",
+			sources: []model.ConsensusSource{{RankingID: 1, Weight: 1}, {RankingID: 2}},
+			err:     "the weight of ranking 2 must be more than 0, got: 0",
+		},
+		{
+			name:    "same ranking twice",
+			sources: []model.ConsensusSource{{RankingID: 1, Weight: 1}, {RankingID: 1, Weight: 2}},
+			err:     "ranking 1 can only be used once",
+		},
+		{
+			name:    "negative missing rank",
+			sources: []model.ConsensusSource{{RankingID: 1, Weight: 1, MissingRank: -1}, {RankingID: 2, Weight: 1}},
+			err:     "the missing rank of ranking 1 can't be negative, got: -1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ctrl.AddConsensusRanking(context.Background(), model.ConsensusOptions{Date: time.Now(), Sources: tc.sources})
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("expected error '%s', got: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestConsensusRanking(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error getting players: %v", err)
+	}
+
+	date, _ := time.ParseInLocation(time.DateOnly, "2023-09-07", time.UTC)
+	id1, err := ctrl.AddRanking(ctx, strings.NewReader(rankingsGood), date, model.RankingImportOptions{Scoring: model.SCORING_HALF})
+	if err != nil {
+		t.Fatalf("error adding first ranking: %v", err)
+	}
+	defer ctrl.DeleteRanking(ctx, id1)
+	id2, err := ctrl.AddRanking(ctx, strings.NewReader(rankingsJSON), date, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding second ranking: %v", err)
+	}
+	defer ctrl.DeleteRanking(ctx, id2)
+
+	// The JSON ranking only has two players, so the rest get rank 3 from it
+	id, err := ctrl.AddConsensusRanking(ctx, model.ConsensusOptions{Date: date, Sources: []model.ConsensusSource{
+		{RankingID: id1, Weight: 1},
+		{RankingID: id2, Weight: 1},
+	}})
+	if err != nil {
+		t.Fatalf("error adding consensus ranking: %v", err)
+	}
+	defer ctrl.DeleteRanking(ctx, id)
+
+	cr, err := ctrl.GetConsensusRanking(ctx, id)
+	if err != nil {
+		t.Fatalf("error getting consensus ranking: %v", err)
+	}
+	if cr.Ranking.Source != model.RankingSourceConsensus || cr.Ranking.Scoring != model.SCORING_UNKNOWN {
+		t.Errorf("unexpected source and scoring: %s, %s", cr.Ranking.Source, cr.Ranking.Scoring)
+	}
+	if len(cr.Sources) != 2 || cr.Sources[0].MissingRank != 8 || cr.Sources[1].MissingRank != 3 {
+		t.Fatalf("unexpected sources: %v", cr.Sources)
+	}
+
+	expectedOrder := []string{testutils.IDJefferson, testutils.IDMcCaffrey, testutils.IDChase, testutils.IDChubb, testutils.IDTucker, testutils.IDKelce, testutils.IDHill}
+	if len(cr.Players) != len(expectedOrder) {
+		t.Fatalf("expected %d players, got: %v", len(expectedOrder), cr.Players)
+	}
+	for i, p := range cr.Players {
+		if p.Player.ID != expectedOrder[i] || p.Player.Rank != int32(i+1) {
+			t.Errorf("expected player %s at rank %d, got: %v", expectedOrder[i], i+1, p)
+		}
+	}
+	chubb := cr.Players[3]
+	if !reflect.DeepEqual([]int32{4, 0}, chubb.Ranks) || chubb.Average != 3.5 || chubb.StdDev != 0.5 {
+		t.Errorf("unexpected ranks for Nick Chubb: %v", chubb)
+	}
+
+	if _, err := ctrl.GetConsensusRanking(ctx, id1); err == nil {
+		t.Error("expected an error getting a ranking that isn't a consensus ranking")
+	}
+}
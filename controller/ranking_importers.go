@@ -0,0 +1,423 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/platforms/yahoo"
+)
+
+// How much of a rankings file is looked at to detect its format.
+const rankingDetectSize = 4096
+
+// A rankingImporter reads the player ranks from one format of rankings file.
+type rankingImporter interface {
+	// The source saved with the rankings, one of the model.RankingSources.
+	source() string
+	// Whether the start of a file looks like it is in this format.
+	detect(start []byte) bool
+	// Read the ranks from the file. Players at positions that aren't used are skipped.
+	// The importer can set opts.Scoring if the file says what scoring it is for.
+	read(r io.Reader, opts *model.RankingImportOptions) ([]rankingLine, error)
+}
+
+// The supported formats, in the order they are checked when detecting the format
+// of a file. The generic CSV format accepts anything, so it must be last.
+var rankingImporters = []rankingImporter{
+	&fantasyProsImporter{},
+	&sleeperADPImporter{},
+	&yahooRankingImporter{},
+	&jsonRankingImporter{},
+	&csvRankingImporter{},
+}
+
+// Read all of the lines from a rankings file. If opts.Source isn't set the format is
+// detected from the start of the file and opts.Source is set to it.
+func readRanking(r io.Reader, opts *model.RankingImportOptions) ([]rankingLine, error) {
+	var importer rankingImporter
+	if opts.Source != "" {
+		idx := slices.IndexFunc(rankingImporters, func(i rankingImporter) bool { return i.source() == opts.Source })
+		if idx == -1 {
+			return nil, fmt.Errorf("unknown rankings source: %s", opts.Source)
+		}
+		importer = rankingImporters[idx]
+	} else {
+		br := bufio.NewReaderSize(r, rankingDetectSize)
+		start, err := br.Peek(rankingDetectSize)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error reading rankings file: %w", err)
+		}
+		// Files saved from spreadsheets sometimes start with a byte order mark
+		if bom := []byte("\ufeff"); bytes.HasPrefix(start, bom) {
+			br.Discard(len(bom))
+			start = start[len(bom):]
+		}
+		start = bytes.TrimLeft(start, " \t\r\n")
+		if len(start) == 0 {
+			return nil, errors.New("rankings file is empty")
+		}
+
+		for _, i := range rankingImporters {
+			if i.detect(start) {
+				importer = i
+				break
+			}
+		}
+		opts.Source = importer.source()
+		r = br
+	}
+
+	lines, err := importer.read(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no players found in %s rankings", importer.source())
+	}
+	return lines, nil
+}
+
+// Rankings downloaded from fantasypros, see newFantasyProsCSVReader.
+type fantasyProsImporter struct{}
+
+func (*fantasyProsImporter) source() string {
+	return model.RankingSourceFantasyPros
+}
+
+func (*fantasyProsImporter) detect(start []byte) bool {
+	header, _, _ := bytes.Cut(start, []byte("\n"))
+	return bytes.Contains(header, []byte("PLAYER NAME")) && bytes.Contains(header, []byte("RK"))
+}
+
+func (*fantasyProsImporter) read(r io.Reader, _ *model.RankingImportOptions) ([]rankingLine, error) {
+	reader, err := newFantasyProsCSVReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]rankingLine, 0, 500)
+	for {
+		line, err := reader.readLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if errors.Is(err, errUnusedPosition) {
+				continue
+			}
+			return nil, err
+		}
+		lines = append(lines, *line)
+	}
+	return lines, nil
+}
+
+// A CSV file with a header row. The columns are found using the names in opts.Columns,
+// or the common names for them if they aren't set. Only the name column is required,
+// without a rank column the players are ranked in the order they are listed.
+type csvRankingImporter struct{}
+
+var (
+	csvRankColumns     = []string{"rank", "rk", "overall", "ecr"}
+	csvNameColumns     = []string{"name", "player", "player name", "player_name"}
+	csvTeamColumns     = []string{"team", "tm", "nfl team"}
+	csvPositionColumns = []string{"position", "pos"}
+)
+
+func (*csvRankingImporter) source() string {
+	return model.RankingSourceCSV
+}
+
+func (*csvRankingImporter) detect(start []byte) bool {
+	return true
+}
+
+func (*csvRankingImporter) read(r io.Reader, opts *model.RankingImportOptions) ([]rankingLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV file header: %v", err)
+	}
+
+	rankIdx := findColumn(header, opts.Columns.Rank, csvRankColumns)
+	nameIdx := findColumn(header, opts.Columns.Name, csvNameColumns)
+	teamIdx := findColumn(header, opts.Columns.Team, csvTeamColumns)
+	posIdx := findColumn(header, opts.Columns.Position, csvPositionColumns)
+	if nameIdx == -1 {
+		return nil, fmt.Errorf("error finding the player name column in %v", header)
+	}
+	// A column that was asked for by name must be there
+	for _, c := range []struct {
+		name string
+		idx  int
+	}{{opts.Columns.Rank, rankIdx}, {opts.Columns.Team, teamIdx}, {opts.Columns.Position, posIdx}} {
+		if c.name != "" && c.idx == -1 {
+			return nil, fmt.Errorf("column %s not found in %v", c.name, header)
+		}
+	}
+
+	field := func(record []string, idx int) string {
+		if idx == -1 || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	lines := make([]rankingLine, 0, 500)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading line in rankings file (%v): %w", record, err)
+		}
+
+		name := field(record, nameIdx)
+		if name == "" {
+			continue
+		}
+
+		line := rankingLine{rank: int32(row), name: model.TrimNameSuffix(name)}
+		if rankIdx != -1 {
+			rank, err := strconv.Atoi(field(record, rankIdx))
+			if err != nil {
+				return nil, fmt.Errorf("error parsing ranking (%v): %w", record, err)
+			}
+			line.rank = int32(rank)
+		}
+
+		if line.team, err = parseRankingTeam(field(record, teamIdx)); err != nil {
+			return nil, fmt.Errorf("%w for %s", err, line.name)
+		}
+
+		if posIdx != -1 {
+			line.pos = parseRankingPosition(field(record, posIdx))
+			if line.pos == model.POS_UNKNOWN {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// Get the index of the column named name, or if name is empty the first column with one
+// of the default names. The names are not case sensitive. Returns -1 if not found.
+func findColumn(header []string, name string, defaults []string) int {
+	names := defaults
+	if name != "" {
+		names = []string{name}
+	}
+	for _, n := range names {
+		idx := slices.IndexFunc(header, func(h string) bool {
+			return strings.EqualFold(strings.TrimSpace(h), n)
+		})
+		if idx != -1 {
+			return idx
+		}
+	}
+	return -1
+}
+
+// A JSON array of players, e.g.
+//
+//	[{"rank": 1, "name": "Justin Jefferson", "team": "MIN", "position": "WR"}]
+//
+// If a player has a player_id it is used instead of searching for the player. Players
+// without a rank are ranked in the order they are listed.
+type jsonRankingImporter struct{}
+
+type jsonRankingPlayer struct {
+	Rank     int32  `json:"rank"`
+	PlayerID string `json:"player_id"`
+	Name     string `json:"name"`
+	Team     string `json:"team"`
+	Position string `json:"position"`
+}
+
+func (*jsonRankingImporter) source() string {
+	return model.RankingSourceJSON
+}
+
+func (*jsonRankingImporter) detect(start []byte) bool {
+	return start[0] == '['
+}
+
+func (*jsonRankingImporter) read(r io.Reader, _ *model.RankingImportOptions) ([]rankingLine, error) {
+	var players []jsonRankingPlayer
+	if err := json.NewDecoder(r).Decode(&players); err != nil {
+		return nil, fmt.Errorf("error parsing JSON rankings: %w", err)
+	}
+
+	lines := make([]rankingLine, 0, len(players))
+	for i, p := range players {
+		if p.Name == "" && p.PlayerID == "" {
+			return nil, fmt.Errorf("player %d in the rankings has no name or player_id", i+1)
+		}
+
+		line := rankingLine{
+			rank:     cmp.Or(p.Rank, int32(i+1)),
+			name:     model.TrimNameSuffix(p.Name),
+			playerID: p.PlayerID,
+		}
+		var err error
+		if line.team, err = parseRankingTeam(p.Team); err != nil {
+			return nil, fmt.Errorf("%w for %s", err, line.name)
+		}
+		if p.Position != "" {
+			line.pos = parseRankingPosition(p.Position)
+			if line.pos == model.POS_UNKNOWN {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// The ADP of players from Sleeper's projections, e.g.
+// https://api.sleeper.com/projections/nfl/2024?season_type=regular&position[]=QB&position[]=RB&position[]=WR&position[]=TE
+// The players are ranked by their ADP for the scoring format, half PPR by default.
+// Sleeper's player ids are the ids used for players here.
+type sleeperADPImporter struct{}
+
+type sleeperADPPlayer struct {
+	PlayerID string `json:"player_id"`
+	Player   struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Position  string `json:"position"`
+		Team      string `json:"team"`
+	} `json:"player"`
+	Stats map[string]float64 `json:"stats"`
+}
+
+// The stat with the ADP for each scoring format.
+var sleeperADPStats = map[model.ScoringFormat]string{
+	model.SCORING_PPR:       "adp_ppr",
+	model.SCORING_HALF:      "adp_half_ppr",
+	model.SCORING_STANDARD:  "adp_std",
+	model.SCORING_SUPERFLEX: "adp_2qb",
+	model.SCORING_DYNASTY:   "adp_dynasty_ppr",
+}
+
+// Sleeper uses this ADP for players that aren't being drafted.
+const sleeperNoADP = 999
+
+func (*sleeperADPImporter) source() string {
+	return model.RankingSourceSleeper
+}
+
+func (*sleeperADPImporter) detect(start []byte) bool {
+	return start[0] == '[' && bytes.Contains(start, []byte(`"player_id"`)) && bytes.Contains(start, []byte(`"adp_`))
+}
+
+func (*sleeperADPImporter) read(r io.Reader, opts *model.RankingImportOptions) ([]rankingLine, error) {
+	var players []sleeperADPPlayer
+	if err := json.NewDecoder(r).Decode(&players); err != nil {
+		return nil, fmt.Errorf("error parsing sleeper ADP: %w", err)
+	}
+
+	if opts.Scoring == model.SCORING_UNKNOWN {
+		opts.Scoring = model.SCORING_HALF
+	}
+	stat := sleeperADPStats[opts.Scoring]
+
+	type adp struct {
+		player *sleeperADPPlayer
+		adp    float64
+	}
+	drafted := make([]adp, 0, len(players))
+	for i := range players {
+		a, found := players[i].Stats[stat]
+		if !found || a >= sleeperNoADP {
+			continue
+		}
+		drafted = append(drafted, adp{player: &players[i], adp: a})
+	}
+	slices.SortStableFunc(drafted, func(a, b adp) int {
+		return cmp.Compare(a.adp, b.adp)
+	})
+
+	lines := make([]rankingLine, 0, len(drafted))
+	for _, d := range drafted {
+		p := d.player
+		line := rankingLine{
+			rank:     int32(len(lines) + 1),
+			name:     model.TrimNameSuffix(strings.TrimSpace(p.Player.FirstName + " " + p.Player.LastName)),
+			pos:      model.ParsePosition(p.Player.Position),
+			playerID: p.PlayerID,
+		}
+		if p.Player.Team != "" {
+			line.team = model.ParseTeam(p.Player.Team)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// A Yahoo players collection in XML, like the response from
+// https://fantasysports.yahooapis.com/fantasy/v2/game/nfl/players;sort=AR
+// The players are ranked in the order they are listed.
+type yahooRankingImporter struct{}
+
+func (*yahooRankingImporter) source() string {
+	return model.RankingSourceYahoo
+}
+
+func (*yahooRankingImporter) detect(start []byte) bool {
+	return start[0] == '<' && bytes.Contains(start, []byte("<fantasy_content"))
+}
+
+func (*yahooRankingImporter) read(r io.Reader, _ *model.RankingImportOptions) ([]rankingLine, error) {
+	players, err := yahoo.ParseRankings(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]rankingLine, 0, len(players))
+	for i := range players {
+		p := &players[i]
+		lines = append(lines, rankingLine{
+			rank:  int32(i + 1),
+			name:  strings.TrimSpace(p.FirstName + " " + p.LastName),
+			pos:   p.Pos,
+			yahoo: p,
+		})
+	}
+	return lines, nil
+}
+
+// Parse the team from a rankings file, an empty team means the team isn't known.
+func parseRankingTeam(t string) (*model.NFLTeam, error) {
+	if t == "" {
+		return nil, nil
+	}
+	team := model.ParseTeam(t)
+	if team == model.TEAM_FA && !strings.EqualFold(t, "FA") {
+		return nil, errors.New("bad team name")
+	}
+	return team, nil
+}
+
+// Parse a position that is either just the position, or the position and the player's
+// rank at it like fantasypros uses.
+func parseRankingPosition(p string) model.Position {
+	if pos := model.ParsePosition(p); pos != model.POS_UNKNOWN {
+		return pos
+	}
+	return getPosition(strings.ToUpper(p))
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestReadRankingDetectsFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		source  string
+		scoring model.ScoringFormat
+	}{
+		{name: "fantasypros", data: rankingsGood, source: model.RankingSourceFantasyPros},
+		{name: "fantasypros with byte order mark", data: "\ufeff" + rankingsGood, source: model.RankingSourceFantasyPros},
+		{name: "generic csv", data: rankingsGenericCSV, source: model.RankingSourceCSV},
+		{name: "json", data: rankingsJSON, source: model.RankingSourceJSON},
+		{name: "sleeper adp", data: rankingsSleeperADP, source: model.RankingSourceSleeper, scoring: model.SCORING_HALF},
+		{name: "yahoo", data: rankingsYahoo, source: model.RankingSourceYahoo},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := model.RankingImportOptions{}
+			lines, err := readRanking(strings.NewReader(tc.data), &opts)
+			if err != nil {
+				t.Fatalf("error reading rankings: %v", err)
+			}
+			if opts.Source != tc.source || opts.Scoring != tc.scoring {
+				t.Errorf("expected source %s and scoring %s, got: %s and %s", tc.source, tc.scoring, opts.Source, opts.Scoring)
+			}
+			if len(lines) == 0 || lines[0].rank != 1 {
+				t.Errorf("expected the first line to have rank 1, got: %v", lines)
+			}
+		})
+	}
+
+	if _, err := readRanking(strings.NewReader(" \n"), &model.RankingImportOptions{}); err == nil {
+		t.Error("expected an error reading an empty file")
+	}
+	if _, err := readRanking(strings.NewReader(rankingsGood), &model.RankingImportOptions{Source: "other"}); err == nil {
+		t.Error("expected an error reading an unknown source")
+	}
+}
+
+func TestReadRankingLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		opts     model.RankingImportOptions
+		expected []rankingLine
+	}{
+		{
+			name: "generic csv",
+			data: rankingsGenericCSV,
+			expected: []rankingLine{
+				{rank: 1, name: "Justin Jefferson", team: model.TEAM_MIN, pos: model.POS_WR},
+				{rank: 2, name: "Christian McCaffrey", team: model.TEAM_SFO, pos: model.POS_RB},
+				{rank: 4, name: "Travis Kelce", team: model.TEAM_KCC, pos: model.POS_TE},
+			},
+		},
+		{
+			name: "csv with fantasypros positions and no team",
+			data: "RANK,NAME,POSITION\n3,Nick Chubb,RB2\n1,Josh Allen Jr.,QB1\n",
+			opts: model.RankingImportOptions{Source: model.RankingSourceCSV},
+			expected: []rankingLine{
+				{rank: 3, name: "Nick Chubb", pos: model.POS_RB},
+				{rank: 1, name: "Josh Allen", pos: model.POS_QB},
+			},
+		},
+		{
+			name: "mapped csv columns",
+			data: rankingsMappedCSV,
+			opts: model.RankingImportOptions{Columns: model.RankingColumns{Rank: "overall", Name: "FULL NAME", Team: "Club"}},
+			expected: []rankingLine{
+				{rank: 1, name: "Ja'Marr Chase", team: model.TEAM_CIN},
+				{rank: 2, name: "Tyreek Hill", team: model.TEAM_MIA},
+			},
+		},
+		{
+			name: "json",
+			data: rankingsJSON,
+			expected: []rankingLine{
+				{rank: 1, name: "Justin Jefferson", team: model.TEAM_MIN, pos: model.POS_WR},
+				{rank: 2, playerID: "4034"},
+			},
+		},
+		{
+			name: "sleeper adp half ppr",
+			data: rankingsSleeperADP,
+			opts: model.RankingImportOptions{Scoring: model.SCORING_HALF},
+			expected: []rankingLine{
+				{rank: 1, name: "Justin Jefferson", team: model.TEAM_MIN, pos: model.POS_WR, playerID: "6794"},
+				{rank: 2, name: "Travis Kelce", team: model.TEAM_KCC, pos: model.POS_TE, playerID: "1466"},
+			},
+		},
+		{
+			name: "yahoo",
+			data: rankingsYahoo,
+			expected: []rankingLine{
+				{rank: 1, name: "Ja'Marr Chase", pos: model.POS_WR, yahoo: &model.YahooPlayer{YahooID: "90001", FirstName: "Ja'Marr", LastName: "Chase", Pos: model.POS_WR}},
+				{rank: 2, name: "Nick Chubb", pos: model.POS_RB, yahoo: &model.YahooPlayer{YahooID: "90002", FirstName: "Nick", LastName: "Chubb", Pos: model.POS_RB}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, err := readRanking(strings.NewReader(tc.data), &tc.opts)
+			if err != nil {
+				t.Fatalf("error reading rankings: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expected, lines) {
+				t.Errorf("expected: %v, got: %v", tc.expected, lines)
+			}
+		})
+	}
+}
+
+func TestReadRankingErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts model.RankingImportOptions
+		err  string
+	}{
+		{name: "csv without name", data: "Rank,Team\n1,MIN\n", opts: model.RankingImportOptions{Source: model.RankingSourceCSV}, err: "error finding the player name column in [Rank Team]"},
+		{name: "mapped column missing", data: rankingsGenericCSV, opts: model.RankingImportOptions{Columns: model.RankingColumns{Rank: "Overall"}}, err: "column Overall not found in [Player Team Pos Bye]"},
+		{name: "csv bad team", data: "Name,Team\nJustin Jefferson,XXX\n", err: "bad team name for Justin Jefferson"},
+		{name: "json without name", data: `[{"rank": 1, "team": "MIN"}]`, err: "player 1 in the rankings has no name or player_id"},
+		{name: "yahoo without players", data: `<fantasy_content><game></game></fantasy_content>`, err: "no players found in yahoo rankings"},
+		{name: "no players", data: "Name,Pos\nJustin Tucker,PK\n", err: "no players found in csv rankings"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readRanking(strings.NewReader(tc.data), &tc.opts)
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("expected error %q, got: %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// The most candidates shown for an unmatched row.
+const maxRankingCandidates = 5
+
+// Name suffixes that aren't searched for when finding candidates.
+var nameSuffixes = []string{"jr", "sr", "ii", "iii", "iv", "v"}
+
+func (c *controller) ListUnmatchedRankingPlayers(ctx context.Context, rankingID int32) ([]model.UnmatchedRankingPlayer, error) {
+	unmatched, err := c.db.ListUnmatchedRankingPlayers(ctx, rankingID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range unmatched {
+		u := &unmatched[i]
+		players, err := c.findCandidates(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		u.Candidates = scoreCandidates(u, players)
+	}
+	return unmatched, nil
+}
+
+func (c *controller) ResolveUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32, playerID string, saveAlias bool) error {
+	var u *model.UnmatchedRankingPlayer
+	if saveAlias {
+		unmatched, err := c.db.ListUnmatchedRankingPlayers(ctx, rankingID)
+		if err != nil {
+			return err
+		}
+		idx := slices.IndexFunc(unmatched, func(u model.UnmatchedRankingPlayer) bool { return u.ID == unmatchedID })
+		if idx == -1 {
+			return fmt.Errorf("unmatched player %d not found for ranking %d", unmatchedID, rankingID)
+		}
+		u = &unmatched[idx]
+		// Every other row without a name would match the alias
+		if aliasName(u.Name) == "" {
+			return fmt.Errorf("unmatched player %d doesn't have a name to save an alias for", unmatchedID)
+		}
+	}
+
+	if err := c.db.ResolveUnmatchedRankingPlayer(ctx, rankingID, unmatchedID, playerID); err != nil {
+		return err
+	}
+
+	if u != nil {
+		if err := c.db.SavePlayerAlias(ctx, aliasName(u.Name), u.Position, playerID); err != nil {
+			return fmt.Errorf("player was added to the ranking, but there was an error saving the alias: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *controller) DismissUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32) error {
+	return c.db.DeleteUnmatchedRankingPlayer(ctx, rankingID, unmatchedID)
+}
+
+// Find the players that have any part of the row's name, and if the row has a team the
+// players on the team at the row's position, since the name might be misspelled.
+func (c *controller) findCandidates(ctx context.Context, u *model.UnmatchedRankingPlayer) ([]model.Player, error) {
+	words := make([]string, 0, 3)
+	for _, w := range strings.Fields(aliasName(u.Name)) {
+		if len(w) > 1 && !slices.Contains(nameSuffixes, w) {
+			words = append(words, w)
+		}
+	}
+
+	var players []model.Player
+	if len(words) > 0 {
+		byName, err := c.db.Search(ctx, strings.Join(words, " or "), model.POS_UNKNOWN, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error finding candidates for %s: %w", u.Name, err)
+		}
+		players = append(players, byName...)
+	}
+	if u.Team != nil {
+		byTeam, err := c.db.Search(ctx, "", u.Position, u.Team)
+		if err != nil {
+			return nil, fmt.Errorf("error finding candidates for %s: %w", u.Name, err)
+		}
+		for _, p := range byTeam {
+			if !slices.ContainsFunc(players, func(o model.Player) bool { return o.ID == p.ID }) {
+				players = append(players, p)
+			}
+		}
+	}
+	return players, nil
+}
+
+// Score how similar each of the players is to the unmatched row, and return the best
+// ones first. The name is worth 60 points, and the team and position 20 points each.
+func scoreCandidates(u *model.UnmatchedRankingPlayer, players []model.Player) []model.RankingCandidate {
+	candidates := make([]model.RankingCandidate, 0, len(players))
+	for _, p := range players {
+		score := int(math.Round(nameSimilarity(u.Name, p.FirstName+" "+p.LastName) * 60))
+		if u.Team != nil && u.Team.Equals(p.Team) {
+			score += 20
+		}
+		if u.Position != model.POS_UNKNOWN && u.Position == p.Position {
+			score += 20
+		}
+		candidates = append(candidates, model.RankingCandidate{Player: p, Score: score})
+	}
+
+	slices.SortFunc(candidates, func(a, b model.RankingCandidate) int {
+		if a.Score == b.Score {
+			return cmp.Compare(a.Player.ID, b.Player.ID)
+		}
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return candidates[:min(len(candidates), maxRankingCandidates)]
+}
+
+// How similar two names are from 0 to 1, based on the number of letters that need to
+// change to turn one into the other.
+func nameSimilarity(a, b string) float64 {
+	ra := []rune(aliasName(model.TrimNameSuffix(a)))
+	rb := []rune(aliasName(model.TrimNameSuffix(b)))
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// The number of single letter insertions, deletions or substitutions to turn a into b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// Normalize a name so small differences in how it is written don't matter, e.g.
+// "D.J. Moore" and "DJ Moore" are both "dj moore".
+func aliasName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else if unicode.IsSpace(r) || r == '-' {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
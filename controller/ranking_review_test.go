@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestAliasName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "D.J. Moore", expected: "dj moore"},
+		{input: "DJ Moore", expected: "dj moore"},
+		{input: "Ja'Marr  Chase", expected: "jamarr chase"},
+		{input: "Amon-Ra St. Brown", expected: "amon ra st brown"},
+		{input: " Travis Kelce ", expected: "travis kelce"},
+	}
+
+	for _, tc := range tests {
+		if a := aliasName(tc.input); a != tc.expected {
+			t.Errorf("input: '%s', expected: '%s', got: '%s'", tc.input, tc.expected, a)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	if s := nameSimilarity("D.J. Moore", "DJ Moore"); s != 1 {
+		t.Errorf("expected names that only differ by punctuation to be the same, got: %f", s)
+	}
+	if s := nameSimilarity("Kenneth Walker Jr.", "Kenneth Walker"); s != 1 {
+		t.Errorf("expected names that only differ by a suffix to be the same, got: %f", s)
+	}
+	// One letter out of 13 is different
+	if s := nameSimilarity("Jamarr Chasse", "Ja'Marr Chase"); math.Abs(s-12.0/13.0) > 0.000001 {
+		t.Errorf("unexpected similarity: %f", s)
+	}
+	if s := nameSimilarity("", ""); s != 0 {
+		t.Errorf("expected empty names to not be similar, got: %f", s)
+	}
+	if nameSimilarity("Gabe Davis", "Gabriel Davis") <= nameSimilarity("Gabe Davis", "Mike Evans") {
+		t.Error("expected Gabriel Davis to be more similar than Mike Evans")
+	}
+}
+
+func TestScoreCandidates(t *testing.T) {
+	u := &model.UnmatchedRankingPlayer{Name: "Mike Williams", Team: model.TEAM_NYJ, Position: model.POS_WR}
+	players := []model.Player{
+		{ID: "1", FirstName: "Mike", LastName: "Williams", Team: model.TEAM_LAC, Position: model.POS_WR},
+		{ID: "2", FirstName: "Tyrell", LastName: "Williams", Team: model.TEAM_NYJ, Position: model.POS_WR},
+		{ID: "3", FirstName: "Mike", LastName: "Williams", Team: model.TEAM_NYJ, Position: model.POS_WR},
+		{ID: "4", FirstName: "Garrett", LastName: "Wilson", Team: model.TEAM_NYJ, Position: model.POS_WR},
+		{ID: "5", FirstName: "Mike", LastName: "Evans", Team: model.TEAM_TBB, Position: model.POS_WR},
+		{ID: "6", FirstName: "Mike", LastName: "Williams", Team: model.TEAM_NYJ, Position: model.POS_TE},
+	}
+
+	candidates := scoreCandidates(u, players)
+	if len(candidates) != maxRankingCandidates {
+		t.Fatalf("expected %d candidates, got: %v", maxRankingCandidates, candidates)
+	}
+
+	// Ties are ordered by player id
+	expectedIDs := []string{"3", "1", "2", "6"}
+	expectedScores := []int{100, 80, 80, 80}
+	for i, id := range expectedIDs {
+		if candidates[i].Player.ID != id || candidates[i].Score != expectedScores[i] {
+			t.Errorf("expected candidate %d to be %s with score %d, got: %v", i, id, expectedScores[i], candidates[i])
+		}
+	}
+	for _, c := range candidates {
+		if c.Player.ID == "5" {
+			t.Errorf("expected Mike Evans to not be one of the best candidates, got: %v", candidates)
+		}
+	}
+
+	if c := scoreCandidates(u, nil); len(c) != 0 {
+		t.Errorf("expected no candidates, got: %v", c)
+	}
+}
+
+func TestRankingReview(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error getting players: %v", err)
+	}
+
+	date, _ := time.ParseInLocation(time.DateOnly, "2023-09-14", time.UTC)
+	id, err := ctrl.AddRanking(ctx, strings.NewReader(rankingsReview), date, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding a ranking: %v", err)
+	}
+	defer ctrl.DeleteRanking(ctx, id)
+
+	ranking, err := ctrl.GetRanking(ctx, id)
+	if err != nil {
+		t.Fatalf("error getting ranking: %v", err)
+	}
+	if len(ranking.Players) != 1 || ranking.Unmatched != 2 {
+		t.Fatalf("expected 1 player and 2 unmatched players, got: %d and %d", len(ranking.Players), ranking.Unmatched)
+	}
+
+	unmatched, err := ctrl.ListUnmatchedRankingPlayers(ctx, id)
+	if err != nil {
+		t.Fatalf("error listing unmatched players: %v", err)
+	}
+	if len(unmatched) != 2 || unmatched[0].Name != "Jamarr Chasse" || unmatched[0].Rank != 2 || unmatched[1].Name != "Nobody Special" {
+		t.Fatalf("unexpected unmatched players: %v", unmatched)
+	}
+	if len(unmatched[0].Candidates) == 0 || unmatched[0].Candidates[0].Player.ID != testutils.IDChase {
+		t.Fatalf("expected Ja'Marr Chase to be the best candidate, got: %v", unmatched[0].Candidates)
+	}
+
+	if err := ctrl.ResolveUnmatchedRankingPlayer(ctx, id, unmatched[0].ID, testutils.IDJefferson, false); err == nil {
+		t.Error("expected an error resolving to a player that is already ranked")
+	}
+	if err := ctrl.ResolveUnmatchedRankingPlayer(ctx, id, unmatched[0].ID, testutils.IDChase, true); err != nil {
+		t.Fatalf("error resolving unmatched player: %v", err)
+	}
+	if err := ctrl.DismissUnmatchedRankingPlayer(ctx, id, unmatched[1].ID); err != nil {
+		t.Fatalf("error dismissing unmatched player: %v", err)
+	}
+	if err := ctrl.DismissUnmatchedRankingPlayer(ctx, id, unmatched[1].ID); err == nil {
+		t.Error("expected an error dismissing an unmatched player twice")
+	}
+
+	ranking, err = ctrl.GetRanking(ctx, id)
+	if err != nil {
+		t.Fatalf("error getting ranking: %v", err)
+	}
+	if ranking.Players[testutils.IDChase].Rank != 2 || ranking.Unmatched != 0 {
+		t.Errorf("expected Chase to be ranked 2nd with nothing left to review, got: %v and %d", ranking.Players, ranking.Unmatched)
+	}
+
+	// The alias matches the name in future imports
+	id2, err := ctrl.AddRanking(ctx, strings.NewReader(rankingsReview), date, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding a second ranking: %v", err)
+	}
+	defer ctrl.DeleteRanking(ctx, id2)
+
+	ranking, err = ctrl.GetRanking(ctx, id2)
+	if err != nil {
+		t.Fatalf("error getting second ranking: %v", err)
+	}
+	if ranking.Players[testutils.IDChase].Rank != 2 || ranking.Unmatched != 1 {
+		t.Errorf("expected the alias to match Chase, got: %v and %d", ranking.Players, ranking.Unmatched)
+	}
+
+	// A row without a name keeps its player id, and can't be saved as an alias
+	id3, err := ctrl.AddRanking(ctx, strings.NewReader(rankingsReviewNoName), date, model.RankingImportOptions{})
+	if err != nil {
+		t.Fatalf("error adding a ranking without names: %v", err)
+	}
+	defer ctrl.DeleteRanking(ctx, id3)
+
+	unmatched, err = ctrl.ListUnmatchedRankingPlayers(ctx, id3)
+	if err != nil {
+		t.Fatalf("error listing unmatched players: %v", err)
+	}
+	if len(unmatched) != 1 || unmatched[0].PlayerID != "99999" {
+		t.Fatalf("expected the unmatched row to have player id 99999, got: %v", unmatched)
+	}
+	if err := ctrl.ResolveUnmatchedRankingPlayer(ctx, id3, unmatched[0].ID, testutils.IDChubb, true); err == nil {
+		t.Error("expected an error saving an alias for a row without a name")
+	}
+	if err := ctrl.ResolveUnmatchedRankingPlayer(ctx, id3, unmatched[0].ID, testutils.IDChubb, false); err != nil {
+		t.Errorf("error resolving unmatched player without an alias: %v", err)
+	}
+}
+
+var rankingsReview = `Rank,Name,Team,Pos
+1,Justin Jefferson,MIN,WR
+2,Jamarr Chasse,CIN,WR
+3,Nobody Special,,RB`
+
+var rankingsReviewNoName = `[
+	{"rank": 1, "player_id": "4034"},
+	{"rank": 2, "player_id": "99999"}
+]`
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"encoding/csv"
 	"errors"
@@ -8,22 +9,29 @@ import (
 	"io"
 	"log"
 	"regexp"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/mww/fantasy_manager_v2/db"
 	"github.com/mww/fantasy_manager_v2/model"
 )
 
-// Add a new rankings for players. This will parse the data from the reader (in CSV format) and
-// create a new rankings data point. Returns the id of the new rankings and an error if there
-// was one.
-func (c *controller) AddRanking(ctx context.Context, r io.Reader, date time.Time) (int32, error) {
-	playerRankings, err := c.getPlayerRankingMap(ctx, r)
+// Add a new rankings for players. This will parse the data from the reader and create a new
+// rankings data point. The format of the data is detected unless opts.Source is set, see
+// rankingImporters for the supported formats. Rows that can't be matched to a player are
+// saved to be reviewed, see ListUnmatchedRankingPlayers. Returns the id of the new rankings
+// and an error if there was one.
+func (c *controller) AddRanking(ctx context.Context, r io.Reader, date time.Time, opts model.RankingImportOptions) (int32, error) {
+	playerRankings, unmatched, err := c.getPlayerRankingMap(ctx, r, &opts)
 	if err != nil {
 		return 0, err
 	}
+	if len(playerRankings) == 0 {
+		return 0, fmt.Errorf("none of the %d players in the rankings could be matched", len(unmatched))
+	}
 
-	ranking, err := c.db.AddRanking(ctx, date, playerRankings)
+	ranking, err := c.db.AddRanking(ctx, date, opts.Source, opts.Scoring, playerRankings, unmatched)
 	if err != nil {
 		return 0, err
 	}
@@ -43,57 +51,179 @@ func (c *controller) ListRankings(ctx context.Context) ([]model.Ranking, error)
 	return c.db.ListRankings(ctx)
 }
 
-func (c *controller) getPlayerRankingMap(ctx context.Context, r io.Reader) (map[string]int32, error) {
-	reader, err := newFantasyProsCSVReader(r)
+func (c *controller) CompareRankings(ctx context.Context, fromID, toID int32, pos model.Position) (*model.RankingComparison, error) {
+	from, err := c.GetRanking(ctx, fromID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error getting ranking %d: %w", fromID, err)
+	}
+	to, err := c.GetRanking(ctx, toID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ranking %d: %w", toID, err)
 	}
+	return compareRankings(from, to, pos), nil
+}
 
-	result := make(map[string]int32)
+func (c *controller) GetPlayerRankings(ctx context.Context, playerID string) ([]model.PlayerRankingPoint, error) {
+	return c.db.GetPlayerRankings(ctx, playerID)
+}
 
-	for {
-		line, err := reader.readLine()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			if errors.Is(err, errUnusedPosition) {
-				continue
-			}
-			return nil, err
+// Find the players that moved between the two rankings. Only players at pos are
+// compared, unless it is POS_UNKNOWN. Ranks are the overall ranks even when only
+// one position is compared.
+func compareRankings(from, to *model.Ranking, pos model.Position) *model.RankingComparison {
+	result := &model.RankingComparison{
+		From:     model.Ranking{ID: from.ID, Date: from.Date, Source: from.Source, Scoring: from.Scoring},
+		To:       model.Ranking{ID: to.ID, Date: to.Date, Source: to.Source, Scoring: to.Scoring},
+		Position: pos,
+	}
+	include := func(p model.RankingPlayer) bool {
+		return pos == model.POS_UNKNOWN || p.Position == pos
+	}
+
+	for id, p := range to.Players {
+		if !include(p) {
+			continue
+		}
+		prev, found := from.Players[id]
+		if !found {
+			result.New = append(result.New, model.RankingChange{Player: p, To: p.Rank})
+			continue
+		}
+		change := model.RankingChange{Player: p, From: prev.Rank, To: p.Rank, Change: prev.Rank - p.Rank}
+		if change.Change > 0 {
+			result.Risers = append(result.Risers, change)
+		} else if change.Change < 0 {
+			result.Fallers = append(result.Fallers, change)
 		}
+	}
+	for id, p := range from.Players {
+		if _, found := to.Players[id]; !found && include(p) {
+			result.Dropped = append(result.Dropped, model.RankingChange{Player: p, From: p.Rank})
+		}
+	}
+
+	// Sort by the size of the change, then by the new rank so the better players are first
+	byChange := func(a, b model.RankingChange) int {
+		if a.Change == b.Change {
+			return cmp.Compare(a.To, b.To)
+		}
+		return cmp.Compare(max(b.Change, -b.Change), max(a.Change, -a.Change))
+	}
+	slices.SortFunc(result.Risers, byChange)
+	slices.SortFunc(result.Fallers, byChange)
+	slices.SortFunc(result.New, func(a, b model.RankingChange) int { return cmp.Compare(a.To, b.To) })
+	slices.SortFunc(result.Dropped, func(a, b model.RankingChange) int { return cmp.Compare(a.From, b.From) })
+	return result
+}
+
+// The error for a line in a rankings file that didn't match a single player.
+var errNoRankingMatch = errors.New("no single match")
+
+// Read the rankings and match every line to a player. The lines that couldn't be matched
+// are returned separately. opts.Source and opts.Scoring are updated with the detected
+// format of the rankings.
+func (c *controller) getPlayerRankingMap(ctx context.Context, r io.Reader, opts *model.RankingImportOptions) (map[string]int32, []model.UnmatchedRankingPlayer, error) {
+	lines, err := readRanking(r, opts)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		query := fmt.Sprintf("%s team:%s pos:%s", line.name, line.team.String(), line.pos)
-		matches, err := c.Search(ctx, query)
+	result := make(map[string]int32)
+	unmatched := make([]model.UnmatchedRankingPlayer, 0)
+
+	for _, line := range lines {
+		id, err := c.findRankingPlayer(ctx, &line)
+		if errors.Is(err, errNoRankingMatch) {
+			log.Printf("no match found for %v, saving it for review: %v", &line, err)
+			unmatched = append(unmatched, model.UnmatchedRankingPlayer{
+				Rank:     line.rank,
+				Name:     line.name,
+				Team:     line.team,
+				Position: line.pos,
+				PlayerID: line.playerID,
+			})
+			continue
+		}
 		if err != nil {
-			return nil, fmt.Errorf("error finding player %v: %w", line, err)
+			return nil, nil, err
 		}
 
-		if len(matches) > 1 {
-			return nil, fmt.Errorf("found more than 1 match for %v, got %d", line, len(matches))
+		result[id] = line.rank
+	}
+
+	return result, unmatched, nil
+}
+
+// Find the id of the player for a line in a rankings file. Returns an error wrapping
+// errNoRankingMatch if there isn't a single player for the line.
+func (c *controller) findRankingPlayer(ctx context.Context, line *rankingLine) (string, error) {
+	if line.playerID != "" {
+		// Some rankings use the same player ids, but make sure the player exists
+		if p, err := c.db.GetPlayer(ctx, line.playerID); err == nil {
+			return p.ID, nil
 		}
+	}
 
-		if len(matches) == 0 {
-			// Retry the query without the pos - sometimes fantasy pros have different positions for players
-			query := fmt.Sprintf("%s team:%s", line.name, line.team.String())
-			matches, err = c.Search(ctx, query)
-			if err != nil {
-				return nil, fmt.Errorf("error finding player %v: %w", line, err)
-			}
+	// Names that were matched by hand before are used over searching. A line
+	// without a name can't have an alias.
+	if name := aliasName(line.name); name != "" {
+		id, err := c.db.GetPlayerAlias(ctx, name, line.pos)
+		if err == nil {
+			return id, nil
 		}
+		if !errors.Is(err, db.ErrPlayerNotFound) {
+			return "", err
+		}
+	}
 
-		if len(matches) != 1 {
-			if line.rank > 500 {
-				log.Printf("no match found for %v with rank %d, skipping", line, line.rank)
-				continue
-			}
-			return nil, fmt.Errorf("did not find only a single player for %v, got %d", line, len(matches))
+	if line.yahoo != nil {
+		ids, err := c.db.ConvertYahooPlayerIDs(ctx, []model.YahooPlayer{*line.yahoo})
+		if errors.Is(err, db.ErrPlayerNotFound) {
+			return "", fmt.Errorf("%w for %v: %v", errNoRankingMatch, line, err)
+		} else if err != nil {
+			return "", fmt.Errorf("error converting yahoo player %v: %w", line, err)
 		}
+		return ids[0], nil
+	}
 
-		result[matches[0].ID] = line.rank
+	// A line without a name or team, like a JSON row with an unknown player id,
+	// doesn't give the search anything to match on.
+	if line.name == "" && line.team == nil {
+		return "", fmt.Errorf("%w for %v, nothing to search on", errNoRankingMatch, line)
 	}
 
-	return result, nil
+	team := ""
+	if line.team != nil {
+		team = " team:" + line.team.String()
+	}
+
+	var matches []model.Player
+	var err error
+	if line.pos != model.POS_UNKNOWN {
+		query := fmt.Sprintf("%s%s pos:%s", line.name, team, line.pos)
+		matches, err = c.Search(ctx, query)
+		if err != nil {
+			return "", fmt.Errorf("error finding player %v: %w", line, err)
+		}
+
+		if len(matches) > 1 {
+			return "", fmt.Errorf("%w for %v, found %d players", errNoRankingMatch, line, len(matches))
+		}
+	}
+
+	if len(matches) == 0 {
+		// Retry the query without the pos - sometimes rankings have different positions for players
+		query := line.name + team
+		matches, err = c.Search(ctx, query)
+		if err != nil {
+			return "", fmt.Errorf("error finding player %v: %w", line, err)
+		}
+	}
+
+	if len(matches) != 1 {
+		return "", fmt.Errorf("%w for %v, found %d players", errNoRankingMatch, line, len(matches))
+	}
+	return matches[0].ID, nil
 }
 
 var errUnusedPosition = errors.New("unused position")
@@ -106,15 +236,24 @@ type fantasyprosCSVReader struct {
 	posIdx    int
 }
 
-type csvLine struct {
+// A single player's rank read from a rankings file.
+type rankingLine struct {
 	rank int32
 	name string
-	team *model.NFLTeam
+	team *model.NFLTeam // nil if the rankings don't include teams
 	pos  model.Position
+	// Set when the rankings use the same player ids, like Sleeper's
+	playerID string
+	// Set for Yahoo rankings, which use Yahoo's player ids
+	yahoo *model.YahooPlayer
 }
 
-func (l *csvLine) String() string {
-	return fmt.Sprintf("%d - %s %s %s", l.rank, l.name, l.team.String(), l.pos)
+func (l *rankingLine) String() string {
+	team := ""
+	if l.team != nil {
+		team = l.team.String()
+	}
+	return fmt.Sprintf("%d - %s %s %s", l.rank, l.name, team, l.pos)
 }
 
 func newFantasyProsCSVReader(r io.Reader) (*fantasyprosCSVReader, error) {
@@ -151,7 +290,7 @@ func newFantasyProsCSVReader(r io.Reader) (*fantasyprosCSVReader, error) {
 	return fp, nil
 }
 
-func (fp *fantasyprosCSVReader) readLine() (*csvLine, error) {
+func (fp *fantasyprosCSVReader) readLine() (*rankingLine, error) {
 	record, err := fp.csvReader.Read()
 	if errors.Is(err, io.EOF) {
 		return nil, err
@@ -160,7 +299,7 @@ func (fp *fantasyprosCSVReader) readLine() (*csvLine, error) {
 		return nil, fmt.Errorf("error reading line in rankings file (%v): %w", record, err)
 	}
 
-	line := csvLine{}
+	line := rankingLine{}
 
 	rank, err := strconv.Atoi(record[fp.rankIdx])
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -14,11 +15,16 @@ import (
 
 func TestGetPlayerRankingMap(t *testing.T) {
 	tests := map[string]struct {
-		csvData  string
-		err      error
-		expected map[string]int32
+		data      string
+		opts      model.RankingImportOptions
+		source    string
+		err       error
+		expected  map[string]int32
+		unmatched []string
+		// The player ids of the unmatched rows, only checked when set
+		unmatchedIDs []string
 	}{
-		"good rankings": {csvData: rankingsGood, err: nil, expected: map[string]int32{
+		"good rankings": {data: rankingsGood, source: model.RankingSourceFantasyPros, err: nil, expected: map[string]int32{
 			testutils.IDJefferson: 1,
 			testutils.IDMcCaffrey: 2,
 			testutils.IDChase:     3,
@@ -27,12 +33,48 @@ func TestGetPlayerRankingMap(t *testing.T) {
 			testutils.IDKelce:     6,
 			testutils.IDHill:      7,
 		}},
-		"different col order": {csvData: rankingsDiffColOrder, err: nil, expected: map[string]int32{
+		"different col order": {data: rankingsDiffColOrder, source: model.RankingSourceFantasyPros, err: nil, expected: map[string]int32{
 			testutils.IDJefferson: 1,
 			testutils.IDMcCaffrey: 2,
 		}},
-		"bad team name":    {csvData: rankingsBadTeamName, err: errors.New("bad team name for Christian McCaffrey"), expected: nil},
-		"missing team col": {csvData: rankingsMissingTeamColumn, err: errors.New("error finding required columns; rank: 0, name: 2, team: -1, pos: 3"), expected: nil},
+		"bad team name":    {data: rankingsBadTeamName, err: errors.New("bad team name for Christian McCaffrey"), expected: nil},
+		"missing team col": {data: rankingsMissingTeamColumn, err: errors.New("error finding required columns; rank: 0, name: 2, team: -1, pos: 3"), expected: nil},
+		"generic csv": {data: rankingsGenericCSV, source: model.RankingSourceCSV, err: nil, expected: map[string]int32{
+			testutils.IDJefferson: 1,
+			testutils.IDMcCaffrey: 2,
+			testutils.IDKelce:     4,
+		}},
+		"mapped csv columns": {
+			data:   rankingsMappedCSV,
+			opts:   model.RankingImportOptions{Columns: model.RankingColumns{Rank: "Overall", Name: "Full Name", Team: "Club"}},
+			source: model.RankingSourceCSV,
+			err:    nil,
+			expected: map[string]int32{
+				testutils.IDChase: 1,
+				testutils.IDHill:  2,
+			}},
+		"json": {data: rankingsJSON, source: model.RankingSourceJSON, err: nil, expected: map[string]int32{
+			testutils.IDJefferson: 1,
+			testutils.IDMcCaffrey: 2,
+		}},
+		"sleeper adp": {data: rankingsSleeperADP, opts: model.RankingImportOptions{Scoring: model.SCORING_PPR}, source: model.RankingSourceSleeper, err: nil, expected: map[string]int32{
+			testutils.IDKelce:     1,
+			testutils.IDJefferson: 2,
+		}},
+		"yahoo": {data: rankingsYahoo, source: model.RankingSourceYahoo, err: nil, expected: map[string]int32{
+			testutils.IDChase: 1,
+			testutils.IDChubb: 2,
+		}},
+		"json unknown player id": {data: rankingsJSONUnknownID, source: model.RankingSourceJSON, err: nil, expected: map[string]int32{
+			testutils.IDMcCaffrey: 1,
+		}, unmatched: []string{""}, unmatchedIDs: []string{"99999"}},
+		"yahoo unmatched": {data: rankingsYahooUnmatched, source: model.RankingSourceYahoo, err: nil, expected: map[string]int32{
+			testutils.IDChase: 1,
+		}, unmatched: []string{"Not APlayer"}},
+		"unmatched players": {data: rankingsUnmatched, source: model.RankingSourceCSV, err: nil, expected: map[string]int32{
+			testutils.IDJefferson: 1,
+		}, unmatched: []string{"Not A Player", "Nobody Special"}},
+		"wrong source": {data: rankingsGood, opts: model.RankingImportOptions{Source: model.RankingSourceJSON}, err: errors.New("error parsing JSON rankings: json: cannot unmarshal string into Go value of type []controller.jsonRankingPlayer"), expected: nil},
 	}
 
 	ctx := context.Background()
@@ -47,8 +89,8 @@ func TestGetPlayerRankingMap(t *testing.T) {
 	ctrl := c1.(*controller)
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			r := strings.NewReader(tc.csvData)
-			playerRanks, err := ctrl.getPlayerRankingMap(ctx, r)
+			r := strings.NewReader(tc.data)
+			playerRanks, unmatched, err := ctrl.getPlayerRankingMap(ctx, r, &tc.opts)
 			if tc.err == nil {
 				if err != nil {
 					t.Fatalf("expected err to be nil, but was: %v", err)
@@ -56,6 +98,25 @@ func TestGetPlayerRankingMap(t *testing.T) {
 				if !reflect.DeepEqual(tc.expected, playerRanks) {
 					t.Errorf("player ranks were not as expected - actual: %v", playerRanks)
 				}
+				if tc.opts.Source != tc.source {
+					t.Errorf("expected source %s, got: %s", tc.source, tc.opts.Source)
+				}
+				names := make([]string, 0, len(unmatched))
+				for _, u := range unmatched {
+					names = append(names, u.Name)
+				}
+				if len(names) != len(tc.unmatched) || (len(names) > 0 && !reflect.DeepEqual(tc.unmatched, names)) {
+					t.Errorf("unmatched players were not as expected - actual: %v", unmatched)
+				}
+				if tc.unmatchedIDs != nil {
+					ids := make([]string, 0, len(unmatched))
+					for _, u := range unmatched {
+						ids = append(ids, u.PlayerID)
+					}
+					if !reflect.DeepEqual(tc.unmatchedIDs, ids) {
+						t.Errorf("unmatched player ids were not as expected - actual: %v", ids)
+					}
+				}
 			} else {
 				if err == nil {
 					t.Error("expected an error, got nil instead")
@@ -81,7 +142,7 @@ func TestRankings(t *testing.T) {
 	date, _ := time.ParseInLocation(time.DateOnly, "2023-09-07", time.UTC)
 	r := strings.NewReader(rankingsGood)
 
-	id, err := ctrl.AddRanking(ctx, r, date)
+	id, err := ctrl.AddRanking(ctx, r, date, model.RankingImportOptions{Scoring: model.SCORING_HALF})
 	if err != nil {
 		t.Fatalf("error adding a ranking: %v", err)
 	}
@@ -96,6 +157,9 @@ func TestRankings(t *testing.T) {
 	if res1.Date.Format(time.DateOnly) != "2023-09-07" {
 		t.Fatalf("rankings date is not expected: %s", res1.Date.Format(time.DateOnly))
 	}
+	if res1.Source != model.RankingSourceFantasyPros || res1.Scoring != model.SCORING_HALF {
+		t.Errorf("unexpected rankings source and scoring: %s, %s", res1.Source, res1.Scoring)
+	}
 
 	expectedRankings := map[string]model.RankingPlayer{
 		testutils.IDJefferson: {Rank: 1, ID: testutils.IDJefferson, FirstName: "Justin", LastName: "Jefferson", Position: model.POS_WR, Team: model.TEAM_MIN},
@@ -136,6 +200,22 @@ func TestRankings(t *testing.T) {
 		t.Fatal("expected ranking id not found in list operation")
 	}
 
+	comparison, err := ctrl.CompareRankings(ctx, id, id, model.POS_UNKNOWN)
+	if err != nil {
+		t.Fatalf("error comparing rankings: %v", err)
+	}
+	if len(comparison.Risers)+len(comparison.Fallers)+len(comparison.New)+len(comparison.Dropped) != 0 {
+		t.Errorf("expected no changes comparing a ranking to itself, got: %v", comparison)
+	}
+
+	history, err := ctrl.GetPlayerRankings(ctx, testutils.IDJefferson)
+	if err != nil {
+		t.Fatalf("error getting player rankings: %v", err)
+	}
+	if !slices.ContainsFunc(history, func(p model.PlayerRankingPoint) bool { return p.RankingID == id && p.Rank == 1 }) {
+		t.Errorf("expected rank 1 in ranking %d, got: %v", id, history)
+	}
+
 	if err := ctrl.DeleteRanking(ctx, id); err != nil {
 		t.Fatalf("error deleting ranking: %v", err)
 	}
@@ -149,6 +229,86 @@ func TestRankings(t *testing.T) {
 	}
 }
 
+func TestCompareRankings(t *testing.T) {
+	player := func(id string, rank int32, pos model.Position) model.RankingPlayer {
+		return model.RankingPlayer{ID: id, Rank: rank, Position: pos}
+	}
+	from := &model.Ranking{ID: 1, Source: model.RankingSourceFantasyPros, Players: map[string]model.RankingPlayer{
+		"1": player("1", 1, model.POS_WR),
+		"2": player("2", 2, model.POS_RB),
+		"3": player("3", 3, model.POS_WR),
+		"4": player("4", 4, model.POS_TE),
+		"5": player("5", 5, model.POS_RB),
+		"6": player("6", 6, model.POS_WR),
+	}}
+	to := &model.Ranking{ID: 2, Source: model.RankingSourceCSV, Players: map[string]model.RankingPlayer{
+		"3": player("3", 1, model.POS_WR),
+		"1": player("1", 2, model.POS_WR),
+		"5": player("5", 3, model.POS_RB),
+		"7": player("7", 4, model.POS_WR),
+		"2": player("2", 5, model.POS_RB),
+		"8": player("8", 6, model.POS_RB),
+	}}
+
+	change := func(id string, fromRank, toRank int32) model.RankingChange {
+		c := model.RankingChange{From: fromRank, To: toRank}
+		if toRank != 0 {
+			c.Player = to.Players[id]
+		} else {
+			c.Player = from.Players[id]
+		}
+		if fromRank != 0 && toRank != 0 {
+			c.Change = fromRank - toRank
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		pos      model.Position
+		expected *model.RankingComparison
+	}{
+		{
+			name: "all positions",
+			pos:  model.POS_UNKNOWN,
+			expected: &model.RankingComparison{
+				Risers:  []model.RankingChange{change("3", 3, 1), change("5", 5, 3)},
+				Fallers: []model.RankingChange{change("2", 2, 5), change("1", 1, 2)},
+				New:     []model.RankingChange{change("7", 0, 4), change("8", 0, 6)},
+				Dropped: []model.RankingChange{change("4", 4, 0), change("6", 6, 0)},
+			},
+		},
+		{
+			name: "wide receivers",
+			pos:  model.POS_WR,
+			expected: &model.RankingComparison{
+				Risers:  []model.RankingChange{change("3", 3, 1)},
+				Fallers: []model.RankingChange{change("1", 1, 2)},
+				New:     []model.RankingChange{change("7", 0, 4)},
+				Dropped: []model.RankingChange{change("6", 6, 0)},
+			},
+		},
+		{
+			name:     "no players",
+			pos:      model.POS_K,
+			expected: &model.RankingComparison{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expected.From = model.Ranking{ID: 1, Source: model.RankingSourceFantasyPros}
+			tc.expected.To = model.Ranking{ID: 2, Source: model.RankingSourceCSV}
+			tc.expected.Position = tc.pos
+
+			a := compareRankings(from, to, tc.pos)
+			if !reflect.DeepEqual(tc.expected, a) {
+				t.Errorf("expected: %v, got: %v", tc.expected, a)
+			}
+		})
+	}
+}
+
 func TestGetPosition(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -194,4 +354,81 @@ var (
 	rankingsDiffColOrder = `"POS","RK",TIERS,"BYE WEEK",TEAM,"SOS SEASON","ECR VS. ADP","PLAYER NAME"
 "WR1","1",1,"13",MIN,"3 out of 5 stars","+1","Justin Jefferson"
 "RB1","2",1,"9",SF,"4 out of 5 stars","-1","Christian McCaffrey"`
+
+	// There is no rank column so the row is the rank, the kicker's position isn't ranked
+	// so he is skipped
+	rankingsGenericCSV = `Player,Team,Pos,Bye
+Justin Jefferson,MIN,WR,13
+Christian McCaffrey,SF,RB,9
+Justin Tucker,BAL,PK,13
+Travis Kelce,KC,TE,10`
+
+	rankingsMappedCSV = `Overall,Full Name,Club,Notes
+1,Ja'Marr Chase,CIN,
+2,Tyreek Hill,MIA,"trade target"`
+
+	rankingsUnmatched = `Rank,Name,Team,Pos
+1,Justin Jefferson,MIN,WR
+2,Not A Player,MIN,WR
+600,Nobody Special,,RB`
+
+	rankingsJSON = `[
+	{"rank": 1, "name": "Justin Jefferson", "team": "MIN", "position": "WR"},
+	{"rank": 2, "player_id": "4034"}
+]`
+
+	// The second player id isn't known and there's no name, team or position to search with
+	rankingsJSONUnknownID = `[
+	{"rank": 1, "player_id": "4034"},
+	{"rank": 2, "player_id": "99999"}
+]`
+
+	// Ranked by PPR ADP Kelce is first, the player without an ADP isn't ranked
+	rankingsSleeperADP = `[
+	{"player_id": "6794", "player": {"first_name": "Justin", "last_name": "Jefferson", "position": "WR", "team": "MIN"}, "stats": {"adp_ppr": 2.4, "adp_half_ppr": 1.2}},
+	{"player_id": "1466", "player": {"first_name": "Travis", "last_name": "Kelce", "position": "TE", "team": "KC"}, "stats": {"adp_ppr": 1.8, "adp_half_ppr": 3.1}},
+	{"player_id": "1264", "player": {"first_name": "Justin", "last_name": "Tucker", "position": "K", "team": "BAL"}, "stats": {"adp_ppr": 999, "adp_half_ppr": 999}}
+]`
+
+	rankingsYahoo = `<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <game>
+    <game_key>449</game_key>
+    <players count="2">
+      <player>
+        <player_key>449.p.90001</player_key>
+        <player_id>90001</player_id>
+        <name><full>Ja'Marr Chase</full><first>Ja'Marr</first><last>Chase</last></name>
+        <primary_position>WR</primary_position>
+      </player>
+      <player>
+        <player_key>449.p.90002</player_key>
+        <player_id>90002</player_id>
+        <name><full>Nick Chubb</full><first>Nick</first><last>Chubb</last></name>
+        <primary_position>RB</primary_position>
+      </player>
+    </players>
+  </game>
+</fantasy_content>`
+
+	rankingsYahooUnmatched = `<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <game>
+    <game_key>449</game_key>
+    <players count="2">
+      <player>
+        <player_key>449.p.90001</player_key>
+        <player_id>90001</player_id>
+        <name><full>Ja'Marr Chase</full><first>Ja'Marr</first><last>Chase</last></name>
+        <primary_position>WR</primary_position>
+      </player>
+      <player>
+        <player_key>449.p.90003</player_key>
+        <player_id>90003</player_id>
+        <name><full>Not APlayer</full><first>Not</first><last>APlayer</last></name>
+        <primary_position>WR</primary_position>
+      </player>
+    </players>
+  </game>
+</fantasy_content>`
 )
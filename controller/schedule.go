@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func (c *controller) GetStrengthOfSchedule(ctx context.Context, leagueID int32) ([]model.ScheduleStrength, error) {
+	l, err := c.GetLeague(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up league: %w", err)
+	}
+
+	weeklyResults, lastWeek, err := c.getAllResults(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Not every platform can provide the future matchups, the past schedule is
+	// still useful without them.
+	remaining, err := getPlatformAdapter(l.Platform, c).getSchedule(ctx, l, lastWeek)
+	if errors.Is(err, errScheduleNotSupported) {
+		log.Printf("remaining schedule not available for league %d: %v", leagueID, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting the remaining schedule: %w", err)
+	}
+
+	results := calculateScheduleStrength(weeklyResults, lastWeek, remaining)
+
+	// Teams that haven't played yet won't have a name from the results
+	names := make(map[string]string)
+	for _, m := range l.Managers {
+		names[m.ExternalID] = m.TeamName
+		if m.TeamName == "" {
+			names[m.ExternalID] = m.ManagerName
+		}
+	}
+	for i := range results {
+		if results[i].TeamName == "" {
+			results[i].TeamName = names[results[i].TeamID]
+		}
+	}
+	return results, nil
+}
+
+// A team's record and points scored so far, used to rate them as an opponent.
+type opponentRecord struct {
+	name   string
+	games  int
+	wins   float64 // Draws count as half a win
+	points int64
+}
+
+func (r *opponentRecord) winPct() float64 {
+	if r.games == 0 {
+		return 0
+	}
+	return r.wins / float64(r.games)
+}
+
+func (r *opponentRecord) pointsPerGame() float64 {
+	if r.games == 0 {
+		return 0
+	}
+	return float64(r.points) / 1000 / float64(r.games)
+}
+
+// Rate the schedule of every team using the results for every week up to and
+// including week. The remaining matchups are rated with each opponent's record
+// so far, they can be nil if the remaining schedule isn't known. The results are
+// sorted with the hardest past schedule first.
+func calculateScheduleStrength(weeklyResults map[int][]model.Matchup, week int, remaining []model.Matchup) []model.ScheduleStrength {
+	records := make(map[string]*opponentRecord)
+	getRecord := func(t *model.TeamResult) *opponentRecord {
+		r, found := records[t.TeamID]
+		if !found {
+			r = &opponentRecord{}
+			records[t.TeamID] = r
+		}
+		if r.name == "" {
+			r.name = t.TeamName
+		}
+		return r
+	}
+
+	played := make([]model.Matchup, 0, week*6)
+	for w := 1; w <= week; w++ {
+		for _, m := range weeklyResults[w] {
+			if m.TeamA == nil || m.TeamB == nil {
+				continue
+			}
+			played = append(played, m)
+
+			a, b := getRecord(m.TeamA), getRecord(m.TeamB)
+			a.games++
+			b.games++
+			a.points += int64(m.TeamA.Score)
+			b.points += int64(m.TeamB.Score)
+			if m.TeamA.Score > m.TeamB.Score {
+				a.wins++
+			} else if m.TeamA.Score < m.TeamB.Score {
+				b.wins++
+			} else {
+				a.wins += 0.5
+				b.wins += 0.5
+			}
+		}
+	}
+
+	strengths := make(map[string]*model.ScheduleStrength)
+	getStrength := func(teamID string) *model.ScheduleStrength {
+		s, found := strengths[teamID]
+		if !found {
+			s = &model.ScheduleStrength{TeamID: teamID}
+			strengths[teamID] = s
+		}
+		return s
+	}
+
+	for _, m := range played {
+		for _, pair := range [][2]*model.TeamResult{{m.TeamA, m.TeamB}, {m.TeamB, m.TeamA}} {
+			s, opponent := getStrength(pair[0].TeamID), records[pair[1].TeamID]
+			s.PastGames++
+			s.PastOpponentWinPct += opponent.winPct()
+			s.PastOpponentPoints += opponent.pointsPerGame()
+		}
+	}
+	for _, m := range remaining {
+		if m.TeamA == nil || m.TeamB == nil {
+			continue
+		}
+		for _, pair := range [][2]*model.TeamResult{{m.TeamA, m.TeamB}, {m.TeamB, m.TeamA}} {
+			s := getStrength(pair[0].TeamID)
+			s.RemainingGames++
+			if opponent, found := records[pair[1].TeamID]; found {
+				s.RemainingOpponentWinPct += opponent.winPct()
+				s.RemainingOpponentPoints += opponent.pointsPerGame()
+			}
+		}
+	}
+
+	results := make([]model.ScheduleStrength, 0, len(strengths))
+	for id, s := range strengths {
+		if r, found := records[id]; found {
+			s.TeamName = r.name
+		}
+		if s.PastGames > 0 {
+			s.PastOpponentWinPct = math.Round(s.PastOpponentWinPct/float64(s.PastGames)*1000) / 1000
+			s.PastOpponentPoints = math.Round(s.PastOpponentPoints/float64(s.PastGames)*100) / 100
+		}
+		if s.RemainingGames > 0 {
+			s.RemainingOpponentWinPct = math.Round(s.RemainingOpponentWinPct/float64(s.RemainingGames)*1000) / 1000
+			s.RemainingOpponentPoints = math.Round(s.RemainingOpponentPoints/float64(s.RemainingGames)*100) / 100
+		}
+		results = append(results, *s)
+	}
+
+	// A schedule is harder when the opponents win more, and then when they score more.
+	harder := func(winA, winB, pointsA, pointsB float64) int {
+		if winA == winB {
+			return cmp.Compare(pointsB, pointsA)
+		}
+		return cmp.Compare(winB, winA)
+	}
+
+	slices.SortFunc(results, func(a, b model.ScheduleStrength) int {
+		if c := harder(a.RemainingOpponentWinPct, b.RemainingOpponentWinPct, a.RemainingOpponentPoints, b.RemainingOpponentPoints); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+	rank := 0
+	for i := range results {
+		if results[i].RemainingGames > 0 {
+			rank++
+			results[i].RemainingRank = rank
+		}
+	}
+
+	slices.SortFunc(results, func(a, b model.ScheduleStrength) int {
+		if c := harder(a.PastOpponentWinPct, b.PastOpponentWinPct, a.PastOpponentPoints, b.PastOpponentPoints); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+	for i := range results {
+		results[i].PastRank = i + 1
+	}
+	return results
+}
+
+// Score each team by how many games over .500 their opponents have been, so
+// teams that have played a hard schedule get credit for it.
+func calculateScheduleScore(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) map[string]int32 {
+	scores := make(map[string]int32)
+	for _, s := range calculateScheduleStrength(weeklyResults, week, nil) {
+		gamesOver500 := (2*s.PastOpponentWinPct - 1) * float64(s.PastGames)
+		scores[s.TeamID] = int32(math.Round(gamesOver500 * float64(pr.Config.SchedulePoints)))
+	}
+	return scores
+}
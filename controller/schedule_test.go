@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestCalculateScheduleStrength(t *testing.T) {
+	_, weeklyResults := getDataForTest()
+
+	remaining := []model.Matchup{
+		{Week: 6, TeamA: &model.TeamResult{TeamID: "1"}, TeamB: &model.TeamResult{TeamID: "2"}},
+		{Week: 6, TeamA: &model.TeamResult{TeamID: "3"}, TeamB: &model.TeamResult{TeamID: "4"}},
+		{Week: 7, TeamA: &model.TeamResult{TeamID: "1"}, TeamB: &model.TeamResult{TeamID: "4"}},
+		{Week: 7, TeamA: &model.TeamResult{TeamID: "2"}, TeamB: &model.TeamResult{TeamID: "3"}},
+	}
+
+	// After 5 weeks the records are 1: 3-2, 2: 1-4, 3: 4-1, 4: 2-3
+	expected := []model.ScheduleStrength{
+		{TeamID: "2", PastGames: 5, PastOpponentWinPct: 0.56, PastOpponentPoints: 94.68, PastRank: 1,
+			RemainingGames: 2, RemainingOpponentWinPct: 0.7, RemainingOpponentPoints: 98.9, RemainingRank: 1},
+		{TeamID: "4", PastGames: 5, PastOpponentWinPct: 0.52, PastOpponentPoints: 99.36, PastRank: 2,
+			RemainingGames: 2, RemainingOpponentWinPct: 0.7, RemainingOpponentPoints: 98.9, RemainingRank: 2},
+		{TeamID: "1", PastGames: 5, PastOpponentWinPct: 0.48, PastOpponentPoints: 98.12, PastRank: 3,
+			RemainingGames: 2, RemainingOpponentWinPct: 0.3, RemainingOpponentPoints: 94.2, RemainingRank: 3},
+		{TeamID: "3", PastGames: 5, PastOpponentWinPct: 0.44, PastOpponentPoints: 94.04, PastRank: 4,
+			RemainingGames: 2, RemainingOpponentWinPct: 0.3, RemainingOpponentPoints: 94.2, RemainingRank: 4},
+	}
+
+	res := calculateScheduleStrength(weeklyResults, 5, remaining)
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("expected: %v, got: %v", expected, res)
+	}
+
+	// Without the remaining schedule only the past schedule is rated
+	res = calculateScheduleStrength(weeklyResults, 5, nil)
+	for _, s := range res {
+		if s.RemainingGames != 0 || s.RemainingRank != 0 {
+			t.Errorf("expected team %s to have no remaining schedule, got: %v", s.TeamID, s)
+		}
+	}
+	if res[0].TeamID != "2" || res[3].TeamID != "3" {
+		t.Errorf("unexpected order for the past schedule: %v", res)
+	}
+}
+
+func TestScheduleComponent(t *testing.T) {
+	pr, weeklyResults := getDataForTest()
+	in := &powerRankingInput{pr: pr, weeklyResults: weeklyResults, week: 5}
+
+	// The schedule component isn't used by default
+	calculateComponentScores(in, powerRankingComponents)
+	if _, found := pr.Teams[0].Scores[componentSchedule]; found {
+		t.Errorf("expected the schedule component to be disabled, got: %v", pr.Components)
+	}
+
+	pr.Config.SchedulePoints = 10
+	calculateComponentScores(in, powerRankingComponents)
+	if pr.Components[len(pr.Components)-1] != componentSchedule {
+		t.Fatalf("expected the schedule component to be enabled, got: %v", pr.Components)
+	}
+	expected := map[string]int32{"1": -2, "2": 6, "3": -6, "4": 2}
+	for _, team := range pr.Teams {
+		if team.Scores[componentSchedule] != expected[team.TeamID] {
+			t.Errorf("expected team %s to have a schedule score of %d, got: %d",
+				team.TeamID, expected[team.TeamID], team.Scores[componentSchedule])
+		}
+	}
+}
+
+func TestGetStrengthOfSchedule(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error adding players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		ctrl.ArchiveLeague(ctx, l.ID)
+	}()
+
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	const weeks = 3
+	for w := 1; w <= weeks; w++ {
+		if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, w); err != nil {
+			t.Fatalf("error syncing week %d results: %v", w, err)
+		}
+	}
+
+	res, err := ctrl.GetStrengthOfSchedule(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting strength of schedule: %v", err)
+	}
+	if len(res) != 4 {
+		t.Fatalf("expected 4 teams, got: %v", res)
+	}
+
+	// The playoffs start in week 15, so there are 11 regular season weeks left
+	for i, s := range res {
+		if s.PastGames != weeks || s.RemainingGames != 11 {
+			t.Errorf("expected team %s to have %d past and 11 remaining games, got: %v", s.TeamID, weeks, s)
+		}
+		if s.PastRank != i+1 || s.RemainingRank == 0 {
+			t.Errorf("unexpected ranks for team %s: %v", s.TeamID, s)
+		}
+		if s.TeamName == "" {
+			t.Errorf("expected team %s to have a name", s.TeamID)
+		}
+	}
+}
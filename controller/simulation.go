@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+const maxSimulations = 100000
+
+// ErrSimulationNotSupported is returned when the league's platform can't provide
+// the remaining schedule, so there is nothing to simulate.
+var ErrSimulationNotSupported = errors.New("season simulations are not supported for this platform")
+
+// ErrInvalidSimulationConfig is returned when the simulation config is out of range.
+var ErrInvalidSimulationConfig = errors.New("invalid simulation config")
+
+func (c *controller) SimulateSeason(ctx context.Context, leagueID int32, cfg model.SimulationConfig) (*model.SeasonSimulation, error) {
+	l, err := c.GetLeague(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up league: %w", err)
+	}
+
+	weeklyResults, lastWeek, err := c.getAllResults(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if lastWeek == 0 {
+		return nil, fmt.Errorf("no results have been synced for league %d", leagueID)
+	}
+
+	remaining, err := getPlatformAdapter(l.Platform, c).getSchedule(ctx, l, lastWeek)
+	if errors.Is(err, errScheduleNotSupported) {
+		return nil, fmt.Errorf("%s leagues can't be simulated: %w", l.Platform, ErrSimulationNotSupported)
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting the remaining schedule: %w", err)
+	}
+
+	// Pick a seed so the results can be repeated
+	if cfg.Seed == 0 {
+		cfg.Seed = uint64(c.clock.Now().UnixNano())
+	}
+
+	sim, err := simulateSeason(weeklyResults, lastWeek, remaining, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for _, m := range l.Managers {
+		names[m.ExternalID] = m.TeamName
+		if m.TeamName == "" {
+			names[m.ExternalID] = m.ManagerName
+		}
+	}
+	for i := range sim.Teams {
+		if sim.Teams[i].TeamName == "" {
+			sim.Teams[i].TeamName = names[sim.Teams[i].TeamID]
+		}
+	}
+	return sim, nil
+}
+
+func validateSimulationConfig(cfg *model.SimulationConfig, numTeams int) error {
+	if cfg.Simulations < 1 || cfg.Simulations > maxSimulations {
+		return fmt.Errorf("%w, simulations must be between 1 and %d, got: %d", ErrInvalidSimulationConfig, maxSimulations, cfg.Simulations)
+	}
+	if cfg.PlayoffTeams < 0 || cfg.PlayoffTeams > numTeams {
+		return fmt.Errorf("%w, playoff teams must be between 0 and %d, got: %d", ErrInvalidSimulationConfig, numTeams, cfg.PlayoffTeams)
+	}
+	if cfg.Byes < 0 || cfg.Byes > cfg.PlayoffTeams {
+		return fmt.Errorf("%w, byes must be between 0 and %d, got: %d", ErrInvalidSimulationConfig, cfg.PlayoffTeams, cfg.Byes)
+	}
+	return nil
+}
+
+// A team's current record and the distribution of their weekly scores.
+type simulatedTeam struct {
+	id     string
+	name   string
+	wins   int
+	losses int
+	draws  int
+	points int64   // Points scored so far, times 1000 like the results
+	scores []int32 // Every weekly score so far
+	mean   float64 // The mean and standard deviation of the weekly scores, times 1000
+	stdDev float64
+}
+
+// Draw a score for a week from the team's distribution.
+func (t *simulatedTeam) score(rng *rand.Rand) int64 {
+	return int64(math.Round(max(0, t.mean+t.stdDev*rng.NormFloat64())))
+}
+
+// Simulate the remaining matchups cfg.Simulations times, starting from the results
+// of every week up to and including week. Each team's weekly score is drawn from a
+// normal distribution of the scores they have had so far. The teams are seeded the
+// same way the standings are sorted.
+func simulateSeason(weeklyResults map[int][]model.Matchup, week int, remaining []model.Matchup, cfg model.SimulationConfig) (*model.SeasonSimulation, error) {
+	teams := make(map[string]*simulatedTeam)
+	getTeam := func(t *model.TeamResult) *simulatedTeam {
+		st, found := teams[t.TeamID]
+		if !found {
+			st = &simulatedTeam{id: t.TeamID}
+			teams[t.TeamID] = st
+		}
+		if st.name == "" {
+			st.name = t.TeamName
+		}
+		return st
+	}
+
+	allScores := make([]int32, 0, week*12)
+	for w := 1; w <= week; w++ {
+		for _, m := range weeklyResults[w] {
+			if m.TeamA == nil || m.TeamB == nil {
+				continue
+			}
+			a, b := getTeam(m.TeamA), getTeam(m.TeamB)
+			a.points += int64(m.TeamA.Score)
+			b.points += int64(m.TeamB.Score)
+			a.scores = append(a.scores, m.TeamA.Score)
+			b.scores = append(b.scores, m.TeamB.Score)
+			allScores = append(allScores, m.TeamA.Score, m.TeamB.Score)
+			if m.TeamA.Score > m.TeamB.Score {
+				a.wins++
+				b.losses++
+			} else if m.TeamA.Score < m.TeamB.Score {
+				a.losses++
+				b.wins++
+			} else {
+				a.draws++
+				b.draws++
+			}
+		}
+	}
+
+	games := make([]model.Matchup, 0, len(remaining))
+	weeks := make(map[int]bool)
+	for _, m := range remaining {
+		if m.TeamA == nil || m.TeamB == nil {
+			continue
+		}
+		getTeam(m.TeamA)
+		getTeam(m.TeamB)
+		games = append(games, m)
+		weeks[m.Week] = true
+	}
+
+	if err := validateSimulationConfig(&cfg, len(teams)); err != nil {
+		return nil, err
+	}
+	if len(allScores) == 0 {
+		return nil, fmt.Errorf("there are no scores to simulate from")
+	}
+
+	// Teams without enough scores of their own use the whole league's distribution
+	leagueMean, leagueStdDev := meanAndStdDev(allScores)
+	ids := make([]string, 0, len(teams))
+	for id, t := range teams {
+		ids = append(ids, id)
+		t.mean, t.stdDev = leagueMean, leagueStdDev
+		if len(t.scores) > 0 {
+			t.mean, _ = meanAndStdDev(t.scores)
+		}
+		if len(t.scores) > 1 {
+			_, t.stdDev = meanAndStdDev(t.scores)
+		}
+	}
+	// Go through the teams in a fixed order so the same seed gives the same results
+	slices.Sort(ids)
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	n := len(ids)
+	wins := make([]int, n)
+	losses := make([]int, n)
+	draws := make([]int, n)
+	points := make([]int64, n)
+	totalWins := make([]int, n)
+	playoffs := make([]int, n)
+	byes := make([]int, n)
+	seeds := make([][]int, n)
+	for i := range seeds {
+		seeds[i] = make([]int, n)
+	}
+	standings := make([]model.LeagueStanding, n)
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	for range cfg.Simulations {
+		for i, id := range ids {
+			t := teams[id]
+			wins[i], losses[i], draws[i], points[i] = t.wins, t.losses, t.draws, t.points
+		}
+
+		for _, m := range games {
+			a, b := index[m.TeamA.TeamID], index[m.TeamB.TeamID]
+			scoreA, scoreB := teams[ids[a]].score(rng), teams[ids[b]].score(rng)
+			points[a] += scoreA
+			points[b] += scoreB
+			if scoreA > scoreB {
+				wins[a]++
+				losses[b]++
+			} else if scoreA < scoreB {
+				losses[a]++
+				wins[b]++
+			} else {
+				draws[a]++
+				draws[b]++
+			}
+		}
+
+		for i, id := range ids {
+			standings[i] = model.LeagueStanding{
+				TeamID: id,
+				Wins:   wins[i],
+				Losses: losses[i],
+				Draws:  draws[i],
+				Scored: fmt.Sprintf("%.2f", float64(points[i])/1000),
+			}
+		}
+		model.SortStandings(standings)
+
+		for seed, s := range standings {
+			i := index[s.TeamID]
+			seeds[i][seed]++
+			totalWins[i] += wins[i]
+			if seed < cfg.PlayoffTeams {
+				playoffs[i]++
+			}
+			if seed < cfg.Byes {
+				byes[i]++
+			}
+		}
+	}
+
+	percent := func(count int) float64 {
+		return math.Round(float64(count)*1000/float64(cfg.Simulations)) / 10
+	}
+	sim := &model.SeasonSimulation{
+		Config:         cfg,
+		Week:           week,
+		RemainingWeeks: len(weeks),
+		Teams:          make([]model.TeamSimulation, 0, n),
+	}
+	for i, id := range ids {
+		t := teams[id]
+		ts := model.TeamSimulation{
+			TeamID:       id,
+			TeamName:     t.name,
+			Wins:         t.wins,
+			Losses:       t.losses,
+			Draws:        t.draws,
+			AverageScore: math.Round(t.mean/10) / 100,
+			StdDev:       math.Round(t.stdDev/10) / 100,
+			ExpectedWins: math.Round(float64(totalWins[i])*100/float64(cfg.Simulations)) / 100,
+			PlayoffPct:   percent(playoffs[i]),
+			ByePct:       percent(byes[i]),
+			SeedPct:      make([]float64, n),
+		}
+		for seed, count := range seeds[i] {
+			ts.SeedPct[seed] = percent(count)
+		}
+		sim.Teams = append(sim.Teams, ts)
+	}
+
+	slices.SortFunc(sim.Teams, func(a, b model.TeamSimulation) int {
+		if a.PlayoffPct != b.PlayoffPct {
+			return cmp.Compare(b.PlayoffPct, a.PlayoffPct)
+		}
+		if a.ByePct != b.ByePct {
+			return cmp.Compare(b.ByePct, a.ByePct)
+		}
+		if a.ExpectedWins != b.ExpectedWins {
+			return cmp.Compare(b.ExpectedWins, a.ExpectedWins)
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+	return sim, nil
+}
+
+// Get the mean and sample standard deviation of the scores.
+func meanAndStdDev(scores []int32) (float64, float64) {
+	if len(scores) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, s := range scores {
+		sum += float64(s)
+	}
+	mean := sum / float64(len(scores))
+	if len(scores) == 1 {
+		return mean, 0
+	}
+
+	var squares float64
+	for _, s := range scores {
+		squares += (float64(s) - mean) * (float64(s) - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(scores)-1))
+}
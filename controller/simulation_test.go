@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestSimulateSeason(t *testing.T) {
+	_, weeklyResults := getDataForTest()
+
+	remaining := []model.Matchup{
+		{Week: 6, TeamA: &model.TeamResult{TeamID: "1"}, TeamB: &model.TeamResult{TeamID: "2"}},
+		{Week: 6, TeamA: &model.TeamResult{TeamID: "3"}, TeamB: &model.TeamResult{TeamID: "4"}},
+		{Week: 7, TeamA: &model.TeamResult{TeamID: "1"}, TeamB: &model.TeamResult{TeamID: "4"}},
+		{Week: 7, TeamA: &model.TeamResult{TeamID: "2"}, TeamB: &model.TeamResult{TeamID: "3"}},
+	}
+	cfg := model.SimulationConfig{Simulations: 500, PlayoffTeams: 2, Byes: 1, Seed: 42}
+
+	sim, err := simulateSeason(weeklyResults, 5, remaining, cfg)
+	if err != nil {
+		t.Fatalf("error simulating season: %v", err)
+	}
+	if sim.Week != 5 || sim.RemainingWeeks != 2 || len(sim.Teams) != 4 {
+		t.Fatalf("unexpected simulation: %v", sim)
+	}
+
+	// The same seed gives the same results
+	again, err := simulateSeason(weeklyResults, 5, remaining, cfg)
+	if err != nil {
+		t.Fatalf("error simulating season a second time: %v", err)
+	}
+	if !reflect.DeepEqual(sim, again) {
+		t.Errorf("expected the same results for the same seed, got: %v and %v", sim, again)
+	}
+
+	playoffs, byes := 0.0, 0.0
+	for _, team := range sim.Teams {
+		playoffs += team.PlayoffPct
+		byes += team.ByePct
+		seeds := 0.0
+		for _, p := range team.SeedPct {
+			seeds += p
+		}
+		if math.Abs(seeds-100) > 0.5 {
+			t.Errorf("expected the seeds for team %s to add up to 100, got: %v", team.TeamID, team.SeedPct)
+		}
+		if team.ExpectedWins < float64(team.Wins) || team.ExpectedWins > float64(team.Wins+2) {
+			t.Errorf("expected team %s to have between %d and %d wins, got: %f", team.TeamID, team.Wins, team.Wins+2, team.ExpectedWins)
+		}
+	}
+	if math.Abs(playoffs-200) > 0.5 || math.Abs(byes-100) > 0.5 {
+		t.Errorf("expected 2 playoff teams and 1 bye, got: %f and %f", playoffs, byes)
+	}
+
+	// Team 3 is 4-1 and team 2 is 1-4, with two games left team 2 can't catch
+	// team 3 for the top seed.
+	if sim.Teams[0].TeamID != "3" || sim.Teams[3].TeamID != "2" {
+		t.Errorf("expected team 3 to be first and team 2 to be last, got: %v", sim.Teams)
+	}
+	if sim.Teams[3].ByePct != 0 || sim.Teams[3].SeedPct[0] != 0 {
+		t.Errorf("expected team 2 to never get the bye, got: %v", sim.Teams[3])
+	}
+}
+
+func TestSimulateSeasonTieBreaks(t *testing.T) {
+	weeklyResults := map[int][]model.Matchup{
+		1: {
+			{Week: 1, TeamA: &model.TeamResult{TeamID: "A", Score: 100000}, TeamB: &model.TeamResult{TeamID: "B", Score: 90000}},
+			{Week: 1, TeamA: &model.TeamResult{TeamID: "C", Score: 80000}, TeamB: &model.TeamResult{TeamID: "D", Score: 120000}},
+		},
+	}
+	cfg := model.SimulationConfig{Simulations: 10, PlayoffTeams: 2, Byes: 1, Seed: 1}
+
+	// With nothing left to play the seeds are the standings, and the teams with the
+	// same record are ordered by points scored.
+	sim, err := simulateSeason(weeklyResults, 1, nil, cfg)
+	if err != nil {
+		t.Fatalf("error simulating season: %v", err)
+	}
+
+	expected := []struct {
+		id    string
+		seeds []float64
+	}{
+		{id: "D", seeds: []float64{100, 0, 0, 0}},
+		{id: "A", seeds: []float64{0, 100, 0, 0}},
+		{id: "B", seeds: []float64{0, 0, 100, 0}},
+		{id: "C", seeds: []float64{0, 0, 0, 100}},
+	}
+	for i, e := range expected {
+		team := sim.Teams[i]
+		if team.TeamID != e.id || !reflect.DeepEqual(e.seeds, team.SeedPct) {
+			t.Errorf("expected team %s with seeds %v, got: %v", e.id, e.seeds, team)
+		}
+	}
+	if sim.Teams[0].ByePct != 100 || sim.Teams[1].ByePct != 0 || sim.Teams[1].PlayoffPct != 100 {
+		t.Errorf("unexpected playoff and bye odds: %v", sim.Teams)
+	}
+}
+
+func TestValidateSimulationConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   model.SimulationConfig
+		valid bool
+	}{
+		{name: "default", cfg: model.DefaultSimulationConfig(), valid: true},
+		{name: "no simulations", cfg: model.SimulationConfig{Simulations: 0, PlayoffTeams: 6, Byes: 2}},
+		{name: "too many simulations", cfg: model.SimulationConfig{Simulations: maxSimulations + 1, PlayoffTeams: 6, Byes: 2}},
+		{name: "too many playoff teams", cfg: model.SimulationConfig{Simulations: 10, PlayoffTeams: 13, Byes: 2}},
+		{name: "more byes than playoff teams", cfg: model.SimulationConfig{Simulations: 10, PlayoffTeams: 2, Byes: 3}},
+		{name: "no playoffs", cfg: model.SimulationConfig{Simulations: 10}, valid: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSimulationConfig(&tc.cfg, 12)
+			if tc.valid && err != nil {
+				t.Errorf("expected config to be valid, got: %v", err)
+			} else if !tc.valid && !errors.Is(err, ErrInvalidSimulationConfig) {
+				t.Errorf("expected config to be invalid, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestSimulateSeasonForLeague(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error adding players: %v", err)
+	}
+
+	l, err := ctrl.AddLeague(ctx, model.PlatformSleeper, testutils.SleeperLeagueID, "2024", "" /* state */)
+	if err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		ctrl.ArchiveLeague(ctx, l.ID)
+	}()
+
+	if _, err := ctrl.AddLeagueManagers(ctx, l.ID); err != nil {
+		t.Fatalf("error adding league managers: %v", err)
+	}
+
+	cfg := model.SimulationConfig{Simulations: 200, PlayoffTeams: 2, Byes: 1, Seed: 7}
+	if _, err := ctrl.SimulateSeason(ctx, l.ID, cfg); err == nil {
+		t.Error("expected an error simulating a season without any results")
+	}
+
+	for w := 1; w <= 5; w++ {
+		if err := ctrl.SyncResultsFromPlatform(ctx, l.ID, w); err != nil {
+			t.Fatalf("error syncing week %d results: %v", w, err)
+		}
+	}
+
+	sim, err := ctrl.SimulateSeason(ctx, l.ID, cfg)
+	if err != nil {
+		t.Fatalf("error simulating season: %v", err)
+	}
+
+	// The playoffs start in week 15, so weeks 6 through 14 are simulated
+	if sim.Week != 5 || sim.RemainingWeeks != 9 || len(sim.Teams) != 4 {
+		t.Fatalf("unexpected simulation: %v", sim)
+	}
+	for _, team := range sim.Teams {
+		if team.TeamName == "" || team.AverageScore == 0 || len(team.SeedPct) != 4 {
+			t.Errorf("unexpected team simulation: %v", team)
+		}
+	}
+
+	again, err := ctrl.SimulateSeason(ctx, l.ID, cfg)
+	if err != nil {
+		t.Fatalf("error simulating season a second time: %v", err)
+	}
+	if !reflect.DeepEqual(sim, again) {
+		t.Errorf("expected the same results for the same seed, got: %v and %v", sim, again)
+	}
+}
@@ -11,7 +11,7 @@ type sleeperAdapter struct {
 	c *controller
 }
 
-func (a *sleeperAdapter) getLeagues(user, year string) ([]model.League, error) {
+func (a *sleeperAdapter) getLeagues(ctx context.Context, user, year, stateToken string) ([]model.League, error) {
 	userID, err := a.c.sleeper.GetUserID(user)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,7 @@ func (a *sleeperAdapter) getLeagues(user, year string) ([]model.League, error) {
 	return a.c.sleeper.GetLeaguesForUser(userID, year)
 }
 
-func (a *sleeperAdapter) getLeagueName(ctx context.Context, leagueID, stateToken string) (string, error) {
+func (a *sleeperAdapter) getLeagueName(ctx context.Context, leagueID, year, stateToken string) (string, error) {
 	return a.c.sleeper.GetLeagueName(leagueID)
 }
 
@@ -64,6 +64,85 @@ func (a *sleeperAdapter) getStarters(ctx context.Context, l *model.League) ([]mo
 	return a.c.sleeper.GetStarters(l.ExternalID)
 }
 
-func (a *sleeperAdapter) getLeagueStandings(ctx context.Context, leagueID string) ([]model.LeagueStanding, error) {
-	return a.c.sleeper.GetLeagueStandings(leagueID)
+func (a *sleeperAdapter) getLeagueStandings(ctx context.Context, l *model.League) ([]model.LeagueStanding, error) {
+	return a.c.sleeper.GetLeagueStandings(l.ExternalID)
+}
+
+func (a *sleeperAdapter) getTransactions(ctx context.Context, l *model.League, week int) ([]model.Transaction, error) {
+	transactions, err := a.c.sleeper.GetTransactions(l.ExternalID, week)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sleeper identifies teams by roster id, so map those to the owners
+	owners := make(map[string]string)
+	for _, manager := range l.Managers {
+		owners[manager.JoinKey] = manager.ExternalID
+	}
+
+	for i := range transactions {
+		for j := range transactions[i].Assets {
+			a := &transactions[i].Assets[j]
+			a.FromTeamID = owners[a.FromTeamID]
+			a.ToTeamID = owners[a.ToTeamID]
+			a.PickTeamID = owners[a.PickTeamID]
+		}
+	}
+	return transactions, nil
+}
+
+func (a *sleeperAdapter) getDrafts(ctx context.Context, l *model.League) ([]model.Draft, error) {
+	drafts, err := a.c.sleeper.GetDrafts(l.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]string)
+	for _, manager := range l.Managers {
+		owners[manager.JoinKey] = manager.ExternalID
+	}
+
+	for i := range drafts {
+		for j := range drafts[i].Picks {
+			p := &drafts[i].Picks[j]
+			p.TeamID = owners[p.TeamID]
+		}
+	}
+	return drafts, nil
+}
+
+func (a *sleeperAdapter) getPreviousLeague(ctx context.Context, l *model.League) (*model.League, error) {
+	return a.c.sleeper.GetPreviousLeague(l.ExternalID)
+}
+
+func (a *sleeperAdapter) getChampion(ctx context.Context, l *model.League) (string, error) {
+	rosterID, err := a.c.sleeper.GetLeagueWinner(l.ExternalID)
+	if err != nil || rosterID == "" {
+		return "", err
+	}
+
+	for _, m := range l.Managers {
+		if m.JoinKey == rosterID {
+			return m.ExternalID, nil
+		}
+	}
+	return "", fmt.Errorf("no manager found for the winning roster %s", rosterID)
+}
+
+func (a *sleeperAdapter) getSchedule(ctx context.Context, l *model.League, week int) ([]model.Matchup, error) {
+	matchups, err := a.c.sleeper.GetSchedule(l.ExternalID, week)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]string)
+	for _, manager := range l.Managers {
+		owners[manager.JoinKey] = manager.ExternalID
+	}
+
+	for i, m := range matchups {
+		matchups[i].TeamA.TeamID = owners[m.TeamA.JoinKey]
+		matchups[i].TeamB.TeamID = owners[m.TeamB.JoinKey]
+	}
+	return matchups, nil
 }
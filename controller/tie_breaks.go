@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// Sort the teams by their total score and assign their ranks. Teams with the same
+// score share a rank, and are sorted by the config's tie-breaks in order. Teams that
+// are still tied after all of the tie-breaks are sorted by team id, so the order is
+// the same every time the power ranking is calculated.
+func rankTeams(pr *model.PowerRanking, weeklyResults map[int][]model.Matchup, week int) {
+	slices.SortFunc(pr.Teams, func(a, b model.TeamPowerRanking) int {
+		return cmp.Compare(b.TotalScore, a.TotalScore)
+	})
+
+	for start := 0; start < len(pr.Teams); {
+		end := start + 1
+		for end < len(pr.Teams) && pr.Teams[end].TotalScore == pr.Teams[start].TotalScore {
+			end++
+		}
+
+		tied := pr.Teams[start:end]
+		if len(tied) > 1 {
+			breakTies(tied, pr.Config.TieBreaks, weeklyResults, week)
+		}
+		for i := range tied {
+			tied[i].Rank = start + 1
+		}
+		start = end
+	}
+}
+
+// Sort teams with the same score, the team with the higher value for the first
+// tie-break goes first, and so on for each of the tie-breaks.
+func breakTies(teams []model.TeamPowerRanking, tieBreaks []string, weeklyResults map[int][]model.Matchup, week int) {
+	tied := make(map[string]bool, len(teams))
+	for _, t := range teams {
+		tied[t.TeamID] = true
+	}
+
+	values := make(map[string][]float64, len(teams))
+	for _, t := range teams {
+		v := make([]float64, 0, len(tieBreaks))
+		for _, tb := range tieBreaks {
+			v = append(v, tieBreakValue(tb, &t, tied, weeklyResults, week))
+		}
+		values[t.TeamID] = v
+	}
+
+	slices.SortFunc(teams, func(a, b model.TeamPowerRanking) int {
+		if c := slices.Compare(values[b.TeamID], values[a.TeamID]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+}
+
+// Get the team's value for the tie-break, higher values are better. tied has the
+// ids of all of the teams with the same score.
+func tieBreakValue(tieBreak string, t *model.TeamPowerRanking, tied map[string]bool, weeklyResults map[int][]model.Matchup, week int) float64 {
+	switch tieBreak {
+	case model.TieBreakPointsFor:
+		var points int64
+		for w := 1; w <= week; w++ {
+			for _, m := range weeklyResults[w] {
+				if m.TeamA != nil && m.TeamA.TeamID == t.TeamID {
+					points += int64(m.TeamA.Score)
+				} else if m.TeamB != nil && m.TeamB.TeamID == t.TeamID {
+					points += int64(m.TeamB.Score)
+				}
+			}
+		}
+		return float64(points)
+	case model.TieBreakRecord:
+		wins, losses, draws := getRecord(t.TeamID, weeklyResults, week)
+		games := wins + losses + draws
+		if games == 0 {
+			return 0
+		}
+		return (float64(wins) + float64(draws)/2) / float64(games)
+	case model.TieBreakHeadToHead:
+		// Wins minus losses in the games between the tied teams
+		record := 0
+		for w := 1; w <= week; w++ {
+			for _, m := range weeklyResults[w] {
+				if m.TeamA == nil || m.TeamB == nil || !tied[m.TeamA.TeamID] || !tied[m.TeamB.TeamID] {
+					continue
+				}
+				if m.TeamA.TeamID == t.TeamID {
+					record += cmp.Compare(m.TeamA.Score, m.TeamB.Score)
+				} else if m.TeamB.TeamID == t.TeamID {
+					record += cmp.Compare(m.TeamB.Score, m.TeamA.Score)
+				}
+			}
+		}
+		return float64(record)
+	case model.TieBreakRoster:
+		return float64(t.Scores[componentRoster])
+	default:
+		return 0
+	}
+}
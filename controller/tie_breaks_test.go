@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestRankTeams(t *testing.T) {
+	tests := map[string]struct {
+		tieBreaks []string
+		expected  []string
+	}{
+		// Team 2 has 493 points, team 1 has 480 and team 4 has 449
+		"points for": {tieBreaks: []string{model.TieBreakPointsFor}, expected: []string{"3", "2", "1", "4"}},
+		// Team 1 is 3-2, team 4 is 2-3 and team 2 is 1-4
+		"record": {tieBreaks: []string{model.TieBreakRecord}, expected: []string{"3", "1", "4", "2"}},
+		// Teams 1 and 4 are both 1-0 against the other tied teams, so the roster breaks the tie
+		"head to head": {tieBreaks: []string{model.TieBreakHeadToHead, model.TieBreakRoster}, expected: []string{"3", "4", "1", "2"}},
+		"roster":       {tieBreaks: []string{model.TieBreakRoster}, expected: []string{"3", "4", "1", "2"}},
+		"team id":      {tieBreaks: []string{}, expected: []string{"3", "1", "2", "4"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			pr, weeklyResults := getDataForTest()
+			pr.Config.TieBreaks = tc.tieBreaks
+			// List the teams in a different order than the one expected
+			pr.Teams[0], pr.Teams[3] = pr.Teams[3], pr.Teams[0]
+			rosterScores := map[string]int32{"1": 40, "2": 30, "3": 10, "4": 50}
+			for i := range pr.Teams {
+				pr.Teams[i].TotalScore = 10
+				pr.Teams[i].Scores = map[string]int32{componentRoster: rosterScores[pr.Teams[i].TeamID]}
+				if pr.Teams[i].TeamID == "3" {
+					pr.Teams[i].TotalScore = 20
+				}
+			}
+
+			rankTeams(pr, weeklyResults, 5)
+
+			ids := make([]string, 0, len(pr.Teams))
+			for _, team := range pr.Teams {
+				ids = append(ids, team.TeamID)
+			}
+			if !reflect.DeepEqual(tc.expected, ids) {
+				t.Errorf("expected the teams in order: %v, got: %v", tc.expected, ids)
+			}
+
+			// The tied teams all share second place
+			ranks := []int{pr.Teams[0].Rank, pr.Teams[1].Rank, pr.Teams[2].Rank, pr.Teams[3].Rank}
+			if !reflect.DeepEqual([]int{1, 2, 2, 2}, ranks) {
+				t.Errorf("expected ranks [1 2 2 2], got: %v", ranks)
+			}
+		})
+	}
+}
+
+func TestRankTeamsWithoutTies(t *testing.T) {
+	pr, weeklyResults := getDataForTest()
+	for i := range pr.Teams {
+		pr.Teams[i].TotalScore = int32(10 * i)
+	}
+
+	rankTeams(pr, weeklyResults, 5)
+
+	for i, team := range pr.Teams {
+		if team.Rank != i+1 || team.TotalScore != int32(10*(3-i)) {
+			t.Errorf("expected rank %d for score %d, got: %v", i+1, 10*(3-i), team)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package controller
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -10,6 +9,7 @@ import (
 	"strconv"
 
 	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/platforms/yahoo"
 )
 
 var (
@@ -21,18 +21,25 @@ type yahooAdapter struct {
 	c *controller
 }
 
-func (a *yahooAdapter) getLeagues(user, year string) ([]model.League, error) {
-	return nil, errors.New("not implemented")
+// Yahoo doesn't look up leagues by username, the user is identified by the oauth token instead.
+func (a *yahooAdapter) getLeagues(ctx context.Context, user, year, stateToken string) ([]model.League, error) {
+	t, err := a.c.OAuthRetrieve(stateToken)
+	if err != nil {
+		return nil, fmt.Errorf("error getting oauth token when getting leagues: %w", err)
+	}
+
+	httpClient := a.c.yahooConfig.Client(ctx, t)
+	return a.c.yahoo.GetLeagues(httpClient, year)
 }
 
-func (a *yahooAdapter) getLeagueName(ctx context.Context, leagueID, stateToken string) (string, error) {
+func (a *yahooAdapter) getLeagueName(ctx context.Context, leagueID, year, stateToken string) (string, error) {
 	t, err := a.c.OAuthRetrieve(stateToken)
 	if err != nil {
 		return "", fmt.Errorf("error getting oauth token when getting league name: %w", err)
 	}
 
 	httpClient := a.c.yahooConfig.Client(ctx, t)
-	return a.c.yahoo.GetLeagueName(httpClient, leagueID)
+	return a.c.yahoo.GetLeagueName(httpClient, leagueID, year)
 }
 
 func (a *yahooAdapter) getManagers(ctx context.Context, l *model.League) ([]model.LeagueManager, error) {
@@ -42,7 +49,7 @@ func (a *yahooAdapter) getManagers(ctx context.Context, l *model.League) ([]mode
 	}
 
 	httpClient := a.c.yahooConfig.Client(ctx, t)
-	return a.c.yahoo.GetManagers(httpClient, l.ExternalID)
+	return a.c.yahoo.GetManagers(httpClient, l.ExternalID, l.Year)
 }
 
 func (a *yahooAdapter) sortManagers(m []model.LeagueManager) {
@@ -71,12 +78,36 @@ func (a *yahooAdapter) getMatchupResults(ctx context.Context, l *model.League, w
 	}
 
 	httpClient := a.c.yahooConfig.Client(ctx, t)
-	matchups, err := a.c.yahoo.GetScoreboard(httpClient, l.ExternalID, week)
+	matchups, err := a.c.yahoo.GetScoreboard(httpClient, l.ExternalID, l.Year, week)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Yahoo only provides player points per team, so load each team in the matchups.
+	scores := make([]yahoo.PlayerScore, 0, len(matchups)*30)
+	for _, m := range matchups {
+		for _, team := range []*model.TeamResult{m.TeamA, m.TeamB} {
+			s, err := a.c.yahoo.GetPlayerScores(httpClient, team.TeamID, week)
+			if err != nil {
+				return nil, nil, err
+			}
+			scores = append(scores, s...)
+		}
+	}
+
+	players := make([]model.YahooPlayer, 0, len(scores))
+	for _, s := range scores {
+		players = append(players, s.Player)
+	}
+	ids, err := a.c.db.ConvertYahooPlayerIDs(ctx, players)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	playerScores := make([]model.PlayerScore, 0) // Yahoo isn't providing this data
+	playerScores := make([]model.PlayerScore, 0, len(scores))
+	for i, s := range scores {
+		playerScores = append(playerScores, model.PlayerScore{PlayerID: ids[i], Score: s.Score})
+	}
 	return matchups, playerScores, nil
 }
 
@@ -112,11 +143,76 @@ func (a *yahooAdapter) getStarters(ctx context.Context, l *model.League) ([]mode
 	}
 
 	httpClient := a.c.yahooConfig.Client(ctx, t)
-	return a.c.yahoo.GetStarters(httpClient, l.ExternalID)
+	return a.c.yahoo.GetStarters(httpClient, l.ExternalID, l.Year)
+}
+
+func (a *yahooAdapter) getLeagueStandings(ctx context.Context, l *model.League) ([]model.LeagueStanding, error) {
+	t, err := a.c.GetToken(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := a.c.yahooConfig.Client(ctx, t)
+	return a.c.yahoo.GetLeagueStandings(httpClient, l.ExternalID, l.Year)
+}
+
+func (a *yahooAdapter) getTransactions(ctx context.Context, l *model.League, week int) ([]model.Transaction, error) {
+	t, err := a.c.GetToken(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := a.c.yahooConfig.Client(ctx, t)
+	transactions, err := a.c.yahoo.GetTransactions(httpClient, l.ExternalID, l.Year, week)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.Transaction, 0, len(transactions))
+	for _, tr := range transactions {
+		ids, err := a.c.db.ConvertYahooPlayerIDs(ctx, tr.Players)
+		if err != nil {
+			return nil, err
+		}
+		converted := make(map[string]string, len(ids))
+		for i, p := range tr.Players {
+			converted[p.YahooID] = ids[i]
+		}
+		for i, a := range tr.Transaction.Assets {
+			if a.Type != model.AssetPlayer {
+				continue
+			}
+			id, found := converted[a.PlayerID]
+			if !found {
+				return nil, fmt.Errorf("transaction %s is missing yahoo player %s", tr.Transaction.ExternalID, a.PlayerID)
+			}
+			tr.Transaction.Assets[i].PlayerID = id
+		}
+		results = append(results, tr.Transaction)
+	}
+	return results, nil
 }
 
-func (a *yahooAdapter) getLeagueStandings(ctx context.Context, leagueID string) ([]model.LeagueStanding, error) {
-	return nil, errors.New("getLeagueStanding not supported for yahoo leagues")
+func (a *yahooAdapter) getDrafts(ctx context.Context, l *model.League) ([]model.Draft, error) {
+	t, err := a.c.GetToken(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := a.c.yahooConfig.Client(ctx, t)
+	d, err := a.c.yahoo.GetDraft(httpClient, l.ExternalID, l.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := a.c.db.ConvertYahooPlayerIDs(ctx, d.Players)
+	if err != nil {
+		return nil, err
+	}
+	for i := range d.Draft.Picks {
+		d.Draft.Picks[i].PlayerID = ids[i]
+	}
+	return []model.Draft{d.Draft}, nil
 }
 
 func parseID(id string) int {
@@ -135,3 +231,30 @@ func parseID(id string) int {
 	}
 	return result
 }
+
+// Yahoo keeps the same league id across seasons for renewed leagues, but the
+// league key changes with the game every year. There isn't a way to look up the
+// previous season with only the league id, so those need to be linked manually.
+func (a *yahooAdapter) getPreviousLeague(ctx context.Context, l *model.League) (*model.League, error) {
+	return nil, errPreviousLeagueNotSupported
+}
+
+func (a *yahooAdapter) getChampion(ctx context.Context, l *model.League) (string, error) {
+	t, err := a.c.GetToken(ctx, l.ID)
+	if err != nil {
+		return "", err
+	}
+
+	httpClient := a.c.yahooConfig.Client(ctx, t)
+	return a.c.yahoo.GetLeagueChampion(httpClient, l.ExternalID, l.Year)
+}
+
+func (a *yahooAdapter) getSchedule(ctx context.Context, l *model.League, week int) ([]model.Matchup, error) {
+	t, err := a.c.GetToken(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := a.c.yahooConfig.Client(ctx, t)
+	return a.c.yahoo.GetSchedule(httpClient, l.ExternalID, l.Year, week)
+}
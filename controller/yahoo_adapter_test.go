@@ -27,7 +27,7 @@ func TestGetLeagueName(t *testing.T) {
 		t.Fatalf("error exchanging oauth token: %v", err)
 	}
 
-	name, err := adapter.getLeagueName(ctx, testutils.YahooLeagueID, state)
+	name, err := adapter.getLeagueName(ctx, testutils.YahooLeagueID, "2024", state)
 	if err != nil {
 		t.Fatalf("unexpected error getting league name: %v", err)
 	}
@@ -145,8 +145,29 @@ func TestGetLeagues(t *testing.T) {
 	ctrl, testCtrl := controllerForTest()
 	defer testCtrl.Close()
 	adapter := &yahooAdapter{ctrl.(*controller)}
-	if _, err := adapter.getLeagues("user", "2024"); err == nil {
-		t.Errorf("expected an error in getLeague(), but got none")
+	ctx := context.Background()
+
+	if _, err := adapter.getLeagues(ctx, "", "2024", "unknown-state"); err == nil {
+		t.Errorf("expected an error in getLeagues() without an oauth token, but got none")
+	}
+
+	authURL, err := ctrl.OAuthStart(model.PlatformYahoo)
+	state := validateOAuthStart(t, authURL, err)
+	if err := ctrl.OAuthExchange(ctx, state, "code"); err != nil {
+		t.Fatalf("error exchanging oauth token: %v", err)
+	}
+
+	leagues, err := adapter.getLeagues(ctx, "", "2024", state)
+	if err != nil {
+		t.Fatalf("unexpected error in getLeagues(): %v", err)
+	}
+
+	expected := []model.League{
+		{Platform: model.PlatformYahoo, ExternalID: testutils.YahooLeagueID, Name: "Y! Friends and Family League", Year: "2024"},
+		{Platform: model.PlatformYahoo, ExternalID: "149976", Name: "Office Pool", Year: "2024"},
+	}
+	if !reflect.DeepEqual(expected, leagues) {
+		t.Errorf("leagues not as expected, got: %v", leagues)
 	}
 }
 
@@ -161,13 +182,32 @@ func TestGetMatchupResults(t *testing.T) {
 		testDB.DB.ArchiveLeague(ctx, l.ID)
 	}()
 
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
 	adapter := &yahooAdapter{ctrl.(*controller)}
 	matchups, players, err := adapter.getMatchupResults(ctx, l, 1)
 	if err != nil {
 		t.Fatalf("unexpected error in getMatchupResults: %v", err)
 	}
-	if players == nil || len(players) > 0 {
-		t.Errorf("players was nil or had results when expected to be empty: %v", players)
+
+	expectedPlayers := []model.PlayerScore{
+		{PlayerID: "3225", Score: 25120},
+		{PlayerID: "4080", Score: 13700},
+		{PlayerID: "4993", Score: 8000},
+		{PlayerID: "1166", Score: 18440},
+		{PlayerID: "1339", Score: 6300},
+		{PlayerID: "1352", Score: 11200},
+		{PlayerID: "1992", Score: 21060},
+		{PlayerID: "2216", Score: 9900},
+		{PlayerID: "2359", Score: 4500},
+		{PlayerID: "7601", Score: 14880},
+		{PlayerID: "8154", Score: 2400},
+		{PlayerID: "10219", Score: 16600},
+	}
+	if !reflect.DeepEqual(expectedPlayers, players) {
+		t.Errorf("expected players %v, got %v", expectedPlayers, players)
 	}
 
 	expected := []model.Matchup{
@@ -236,6 +276,108 @@ func TestGetRosters(t *testing.T) {
 	}
 }
 
+func TestYahooGetTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	l := setupTest(t, ctx, testCtrl.Clock)
+	defer func() {
+		testDB.DB.ArchiveLeague(ctx, l.ID)
+	}()
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	adapter := &yahooAdapter{ctrl.(*controller)}
+	transactions, err := adapter.getTransactions(ctx, l, 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting yahoo transactions: %v", err)
+	}
+
+	expectedIDs := [][]string{
+		{"10226", "1339"},
+		{"10871"},
+		{"8154"},
+		{"4080", "1352"},
+		{"10871", ""},
+	}
+	if len(transactions) != len(expectedIDs) {
+		t.Fatalf("expected %d transactions, got: %v", len(expectedIDs), transactions)
+	}
+	for i, tr := range transactions {
+		ids := make([]string, 0, len(tr.Assets))
+		for _, a := range tr.Assets {
+			ids = append(ids, a.PlayerID)
+		}
+		if !reflect.DeepEqual(expectedIDs[i], ids) {
+			t.Errorf("transaction %s expected player ids %v, got: %v", tr.ExternalID, expectedIDs[i], ids)
+		}
+	}
+}
+
+func TestYahooGetDrafts(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	l := setupTest(t, ctx, testCtrl.Clock)
+	defer func() {
+		testDB.DB.ArchiveLeague(ctx, l.ID)
+	}()
+	if err := ctrl.UpdatePlayers(ctx); err != nil {
+		t.Fatalf("error updating players: %v", err)
+	}
+
+	adapter := &yahooAdapter{ctrl.(*controller)}
+	drafts, err := adapter.getDrafts(ctx, l)
+	if err != nil {
+		t.Fatalf("unexpected error getting yahoo drafts: %v", err)
+	}
+	if len(drafts) != 1 {
+		t.Fatalf("expected 1 draft, got: %d", len(drafts))
+	}
+
+	expectedIDs := []string{"1166", "2216", "1992", "3225", "1352", "4993", "7601", "8154"}
+	ids := make([]string, 0, len(drafts[0].Picks))
+	for _, p := range drafts[0].Picks {
+		ids = append(ids, p.PlayerID)
+	}
+	if !reflect.DeepEqual(expectedIDs, ids) {
+		t.Errorf("expected player ids %v, got: %v", expectedIDs, ids)
+	}
+}
+
+func TestYahooGetLeagueStandings(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl, testCtrl := controllerForTest()
+	defer testCtrl.Close()
+
+	l := setupTest(t, ctx, testCtrl.Clock)
+	defer func() {
+		testDB.DB.ArchiveLeague(ctx, l.ID)
+	}()
+
+	adapter := &yahooAdapter{ctrl.(*controller)}
+	standings, err := adapter.getLeagueStandings(ctx, l)
+	if err != nil {
+		t.Fatalf("unexpected error getting yahoo standings: %v", err)
+	}
+
+	expected := []model.LeagueStanding{
+		{TeamID: testutils.YahooTeam10ID, Rank: 1, Wins: 9, Losses: 4, Scored: "1682.33"},
+		{TeamID: testutils.YahooTeam05ID, Rank: 2, Wins: 9, Losses: 4, Scored: "1764.09"},
+		{TeamID: testutils.YahooTeam08ID, Rank: 3, Wins: 8, Losses: 5, Scored: "1569.48"},
+		{TeamID: testutils.YahooTeam12ID, Rank: 4, Wins: 8, Losses: 5, Scored: "1652.27"},
+	}
+	if !reflect.DeepEqual(expected, standings) {
+		t.Errorf("expected: %v, got: %v", expected, standings)
+	}
+}
+
 func setupTest(t *testing.T, ctx context.Context, clock clock.Clock) *model.League {
 	l := &model.League{
 		Platform:   model.PlatformYahoo,
@@ -20,12 +20,29 @@ type DB interface {
 	GetTopScores(ctx context.Context, leagueID int32, week int) ([]model.PlayerScore, error)
 
 	// Lists the 20 most recent rankings in the system. The most recent ranking is returned first.
-	// Only the ranking metadata, the ID and date, are returned. The actual ranking data is returned
+	// Only the ranking metadata, the ID, date, source and scoring format, are returned. The actual ranking data is returned
 	// with GetRanking().
 	ListRankings(ctx context.Context) ([]model.Ranking, error)
 	GetRanking(ctx context.Context, id int32) (*model.Ranking, error)
-	AddRanking(ctx context.Context, date time.Time, rankings map[string]int32) (*model.Ranking, error)
+	// Add a ranking, the unmatched rows are saved to be reviewed later.
+	AddRanking(ctx context.Context, date time.Time, source string, scoring model.ScoringFormat, rankings map[string]int32, unmatched []model.UnmatchedRankingPlayer) (*model.Ranking, error)
 	DeleteRanking(ctx context.Context, id int32) error
+	// Add a ranking blended from other rankings, with the rank each source gave the players.
+	AddConsensusRanking(ctx context.Context, date time.Time, scoring model.ScoringFormat, rankings map[string]int32, sources []model.ConsensusSource) (*model.Ranking, error)
+	// Get the rankings a consensus ranking was blended from, with the highest weight first.
+	// Returns an empty slice if the ranking isn't a consensus ranking.
+	GetConsensusSources(ctx context.Context, rankingID int32) ([]model.ConsensusSource, error)
+	// Get the player's rank in every ranking they are in, oldest ranking first.
+	GetPlayerRankings(ctx context.Context, playerID string) ([]model.PlayerRankingPoint, error)
+	// Lists the rows of a ranking that weren't matched to a player, by rank.
+	ListUnmatchedRankingPlayers(ctx context.Context, rankingID int32) ([]model.UnmatchedRankingPlayer, error)
+	// Adds the player to the ranking with the rank of the unmatched row, and removes the row.
+	ResolveUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32, playerID string) error
+	DeleteUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32) error
+	// Get the id of the player the name and position from a rankings file are an alias
+	// for. Returns ErrPlayerNotFound if there isn't an alias.
+	GetPlayerAlias(ctx context.Context, name string, pos model.Position) (string, error)
+	SavePlayerAlias(ctx context.Context, name string, pos model.Position, playerID string) error
 
 	ListLeagues(ctx context.Context) ([]model.League, error)
 	GetLeague(ctx context.Context, id int32) (*model.League, error)
@@ -34,6 +51,14 @@ type DB interface {
 	AddLeague(ctx context.Context, league *model.League) error
 	ArchiveLeague(ctx context.Context, id int32) error
 
+	AddFranchise(ctx context.Context, f *model.Franchise) error
+	GetFranchise(ctx context.Context, id int32) (*model.Franchise, error)
+	ListFranchises(ctx context.Context) ([]model.Franchise, error)
+	// Returns 0 if the league is not part of a franchise.
+	GetLeagueFranchiseID(ctx context.Context, leagueID int32) (int32, error)
+	// Add the league to the franchise, moving it out of any franchise it was already in.
+	LinkLeague(ctx context.Context, franchiseID, leagueID int32) error
+
 	GetToken(ctx context.Context, leagueID int32) (*oauth2.Token, error)
 	SaveToken(ctx context.Context, leagueID int32, token *oauth2.Token) error
 
@@ -42,9 +67,34 @@ type DB interface {
 	// Return a list of weeks that have results
 	ListResultWeeks(ctx context.Context, leagueID int32) ([]int, error)
 
+	// Save the Elo ratings for the league, replacing any that were already saved.
+	SaveEloRatings(ctx context.Context, leagueID int32, ratings []model.EloRating) error
+	// Get the Elo ratings for the league, ordered by week.
+	GetEloRatings(ctx context.Context, leagueID int32) ([]model.EloRating, error)
+
 	SavePowerRanking(ctx context.Context, leagueID int32, pr *model.PowerRanking) (int32, error)
 	GetPowerRanking(ctx context.Context, leagueID, powerRankingID int32) (*model.PowerRanking, error)
 	ListPowerRankings(ctx context.Context, leagueID int32) ([]model.PowerRanking, error)
+	// Replace the saved power ranking with the same id as pr, keeping when it was created.
+	ReplacePowerRanking(ctx context.Context, leagueID int32, pr *model.PowerRanking) error
+	DeletePowerRanking(ctx context.Context, leagueID, powerRankingID int32) error
+	// Get the power ranking config for the league, or the default config if the league doesn't have one.
+	GetPowerRankingConfig(ctx context.Context, leagueID int32) (*model.PowerRankingConfig, error)
+	SavePowerRankingConfig(ctx context.Context, leagueID int32, c *model.PowerRankingConfig) error
+
+	// Save transactions, replacing any that have already been saved with the same external id.
+	SaveTransactions(ctx context.Context, leagueID int32, transactions []model.Transaction) error
+	GetTransactions(ctx context.Context, leagueID int32, week int) ([]model.Transaction, error)
+	// List all of the transactions for the league, in the order they were processed.
+	ListTransactions(ctx context.Context, leagueID int32) ([]model.Transaction, error)
+
+	// Save a draft and its picks, replacing any picks already saved for the draft.
+	SaveDraft(ctx context.Context, leagueID int32, d *model.Draft) error
+	GetDraft(ctx context.Context, leagueID, draftID int32) (*model.Draft, error)
+	ListDrafts(ctx context.Context, leagueID int32) ([]model.Draft, error)
 
 	ConvertYahooPlayerIDs(ctx context.Context, players []model.YahooPlayer) ([]string, error)
+	ConvertESPNPlayerIDs(ctx context.Context, players []model.ESPNPlayer) ([]string, error)
+	ConvertMFLPlayerIDs(ctx context.Context, players []model.MFLPlayer) ([]string, error)
+	ConvertFleaflickerPlayerIDs(ctx context.Context, players []model.FleaflickerPlayer) ([]string, error)
 }
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// Drafts are saved by their platform id, so saving the same draft again
+// replaces the picks instead of creating a duplicate. The ID field of the
+// draft is set to the id in the DB.
+func (db *postgresDB) SaveDraft(ctx context.Context, leagueID int32, d *model.Draft) error {
+	const insertDraft = `INSERT INTO drafts (league_id, external_id, draft_type, rounds, started)
+			VALUES (@leagueID, @externalID, @draftType, @rounds, @started)
+			ON CONFLICT (league_id, external_id) DO UPDATE SET
+				draft_type=EXCLUDED.draft_type, rounds=EXCLUDED.rounds, started=EXCLUDED.started
+			RETURNING id`
+	const deletePicks = `DELETE FROM draft_picks WHERE draft_id=@id`
+	const insertPick = `INSERT INTO draft_picks (draft_id, pick_no, round, team, player_id, keeper, cost)
+			VALUES (@id, @pick, @round, @team, @playerID, @keeper, @cost)`
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"leagueID":   leagueID,
+		"externalID": d.ExternalID,
+		"draftType":  d.Type,
+		"rounds":     d.Rounds,
+		"started": pgtype.Timestamptz{
+			Time:             d.Started.UTC(),
+			InfinityModifier: pgtype.Finite,
+			Valid:            true,
+		},
+	}
+	if err := tx.QueryRow(ctx, insertDraft, args).Scan(&d.ID); err != nil {
+		return fmt.Errorf("error saving draft %s: %w", d.ExternalID, err)
+	}
+
+	if _, err := tx.Exec(ctx, deletePicks, pgx.NamedArgs{"id": d.ID}); err != nil {
+		return fmt.Errorf("error removing old picks for draft %s: %w", d.ExternalID, err)
+	}
+
+	for _, p := range d.Picks {
+		args := pgx.NamedArgs{
+			"id":       d.ID,
+			"pick":     p.Pick,
+			"round":    p.Round,
+			"team":     p.TeamID,
+			"playerID": nullString(p.PlayerID),
+			"keeper":   p.IsKeeper,
+			"cost":     p.Cost,
+		}
+		if _, err := tx.Exec(ctx, insertPick, args); err != nil {
+			return fmt.Errorf("error saving pick %d for draft %s: %w", p.Pick, d.ExternalID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting draft: %w", err)
+	}
+	return nil
+}
+
+// Load a draft with all of its picks in pick order.
+func (db *postgresDB) GetDraft(ctx context.Context, leagueID, draftID int32) (*model.Draft, error) {
+	const query = `SELECT id, external_id, draft_type, rounds, started FROM drafts
+			WHERE league_id=@leagueID AND id=@id`
+	const picksQuery = `SELECT
+				d.pick_no, d.round, d.team, d.player_id, d.keeper, d.cost,
+				p.name_first, p.name_last, p.position, m.team_name, m.manager_name
+			FROM draft_picks AS d
+				LEFT JOIN players AS p ON (d.player_id=p.id)
+				LEFT JOIN league_managers AS m ON (m.league_id=@leagueID AND m.external_id=d.team)
+			WHERE d.draft_id=@id
+			ORDER BY d.pick_no`
+
+	args := pgx.NamedArgs{
+		"leagueID": leagueID,
+		"id":       draftID,
+	}
+	d, err := scanDraft(db.pool.QueryRow(ctx, query, args))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("draft %d not found", draftID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.pool.Query(ctx, picksQuery, args)
+	if err != nil {
+		return nil, fmt.Errorf("error querying draft picks: %w", err)
+	}
+	for rows.Next() {
+		var p model.DraftPick
+		var playerID, nameFirst, nameLast, teamName, managerName sql.NullString
+		var pos DBPosition
+		err := rows.Scan(&p.Pick, &p.Round, &p.TeamID, &playerID, &p.IsKeeper, &p.Cost,
+			&nameFirst, &nameLast, &pos, &teamName, &managerName)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning draft pick: %w", err)
+		}
+		p.PlayerID = valueOrEmpty(playerID)
+		p.FirstName = valueOrEmpty(nameFirst)
+		p.LastName = valueOrEmpty(nameLast)
+		p.Position = pos.position
+		p.TeamName = first(valueOrEmpty(teamName), valueOrEmpty(managerName))
+
+		d.Picks = append(d.Picks, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading draft picks: %w", err)
+	}
+
+	return d, nil
+}
+
+// List the drafts for a league, most recent first. The picks are not loaded.
+func (db *postgresDB) ListDrafts(ctx context.Context, leagueID int32) ([]model.Draft, error) {
+	const query = `SELECT id, external_id, draft_type, rounds, started FROM drafts
+			WHERE league_id=@leagueID ORDER BY started DESC, id DESC`
+
+	rows, err := db.pool.Query(ctx, query, pgx.NamedArgs{"leagueID": leagueID})
+	if err != nil {
+		return nil, fmt.Errorf("error querying drafts: %w", err)
+	}
+
+	results := make([]model.Draft, 0)
+	for rows.Next() {
+		d, err := scanDraft(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading drafts: %w", err)
+	}
+
+	return results, nil
+}
+
+func scanDraft(row pgx.Row) (*model.Draft, error) {
+	var d model.Draft
+	var started pgtype.Timestamptz
+	if err := row.Scan(&d.ID, &d.ExternalID, &d.Type, &d.Rounds, &started); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error scanning draft: %w", err)
+	}
+	d.Started = started.Time.UTC()
+	return &d, nil
+}
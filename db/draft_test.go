@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestDrafts(t *testing.T) {
+	ctx := context.Background()
+	l := getLeague()
+
+	if err := testDB.AddLeague(ctx, l); err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		testDB.ArchiveLeague(ctx, l.ID)
+	}()
+
+	m1 := getLeagueManager()
+	m2 := getLeagueManager()
+	for _, m := range []*model.LeagueManager{m1, m2} {
+		if err := testDB.SaveLeagueManager(ctx, l.ID, m); err != nil {
+			t.Fatalf("error adding manager to league: %v", err)
+		}
+	}
+
+	p1 := getPlayerWithName("Amon-Ra", "St. Brown")
+	p2 := getPlayerWithName("Sam", "LaPorta")
+	for _, p := range []*model.Player{p1, p2} {
+		if err := testDB.SavePlayer(ctx, p); err != nil {
+			t.Fatalf("error saving player: %v", err)
+		}
+	}
+
+	started := time.Date(2024, time.August, 28, 1, 0, 0, 0, time.UTC)
+	d := &model.Draft{
+		ExternalID: "draft-1",
+		Type:       model.DraftSnake,
+		Rounds:     1,
+		Started:    started,
+		Picks: []model.DraftPick{
+			{Round: 1, Pick: 1, TeamID: m1.ExternalID, PlayerID: p1.ID},
+			{Round: 1, Pick: 2, TeamID: m2.ExternalID, PlayerID: p2.ID, IsKeeper: true},
+		},
+	}
+	if err := testDB.SaveDraft(ctx, l.ID, d); err != nil {
+		t.Fatalf("error saving draft: %v", err)
+	}
+	if d.ID == 0 {
+		t.Fatal("draft id was not set")
+	}
+
+	// Saving again should replace the picks instead of adding new ones
+	d.Picks[1].IsKeeper = false
+	if err := testDB.SaveDraft(ctx, l.ID, d); err != nil {
+		t.Fatalf("error saving draft a second time: %v", err)
+	}
+
+	res, err := testDB.GetDraft(ctx, l.ID, d.ID)
+	if err != nil {
+		t.Fatalf("error getting draft: %v", err)
+	}
+
+	expected := &model.Draft{
+		ID:         d.ID,
+		ExternalID: "draft-1",
+		Type:       model.DraftSnake,
+		Rounds:     1,
+		Started:    started,
+		Picks: []model.DraftPick{
+			{
+				Round: 1, Pick: 1, TeamID: m1.ExternalID, PlayerID: p1.ID, TeamName: m1.TeamName,
+				FirstName: "Amon-Ra", LastName: "St. Brown", Position: model.POS_WR,
+			},
+			{
+				Round: 1, Pick: 2, TeamID: m2.ExternalID, PlayerID: p2.ID, TeamName: m2.TeamName,
+				FirstName: "Sam", LastName: "LaPorta", Position: model.POS_WR,
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("expected: %v, got: %v", expected, res)
+	}
+
+	drafts, err := testDB.ListDrafts(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error listing drafts: %v", err)
+	}
+	if len(drafts) != 1 || drafts[0].ID != d.ID || drafts[0].Picks != nil {
+		t.Errorf("expected only the draft without picks, got: %v", drafts)
+	}
+
+	if _, err := testDB.GetDraft(ctx, l.ID, d.ID+1000); err == nil {
+		t.Error("expected an error getting a draft that doesn't exist")
+	}
+}
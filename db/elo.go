@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func (db *postgresDB) SaveEloRatings(ctx context.Context, leagueID int32, ratings []model.EloRating) error {
+	const deleteQuery = `DELETE FROM elo_ratings WHERE league_id=@leagueID`
+	const insertQuery = `INSERT INTO elo_ratings (league_id, week, team, rating)
+			VALUES (@leagueID, @week, @team, @rating)`
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, deleteQuery, pgx.NamedArgs{"leagueID": leagueID}); err != nil {
+		return fmt.Errorf("error deleting elo ratings for league %d: %w", leagueID, err)
+	}
+
+	for _, r := range ratings {
+		args := pgx.NamedArgs{
+			"leagueID": leagueID,
+			"week":     r.Week,
+			"team":     r.TeamID,
+			"rating":   r.Rating,
+		}
+		if _, err := tx.Exec(ctx, insertQuery, args); err != nil {
+			return fmt.Errorf("error inserting elo rating for team %s week %d: %w", r.TeamID, r.Week, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting transaction: %w", err)
+	}
+	return nil
+}
+
+func (db *postgresDB) GetEloRatings(ctx context.Context, leagueID int32) ([]model.EloRating, error) {
+	const query = `SELECT week, team, rating FROM elo_ratings WHERE league_id=@leagueID ORDER BY week, team`
+
+	rows, err := db.pool.Query(ctx, query, pgx.NamedArgs{"leagueID": leagueID})
+	if err != nil {
+		return nil, fmt.Errorf("error querying elo ratings: %w", err)
+	}
+
+	results := make([]model.EloRating, 0, 64)
+	for rows.Next() {
+		var r model.EloRating
+		if err := rows.Scan(&r.Week, &r.TeamID, &r.Rating); err != nil {
+			return nil, fmt.Errorf("error reading elo rating: %w", err)
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading elo ratings: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestEloRatings(t *testing.T) {
+	ctx := context.Background()
+	l := getLeague()
+	if err := testDB.AddLeague(ctx, l); err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		testDB.ArchiveLeague(ctx, l.ID)
+	}()
+
+	m1 := getLeagueManager()
+	m2 := getLeagueManager()
+	for _, m := range []*model.LeagueManager{m1, m2} {
+		if err := testDB.SaveLeagueManager(ctx, l.ID, m); err != nil {
+			t.Fatalf("error saving league manager: %v", err)
+		}
+	}
+
+	res, err := testDB.GetEloRatings(ctx, l.ID)
+	if err != nil || len(res) != 0 {
+		t.Errorf("expected no elo ratings, got: %v, err: %v", res, err)
+	}
+
+	ratings := []model.EloRating{
+		{TeamID: m1.ExternalID, Week: 0, Rating: 1500},
+		{TeamID: m2.ExternalID, Week: 0, Rating: 1520.5},
+		{TeamID: m1.ExternalID, Week: 1, Rating: 1510.25},
+		{TeamID: m2.ExternalID, Week: 1, Rating: 1510.25},
+	}
+	if err := testDB.SaveEloRatings(ctx, l.ID, ratings[:2]); err != nil {
+		t.Fatalf("error saving elo ratings: %v", err)
+	}
+	// Saving again replaces the ratings that were already saved
+	if err := testDB.SaveEloRatings(ctx, l.ID, ratings); err != nil {
+		t.Fatalf("error saving elo ratings a second time: %v", err)
+	}
+
+	res, err = testDB.GetEloRatings(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting elo ratings: %v", err)
+	}
+	// The ratings are sorted by week and then team
+	expected := ratings
+	if m2.ExternalID < m1.ExternalID {
+		expected = []model.EloRating{ratings[1], ratings[0], ratings[3], ratings[2]}
+	}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("expected: %v, got: %v", expected, res)
+	}
+}
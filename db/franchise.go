@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func (db *postgresDB) AddFranchise(ctx context.Context, f *model.Franchise) error {
+	const query = `INSERT INTO franchises (name) VALUES (@name) RETURNING id`
+
+	if err := db.pool.QueryRow(ctx, query, pgx.NamedArgs{"name": f.Name}).Scan(&f.ID); err != nil {
+		return fmt.Errorf("error inserting franchise: %w", err)
+	}
+	return nil
+}
+
+// Get the franchise and all of its leagues, oldest season first.
+func (db *postgresDB) GetFranchise(ctx context.Context, id int32) (*model.Franchise, error) {
+	const query = `SELECT id, name FROM franchises WHERE id=@id`
+	const leaguesQuery = `SELECT l.id, l.platform, l.external_id, l.name, l.year, l.archived
+			FROM franchise_leagues AS f INNER JOIN leagues AS l ON (f.league_id=l.id)
+			WHERE f.franchise_id=@id
+			ORDER BY l.year, l.id`
+
+	var f model.Franchise
+	args := pgx.NamedArgs{"id": id}
+	if err := db.pool.QueryRow(ctx, query, args).Scan(&f.ID, &f.Name); err != nil {
+		return nil, fmt.Errorf("error querying franchise: %w", err)
+	}
+
+	rows, err := db.pool.Query(ctx, leaguesQuery, args)
+	if err != nil {
+		return nil, fmt.Errorf("error querying franchise leagues: %w", err)
+	}
+	f.Leagues = make([]model.League, 0, 4)
+	for rows.Next() {
+		var l model.League
+		if err := rows.Scan(&l.ID, &l.Platform, &l.ExternalID, &l.Name, &l.Year, &l.Archived); err != nil {
+			return nil, fmt.Errorf("error reading franchise league: %w", err)
+		}
+		f.Leagues = append(f.Leagues, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading franchise leagues: %w", err)
+	}
+
+	return &f, nil
+}
+
+// List all of the franchises, the leagues are not loaded.
+func (db *postgresDB) ListFranchises(ctx context.Context) ([]model.Franchise, error) {
+	const query = `SELECT id, name FROM franchises ORDER BY name, id`
+
+	rows, err := db.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing franchises: %w", err)
+	}
+
+	results := make([]model.Franchise, 0, 4)
+	for rows.Next() {
+		var f model.Franchise
+		if err := rows.Scan(&f.ID, &f.Name); err != nil {
+			return nil, fmt.Errorf("error reading franchise: %w", err)
+		}
+		results = append(results, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading franchises: %w", err)
+	}
+	return results, nil
+}
+
+// Returns 0 if the league is not part of a franchise.
+func (db *postgresDB) GetLeagueFranchiseID(ctx context.Context, leagueID int32) (int32, error) {
+	const query = `SELECT franchise_id FROM franchise_leagues WHERE league_id=@leagueID`
+
+	var id int32
+	err := db.pool.QueryRow(ctx, query, pgx.NamedArgs{"leagueID": leagueID}).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("error querying league franchise: %w", err)
+	}
+	return id, nil
+}
+
+// Add the league to the franchise, moving it out of any franchise it was already in.
+func (db *postgresDB) LinkLeague(ctx context.Context, franchiseID, leagueID int32) error {
+	const query = `INSERT INTO franchise_leagues (league_id, franchise_id) VALUES (@leagueID, @franchiseID)
+			ON CONFLICT (league_id) DO UPDATE SET franchise_id=EXCLUDED.franchise_id`
+
+	args := pgx.NamedArgs{
+		"leagueID":    leagueID,
+		"franchiseID": franchiseID,
+	}
+	if _, err := db.pool.Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("error linking league %d to franchise %d: %w", leagueID, franchiseID, err)
+	}
+	return nil
+}
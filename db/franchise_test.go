@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestFranchises(t *testing.T) {
+	ctx := context.Background()
+
+	l1 := getLeague()
+	l1.Year = "2023"
+	l2 := getLeague()
+	l2.Year = "2024"
+	for _, l := range []*model.League{l2, l1} {
+		if err := testDB.AddLeague(ctx, l); err != nil {
+			t.Fatalf("error adding league: %v", err)
+		}
+	}
+	defer func() {
+		testDB.ArchiveLeague(ctx, l1.ID)
+		testDB.ArchiveLeague(ctx, l2.ID)
+	}()
+
+	id, err := testDB.GetLeagueFranchiseID(ctx, l1.ID)
+	if err != nil || id != 0 {
+		t.Errorf("expected league to not be part of a franchise, got: %d, err: %v", id, err)
+	}
+
+	f1 := &model.Franchise{Name: "Franchise 1"}
+	f2 := &model.Franchise{Name: "Franchise 2"}
+	for _, f := range []*model.Franchise{f1, f2} {
+		if err := testDB.AddFranchise(ctx, f); err != nil {
+			t.Fatalf("error adding franchise: %v", err)
+		}
+		if f.ID == 0 {
+			t.Fatal("franchise id was not set")
+		}
+	}
+
+	if err := testDB.LinkLeague(ctx, f2.ID, l1.ID); err != nil {
+		t.Fatalf("error linking league: %v", err)
+	}
+	// Linking again should move the league to the other franchise
+	for _, l := range []*model.League{l1, l2} {
+		if err := testDB.LinkLeague(ctx, f1.ID, l.ID); err != nil {
+			t.Fatalf("error linking league: %v", err)
+		}
+	}
+
+	id, err = testDB.GetLeagueFranchiseID(ctx, l1.ID)
+	if err != nil || id != f1.ID {
+		t.Errorf("expected franchise id %d, got: %d, err: %v", f1.ID, id, err)
+	}
+
+	res, err := testDB.GetFranchise(ctx, f1.ID)
+	if err != nil {
+		t.Fatalf("error getting franchise: %v", err)
+	}
+	if res.Name != "Franchise 1" || len(res.Leagues) != 2 {
+		t.Fatalf("unexpected franchise: %v", res)
+	}
+	// The oldest season should be first
+	if res.Leagues[0].ID != l1.ID || res.Leagues[1].ID != l2.ID {
+		t.Errorf("leagues are not in the expected order: %v", res.Leagues)
+	}
+
+	res, err = testDB.GetFranchise(ctx, f2.ID)
+	if err != nil || len(res.Leagues) != 0 {
+		t.Errorf("expected franchise without leagues, got: %v, err: %v", res, err)
+	}
+
+	list, err := testDB.ListFranchises(ctx)
+	if err != nil {
+		t.Fatalf("error listing franchises: %v", err)
+	}
+	found := 0
+	for _, f := range list {
+		if f.ID == f1.ID || f.ID == f2.ID {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("expected both franchises to be listed, got: %v", list)
+	}
+
+	if _, err := testDB.GetFranchise(ctx, f2.ID+1000); err == nil {
+		t.Error("expected an error getting a franchise that doesn't exist")
+	}
+}
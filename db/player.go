@@ -69,7 +69,7 @@ func (db *postgresDB) DeletePlayerNickname(ctx context.Context, playerID string,
 }
 
 func (db *postgresDB) Search(ctx context.Context, q string, pos model.Position, team *model.NFLTeam) ([]model.Player, error) {
-	const query = `SELECT id, yahoo_id, name_first, name_last, nickname1,
+	const query = `SELECT id, yahoo_id, espn_id, mfl_id, fleaflicker_id, name_first, name_last, nickname1,
 				  		position, team, weight_lb, height_in, birth_date,
 						rookie_year, years_exp, jersey_num, depth_chart_order,
 						college, active, created, updated
@@ -77,7 +77,7 @@ func (db *postgresDB) Search(ctx context.Context, q string, pos model.Position,
 						AND team ILIKE @team
 						AND position ILIKE @pos`
 
-	const teamAndPosQuery = `SELECT id, yahoo_id, name_first, name_last, nickname1,
+	const teamAndPosQuery = `SELECT id, yahoo_id, espn_id, mfl_id, fleaflicker_id, name_first, name_last, nickname1,
 					    		position, team, weight_lb, height_in, birth_date,
 					  			rookie_year, years_exp, jersey_num, depth_chart_order,
 					  			college, active, created, updated
@@ -228,29 +228,92 @@ func (db *postgresDB) GetTopScores(ctx context.Context, leagueID int32, week int
 func (db *postgresDB) ConvertYahooPlayerIDs(ctx context.Context, players []model.YahooPlayer) ([]string, error) {
 	results := make([]string, 0, len(players))
 	for _, p := range players {
-		id, err := db.findByYahooID(ctx, p.YahooID)
-		if errors.Is(err, pgx.ErrTooManyRows) {
-			return nil, fmt.Errorf("multiple results found for yahoo_id: %s", p.YahooID)
+		id, err := db.convertPlatformPlayerID(ctx, yahooIDColumn, p.YahooID, p.FirstName, p.LastName, p.Pos)
+		if err != nil {
+			return nil, err
 		}
-		if errors.Is(err, pgx.ErrNoRows) {
-			id, err = db.findByPlayerName(ctx, &p)
+		results = append(results, id)
+	}
+
+	return results, nil
+}
+
+func (db *postgresDB) ConvertMFLPlayerIDs(ctx context.Context, players []model.MFLPlayer) ([]string, error) {
+	results := make([]string, 0, len(players))
+	for _, p := range players {
+		id, err := db.convertPlatformPlayerID(ctx, mflIDColumn, p.MFLID, p.FirstName, p.LastName, p.Pos)
+		if err != nil {
+			return nil, err
 		}
+		results = append(results, id)
+	}
+
+	return results, nil
+}
+
+func (db *postgresDB) ConvertFleaflickerPlayerIDs(ctx context.Context, players []model.FleaflickerPlayer) ([]string, error) {
+	results := make([]string, 0, len(players))
+	for _, p := range players {
+		id, err := db.convertPlatformPlayerID(ctx, fleaflickerIDColumn, p.FleaflickerID, p.FirstName, p.LastName, p.Pos)
 		if err != nil {
 			return nil, err
 		}
+		results = append(results, id)
+	}
 
+	return results, nil
+}
+
+func (db *postgresDB) ConvertESPNPlayerIDs(ctx context.Context, players []model.ESPNPlayer) ([]string, error) {
+	results := make([]string, 0, len(players))
+	for _, p := range players {
+		id, err := db.convertPlatformPlayerID(ctx, espnIDColumn, p.ESPNID, p.FirstName, p.LastName, p.Pos)
+		if err != nil {
+			return nil, err
+		}
 		results = append(results, id)
 	}
 
 	return results, nil
 }
 
-func (db *postgresDB) findByYahooID(ctx context.Context, yahooID string) (string, error) {
-	const idQuery = `SELECT id FROM players WHERE yahoo_id=@yahooID`
+// platformIDColumn describes a column in the players table that holds the ID
+// another platform uses for the player.
+type platformIDColumn struct {
+	column   string // The column name in the players table
+	property string // The property name used when recording a player change
+}
+
+var (
+	yahooIDColumn       = platformIDColumn{column: "yahoo_id", property: "YahooID"}
+	espnIDColumn        = platformIDColumn{column: "espn_id", property: "ESPNID"}
+	mflIDColumn         = platformIDColumn{column: "mfl_id", property: "MFLID"}
+	fleaflickerIDColumn = platformIDColumn{column: "fleaflicker_id", property: "FleaflickerID"}
+)
 
-	rows, err := db.pool.Query(ctx, idQuery, pgx.NamedArgs{"yahooID": yahooID})
+// Look up the sleeper player id for a player on another platform. First the platform id
+// is checked, and if that isn't found then a search by name and position is done. When
+// a match is found by name the platform id is saved so future lookups are faster.
+func (db *postgresDB) convertPlatformPlayerID(ctx context.Context, col platformIDColumn, platformID, first, last string, pos model.Position) (string, error) {
+	id, err := db.findByPlatformID(ctx, col, platformID)
+	if errors.Is(err, pgx.ErrTooManyRows) {
+		return "", fmt.Errorf("multiple results found for %s: %s", col.column, platformID)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		id, err = db.findByPlayerName(ctx, col, platformID, first, last, pos)
+	}
 	if err != nil {
-		return "", fmt.Errorf("error querying player with yahoo_id=%s: %w", yahooID, err)
+		return "", err
+	}
+	return id, nil
+}
+
+func (db *postgresDB) findByPlatformID(ctx context.Context, col platformIDColumn, platformID string) (string, error) {
+	idQuery := fmt.Sprintf(`SELECT id FROM players WHERE %s=@platformID`, col.column)
+
+	rows, err := db.pool.Query(ctx, idQuery, pgx.NamedArgs{"platformID": platformID})
+	if err != nil {
+		return "", fmt.Errorf("error querying player with %s=%s: %w", col.column, platformID, err)
 	}
 
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (string, error) {
@@ -260,26 +323,27 @@ func (db *postgresDB) findByYahooID(ctx context.Context, yahooID string) (string
 	})
 }
 
-func (db *postgresDB) findByPlayerName(ctx context.Context, p *model.YahooPlayer) (string, error) {
-	yahooDetails := fmt.Sprintf("%s - %s %s %v", p.YahooID, p.FirstName, p.LastName, p.Pos)
+func (db *postgresDB) findByPlayerName(ctx context.Context, col platformIDColumn, platformID, first, last string, pos model.Position) (string, error) {
+	details := fmt.Sprintf("%s - %s %s %v", platformID, first, last, pos)
 
-	fullName := model.TrimNameSuffix(fmt.Sprintf("%s %s", p.FirstName, p.LastName))
-	results, err := db.Search(ctx, fullName, p.Pos, nil)
+	fullName := model.TrimNameSuffix(fmt.Sprintf("%s %s", first, last))
+	results, err := db.Search(ctx, fullName, pos, nil)
 	if err != nil {
-		return "", fmt.Errorf("error searching for %s: %w", yahooDetails, err)
+		return "", fmt.Errorf("error searching for %s: %w", details, err)
 	}
+	// Neither of these identify a single player
 	if len(results) == 0 {
-		return "", fmt.Errorf("no results found for %s", yahooDetails)
+		return "", fmt.Errorf("%w, no results found for %s", ErrPlayerNotFound, details)
 	}
 	if len(results) > 1 {
-		return "", fmt.Errorf("multiple results found for %s", yahooDetails)
+		return "", fmt.Errorf("%w, multiple results found for %s", ErrPlayerNotFound, details)
 	}
 
 	f := results[0]
-	if err := db.savePlayerYahooID(ctx, f.ID, p.YahooID); err != nil {
+	if err := db.savePlayerPlatformID(ctx, col, f.ID, platformID); err != nil {
 		return "", err
 	}
-	log.Printf("match found for yahoo id %s, sleeper id %s - %s %s", yahooDetails, f.ID, f.FirstName, f.LastName)
+	log.Printf("match found for %s %s, sleeper id %s - %s %s", col.column, details, f.ID, f.FirstName, f.LastName)
 	return f.ID, nil
 }
 
@@ -288,12 +352,15 @@ func scanPlayer(row pgx.Row) (*model.Player, error) {
 
 	var pos DBPosition
 	var team DBNFLTeam
-	var yahooID, nickname1, college sql.NullString
+	var yahooID, espnID, mflID, fleaflickerID, nickname1, college sql.NullString
 	var birthDate, rookieYear pgtype.Date
 	var created, updated pgtype.Timestamptz
 	err := row.Scan(
 		&result.ID,
 		&yahooID,
+		&espnID,
+		&mflID,
+		&fleaflickerID,
 		&result.FirstName,
 		&result.LastName,
 		&nickname1,
@@ -318,6 +385,9 @@ func scanPlayer(row pgx.Row) (*model.Player, error) {
 	result.Position = pos.position
 	result.Team = team.team
 	result.YahooID = valueOrEmpty(yahooID)
+	result.ESPNID = valueOrEmpty(espnID)
+	result.MFLID = valueOrEmpty(mflID)
+	result.FleaflickerID = valueOrEmpty(fleaflickerID)
 	result.Nickname1 = valueOrEmpty(nickname1)
 	result.College = valueOrEmpty(college)
 	result.BirthDate = birthDate.Time
@@ -361,6 +431,9 @@ func (db *postgresDB) insertPlayer(ctx context.Context, p *model.Player) error {
 	const query = `INSERT INTO players (
 		id,
 		yahoo_id,
+		espn_id,
+		mfl_id,
+		fleaflicker_id,
 		name_first,
 		name_last,
 		position,
@@ -377,6 +450,9 @@ func (db *postgresDB) insertPlayer(ctx context.Context, p *model.Player) error {
 	) VALUES (
 		@id,
 		@yahooID,
+		@espnID,
+		@mflID,
+		@fleaflickerID,
 		@nameFirst,
 		@nameLast,
 		@position,
@@ -509,8 +585,8 @@ func savePlayerNickname(ctx context.Context, tx pgx.Tx, id string, nickname stri
 	return nil
 }
 
-func (db *postgresDB) savePlayerYahooID(ctx context.Context, playerID string, yahooID string) error {
-	const query = `UPDATE players SET yahoo_id=@yahooID WHERE id=@playerID`
+func (db *postgresDB) savePlayerPlatformID(ctx context.Context, col platformIDColumn, playerID string, platformID string) error {
+	query := fmt.Sprintf(`UPDATE players SET %s=@platformID WHERE id=@playerID`, col.column)
 
 	tx, err := db.pool.Begin(ctx)
 	if err != nil {
@@ -520,23 +596,23 @@ func (db *postgresDB) savePlayerYahooID(ctx context.Context, playerID string, ya
 
 	args := pgx.NamedArgs{
 		"playerID": playerID,
-		"yahooID": sql.NullString{
-			String: yahooID,
-			Valid:  yahooID != "",
+		"platformID": sql.NullString{
+			String: platformID,
+			Valid:  platformID != "",
 		},
 	}
 	if _, err := tx.Exec(ctx, query, args); err != nil {
-		return fmt.Errorf("error setting player yahooID (%s): %w", playerID, err)
+		return fmt.Errorf("error setting player %s (%s): %w", col.column, playerID, err)
 	}
 
 	change := model.Change{
 		Time:         db.clock.Now().UTC(),
-		PropertyName: "YahooID",
+		PropertyName: col.property,
 		OldValue:     "",
-		NewValue:     yahooID,
+		NewValue:     platformID,
 	}
 	if err := insertPlayerChange(ctx, tx, playerID, &change); err != nil {
-		return fmt.Errorf("error inserting player change for updated yahoo id: %w", err)
+		return fmt.Errorf("error inserting player change for updated %s: %w", col.column, err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -577,6 +653,9 @@ func (db *postgresDB) calculateChanges(old, new *model.Player) ([]model.Change,
 	// changes.
 	// - Nickname1
 	// - YahooID
+	// - ESPNID
+	// - MFLID
+	// - FleaflickerID
 
 	changes = checkChange(changes, db.clock, "FirstName", old.FirstName, new.FirstName)
 	changes = checkChange(changes, db.clock, "LastName", old.LastName, new.LastName)
@@ -619,6 +698,18 @@ func namedArgsForPlayer(p *model.Player, clock clock.Clock) pgx.NamedArgs {
 			String: p.YahooID,
 			Valid:  p.YahooID != "",
 		},
+		"espnID": sql.NullString{
+			String: p.ESPNID,
+			Valid:  p.ESPNID != "",
+		},
+		"mflID": sql.NullString{
+			String: p.MFLID,
+			Valid:  p.MFLID != "",
+		},
+		"fleaflickerID": sql.NullString{
+			String: p.FleaflickerID,
+			Valid:  p.FleaflickerID != "",
+		},
 		"nameFirst": p.FirstName,
 		"nameLast":  p.LastName,
 		"nickname1": sql.NullString{
@@ -456,3 +456,180 @@ func TestConvertYahooPlayerIDs(t *testing.T) {
 		t.Errorf("expected an error but there wasn't one when looking up a duplicated ID")
 	}
 }
+
+func TestConvertESPNPlayerIDs(t *testing.T) {
+	ctx := context.Background()
+
+	players := []model.Player{
+		{ID: "1264", ESPNID: "15683", FirstName: "Justin", LastName: "Tucker", Position: model.POS_K, Team: model.TEAM_BAL},
+		{ID: "6904", ESPNID: "4040715", FirstName: "Jalen", LastName: "Hurts", Position: model.POS_QB, Team: model.TEAM_PHI},
+		{ID: "2078", ESPNID: "", FirstName: "Odell", LastName: "Beckham", Position: model.POS_WR, Team: model.TEAM_MIA},
+		{ID: "9509", ESPNID: "", FirstName: "Bijan", LastName: "Robinson", Position: model.POS_RB, Team: model.TEAM_ATL},
+		{ID: "SEA", ESPNID: "", FirstName: "Seattle", LastName: "Seahawks", Position: model.POS_DEF, Team: model.TEAM_SEA},
+
+		// These players have duplicate espn ids and are not used in the main test
+		{ID: "10222", ESPNID: "99999", FirstName: "Jayden", LastName: "Reed", Position: model.POS_WR, Team: model.TEAM_GBP},
+		{ID: "10223", ESPNID: "99999", FirstName: "Eric", LastName: "Gray", Position: model.POS_RB, Team: model.TEAM_NYG},
+	}
+
+	for _, p := range players {
+		if err := testDB.SavePlayer(ctx, &p); err != nil {
+			t.Fatalf("error saving player: %v", err)
+		}
+	}
+
+	input := []model.ESPNPlayer{
+		{ESPNID: "15683", FirstName: "Justin", LastName: "Tucker", Pos: model.POS_K},
+		{ESPNID: "4040715", FirstName: "Jalen", LastName: "Hurts", Pos: model.POS_QB},
+		{ESPNID: "2976499", FirstName: "Odell", LastName: "Beckham Jr.", Pos: model.POS_WR},
+		{ESPNID: "4430807", FirstName: "Bijan", LastName: "Robinson", Pos: model.POS_RB},
+		{ESPNID: "-16026", LastName: "Seahawks", Pos: model.POS_DEF},
+	}
+
+	expected := []string{"1264", "6904", "2078", "9509", "SEA"}
+
+	results, err := testDB.ConvertESPNPlayerIDs(ctx, input)
+	if err != nil {
+		t.Fatalf("error converting espn player ids: %v", err)
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("expected: %v, got: %v", expected, results)
+	}
+
+	// Verify that players where a named match was found had the espn id saved back to their record.
+	updatedPlayers := []struct {
+		playerID string
+		espnID   string
+	}{
+		{playerID: "2078", espnID: "2976499"},
+		{playerID: "9509", espnID: "4430807"},
+		{playerID: "SEA", espnID: "-16026"},
+	}
+	for _, up := range updatedPlayers {
+		p, err := testDB.GetPlayer(ctx, up.playerID)
+		if err != nil {
+			t.Errorf("error finding player with id: %s: %v", up.playerID, err)
+			continue
+		}
+		if p.ESPNID != up.espnID {
+			t.Errorf("player espn id does not match expected for player: %s (%s %s), wanted: '%s', got: '%s'",
+				up.playerID, p.FirstName, p.LastName, up.espnID, p.ESPNID)
+		}
+	}
+
+	notFound := []model.ESPNPlayer{
+		{ESPNID: "888888", FirstName: "Captain", LastName: "America", Pos: model.POS_RB},
+	}
+	if _, err := testDB.ConvertESPNPlayerIDs(ctx, notFound); err == nil {
+		t.Errorf("expected an error but there wasn't one when looking up Captain America")
+	}
+
+	multipleFound := []model.ESPNPlayer{
+		{ESPNID: "99999", FirstName: "Jayden", LastName: "Reed", Pos: model.POS_WR},
+	}
+	if _, err := testDB.ConvertESPNPlayerIDs(ctx, multipleFound); err == nil {
+		t.Errorf("expected an error but there wasn't one when looking up a duplicated ID")
+	}
+}
+
+func TestConvertMFLPlayerIDs(t *testing.T) {
+	ctx := context.Background()
+
+	players := []model.Player{
+		{ID: "1466", MFLID: "10744", FirstName: "Travis", LastName: "Kelce", Position: model.POS_TE, Team: model.TEAM_KCC},
+		{ID: "2449", MFLID: "", FirstName: "Stefon", LastName: "Diggs", Position: model.POS_WR, Team: model.TEAM_HOU},
+		{ID: "3214", MFLID: "", FirstName: "Hunter", LastName: "Henry", Position: model.POS_TE, Team: model.TEAM_NEP},
+
+		// These players have duplicate mfl ids and are not used in the main test
+		{ID: "10226", MFLID: "77777", FirstName: "Andrei", LastName: "Iosivas", Position: model.POS_WR, Team: model.TEAM_CIN},
+		{ID: "10231", MFLID: "77777", FirstName: "Elijah", LastName: "Higgins", Position: model.POS_TE, Team: model.TEAM_ARI},
+	}
+
+	for _, p := range players {
+		if err := testDB.SavePlayer(ctx, &p); err != nil {
+			t.Fatalf("error saving player: %v", err)
+		}
+	}
+
+	input := []model.MFLPlayer{
+		{MFLID: "10744", FirstName: "Travis", LastName: "Kelce", Pos: model.POS_TE},
+		{MFLID: "12133", FirstName: "Stefon", LastName: "Diggs", Pos: model.POS_WR},
+		{MFLID: "12157", FirstName: "Hunter", LastName: "Henry", Pos: model.POS_TE},
+	}
+
+	expected := []string{"1466", "2449", "3214"}
+
+	results, err := testDB.ConvertMFLPlayerIDs(ctx, input)
+	if err != nil {
+		t.Fatalf("error converting mfl player ids: %v", err)
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("expected: %v, got: %v", expected, results)
+	}
+
+	// The mfl id is saved when a match is found by name
+	p, err := testDB.GetPlayer(ctx, "2449")
+	if err != nil {
+		t.Fatalf("error finding player: %v", err)
+	}
+	if p.MFLID != "12133" {
+		t.Errorf("expected mfl id to be saved, wanted: '12133', got: '%s'", p.MFLID)
+	}
+
+	multipleFound := []model.MFLPlayer{
+		{MFLID: "77777", FirstName: "Andrei", LastName: "Iosivas", Pos: model.POS_WR},
+	}
+	if _, err := testDB.ConvertMFLPlayerIDs(ctx, multipleFound); err == nil {
+		t.Errorf("expected an error but there wasn't one when looking up a duplicated ID")
+	}
+}
+
+func TestConvertFleaflickerPlayerIDs(t *testing.T) {
+	ctx := context.Background()
+
+	players := []model.Player{
+		{ID: "2251", FleaflickerID: "9288", FirstName: "Logan", LastName: "Thomas", Position: model.POS_TE, Team: model.TEAM_WAS},
+		{ID: "11370", FleaflickerID: "", FirstName: "Chris", LastName: "Brooks", Position: model.POS_RB, Team: model.TEAM_MIA},
+
+		// These players have duplicate fleaflicker ids and are not used in the main test
+		{ID: "11435", FleaflickerID: "66666", FirstName: "Emanuel", LastName: "Wilson", Position: model.POS_RB, Team: model.TEAM_GBP},
+		{ID: "11439", FleaflickerID: "66666", FirstName: "Jaleel", LastName: "McLaughlin", Position: model.POS_RB, Team: model.TEAM_DEN},
+	}
+
+	for _, p := range players {
+		if err := testDB.SavePlayer(ctx, &p); err != nil {
+			t.Fatalf("error saving player: %v", err)
+		}
+	}
+
+	input := []model.FleaflickerPlayer{
+		{FleaflickerID: "9288", FirstName: "Logan", LastName: "Thomas", Pos: model.POS_TE},
+		{FleaflickerID: "18911", FirstName: "Chris", LastName: "Brooks", Pos: model.POS_RB},
+	}
+
+	expected := []string{"2251", "11370"}
+
+	results, err := testDB.ConvertFleaflickerPlayerIDs(ctx, input)
+	if err != nil {
+		t.Fatalf("error converting fleaflicker player ids: %v", err)
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("expected: %v, got: %v", expected, results)
+	}
+
+	// The fleaflicker id is saved when a match is found by name
+	p, err := testDB.GetPlayer(ctx, "11370")
+	if err != nil {
+		t.Fatalf("error finding player: %v", err)
+	}
+	if p.FleaflickerID != "18911" {
+		t.Errorf("expected fleaflicker id to be saved, wanted: '18911', got: '%s'", p.FleaflickerID)
+	}
+
+	multipleFound := []model.FleaflickerPlayer{
+		{FleaflickerID: "66666", FirstName: "Emanuel", LastName: "Wilson", Pos: model.POS_RB},
+	}
+	if _, err := testDB.ConvertFleaflickerPlayerIDs(ctx, multipleFound); err == nil {
+		t.Errorf("expected an error but there wasn't one when looking up a duplicated ID")
+	}
+}
@@ -43,7 +43,7 @@ type postgresDB struct {
 }
 
 func (db *postgresDB) ListRankings(ctx context.Context) ([]model.Ranking, error) {
-	const query = "SELECT id, ranking_date FROM rankings ORDER BY ranking_date DESC LIMIT 25"
+	const query = "SELECT id, ranking_date, source, scoring_format FROM rankings ORDER BY ranking_date DESC LIMIT 25"
 
 	rows, err := db.pool.Query(ctx, query)
 	if err != nil {
@@ -66,11 +66,12 @@ func (db *postgresDB) ListRankings(ctx context.Context) ([]model.Ranking, error)
 }
 
 func (db *postgresDB) GetRanking(ctx context.Context, id int32) (*model.Ranking, error) {
-	const metadataQuery = "SELECT id, ranking_date FROM rankings WHERE id=@id"
+	const metadataQuery = "SELECT id, ranking_date, source, scoring_format FROM rankings WHERE id=@id"
 	const rankingsQuery = `SELECT player_rankings.ranking, players.id, players.name_first, players.name_last, players.position, players.team
 							FROM player_rankings INNER JOIN players ON player_rankings.player_id=players.id
 							WHERE player_rankings.ranking_id=@id
 							ORDER BY player_rankings.ranking ASC`
+	const unmatchedQuery = "SELECT COUNT(*) FROM ranking_unmatched WHERE ranking_id=@id"
 
 	args := pgx.NamedArgs{
 		"id": id,
@@ -85,6 +86,10 @@ func (db *postgresDB) GetRanking(ctx context.Context, id int32) (*model.Ranking,
 	}
 	ranking.Players = make(map[string]model.RankingPlayer)
 
+	if err := db.pool.QueryRow(ctx, unmatchedQuery, args).Scan(&ranking.Unmatched); err != nil {
+		return nil, fmt.Errorf("error counting unmatched players: %w", err)
+	}
+
 	rows, err := db.pool.Query(ctx, rankingsQuery, args)
 	if err != nil {
 		return nil, fmt.Errorf("error querying for rankings data: %w", err)
@@ -111,8 +116,38 @@ func (db *postgresDB) GetRanking(ctx context.Context, id int32) (*model.Ranking,
 	return ranking, nil
 }
 
-func (db *postgresDB) AddRanking(ctx context.Context, date time.Time, rankings map[string]int32) (*model.Ranking, error) {
-	const insertRankingQuery = "INSERT INTO rankings(ranking_date) VALUES (@date) RETURNING id"
+func (db *postgresDB) AddRanking(ctx context.Context, date time.Time, source string, scoring model.ScoringFormat, rankings map[string]int32, unmatched []model.UnmatchedRankingPlayer) (*model.Ranking, error) {
+	if source == "" {
+		return nil, errors.New("rankings source must be provided")
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	r, err := insertRanking(ctx, tx, date, source, scoring, rankings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertUnmatchedRankingPlayers(ctx, tx, r.ID, unmatched); err != nil {
+		return nil, err
+	}
+	r.Unmatched = len(unmatched)
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error commiting add rankings transactions: %w", err)
+	}
+
+	return r, nil
+}
+
+// Insert the ranking metadata and the player rankings.
+func insertRanking(ctx context.Context, tx pgx.Tx, date time.Time, source string, scoring model.ScoringFormat, rankings map[string]int32) (*model.Ranking, error) {
+	const insertRankingQuery = "INSERT INTO rankings(ranking_date, source, scoring_format) VALUES (@date, @source, @scoring) RETURNING id"
 	const insertPlayerRankingQuery = "INSERT INTO player_rankings(ranking_id, player_id, ranking) VALUES (@rankingID, @playerID, @ranking)"
 
 	if date.IsZero() {
@@ -122,18 +157,19 @@ func (db *postgresDB) AddRanking(ctx context.Context, date time.Time, rankings m
 		return nil, errors.New("rankings cannot be empty")
 	}
 
-	tx, err := db.pool.Begin(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
 	r := &model.Ranking{
 		Date:    date,
+		Source:  source,
+		Scoring: scoring,
 		Players: make(map[string]model.RankingPlayer),
 	}
 
-	err = tx.QueryRow(ctx, insertRankingQuery, pgx.NamedArgs{"date": date}).Scan(&r.ID)
+	args := pgx.NamedArgs{
+		"date":    date,
+		"source":  source,
+		"scoring": string(scoring),
+	}
+	err := tx.QueryRow(ctx, insertRankingQuery, args).Scan(&r.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error inserting ranking into rankings table: %w", err)
 	}
@@ -158,18 +194,13 @@ func (db *postgresDB) AddRanking(ctx context.Context, date time.Time, rankings m
 		}
 		r.Players[playerID] = model.RankingPlayer{Rank: ranking, ID: playerID}
 	}
-
-	err = tx.Commit(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("error commiting add rankings transactions: %w", err)
-	}
-
 	return r, nil
 }
 
 func (db *postgresDB) DeleteRanking(ctx context.Context, id int32) error {
 	const deleteMetadataQuery = "DELETE FROM rankings WHERE id=@id"
 	const deleteRankingsQuery = "DELETE FROM player_rankings WHERE ranking_id=@id"
+	const deleteUnmatchedQuery = "DELETE FROM ranking_unmatched WHERE ranking_id=@id"
 
 	tx, err := db.pool.Begin(ctx)
 	if err != nil {
@@ -180,6 +211,12 @@ func (db *postgresDB) DeleteRanking(ctx context.Context, id int32) error {
 	args := pgx.NamedArgs{
 		"id": id,
 	}
+	if _, err := tx.Exec(ctx, deleteUnmatchedQuery, args); err != nil {
+		return fmt.Errorf("error deleting from ranking_unmatched: %w", err)
+	}
+	if err := deleteConsensusSources(ctx, tx, id); err != nil {
+		return err
+	}
 	tag, err := tx.Exec(ctx, deleteRankingsQuery, args)
 	if err != nil {
 		return fmt.Errorf("error deleting from player_rankings: %w", err)
@@ -190,6 +227,10 @@ func (db *postgresDB) DeleteRanking(ctx context.Context, id int32) error {
 
 	tag2, err2 := tx.Exec(ctx, deleteMetadataQuery, args)
 	if err2 != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err2, &pgErr) && pgErr.ConstraintName == "ranking_consensus_sources_source_ranking_id_fkey" {
+			return fmt.Errorf("ranking %d is used by a consensus ranking, delete the consensus ranking first", id)
+		}
 		return fmt.Errorf("error deleting from rankings: %w", err2)
 	}
 	if tag2.RowsAffected() != 1 {
@@ -203,6 +244,36 @@ func (db *postgresDB) DeleteRanking(ctx context.Context, id int32) error {
 	return nil
 }
 
+func (db *postgresDB) GetPlayerRankings(ctx context.Context, playerID string) ([]model.PlayerRankingPoint, error) {
+	const query = `SELECT rankings.id, rankings.ranking_date, rankings.source, rankings.scoring_format, player_rankings.ranking
+					FROM player_rankings INNER JOIN rankings ON player_rankings.ranking_id=rankings.id
+					WHERE player_rankings.player_id=@playerID
+					ORDER BY rankings.ranking_date ASC, rankings.id ASC`
+
+	rows, err := db.pool.Query(ctx, query, pgx.NamedArgs{"playerID": playerID})
+	if err != nil {
+		return nil, fmt.Errorf("error querying for player rankings: %w", err)
+	}
+
+	results := make([]model.PlayerRankingPoint, 0)
+	for rows.Next() {
+		var p model.PlayerRankingPoint
+		var date pgtype.Timestamptz
+		var scoring string
+		if err := rows.Scan(&p.RankingID, &date, &p.Source, &scoring, &p.Rank); err != nil {
+			return nil, fmt.Errorf("error reading player ranking: %w", err)
+		}
+		p.Date = date.Time.UTC()
+		p.Scoring = model.ParseScoringFormat(scoring)
+		results = append(results, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading results of player rankings query: %w", err)
+	}
+
+	return results, nil
+}
+
 func (db *postgresDB) ListLeagues(ctx context.Context) ([]model.League, error) {
 	const listLeaguesQuery = `SELECT id, platform, external_id, name, year, archived FROM leagues WHERE archived=false`
 
@@ -391,8 +462,8 @@ func (db *postgresDB) SaveToken(ctx context.Context, leagueID int32, token *oaut
 }
 
 func (db *postgresDB) SaveResults(ctx context.Context, leagueID int32, matchups []model.Matchup) error {
-	const insert = `INSERT INTO team_results(league_id, week, match_id, team, score)
-			VALUES(@leagueID, @week, @matchID, @team, @score)`
+	const insert = `INSERT INTO team_results(league_id, week, match_id, team, score, allplay_wins, allplay_losses, allplay_draws)
+			VALUES(@leagueID, @week, @matchID, @team, @score, @allPlayWins, @allPlayLosses, @allPlayDraws)`
 	const seq = `SELECT nextval('match_ids')`
 
 	tx, err := db.pool.Begin(ctx)
@@ -426,11 +497,14 @@ func (db *postgresDB) SaveResults(ctx context.Context, leagueID int32, matchups
 
 func namedArgsForTeamResult(leagueID int32, matchID int32, week int, tr *model.TeamResult) pgx.NamedArgs {
 	return pgx.NamedArgs{
-		"leagueID": leagueID,
-		"week":     week,
-		"matchID":  matchID,
-		"team":     tr.TeamID,
-		"score":    tr.Score,
+		"leagueID":      leagueID,
+		"week":          week,
+		"matchID":       matchID,
+		"team":          tr.TeamID,
+		"score":         tr.Score,
+		"allPlayWins":   tr.AllPlayWins,
+		"allPlayLosses": tr.AllPlayLosses,
+		"allPlayDraws":  tr.AllPlayDraws,
 	}
 }
 
@@ -440,7 +514,10 @@ func (db *postgresDB) GetResults(ctx context.Context, leagueID int32, week int)
 					league_managers.manager_name,
 					league_managers.external_id,
 					team_results.match_id,
-					team_results.score 
+					team_results.score,
+					team_results.allplay_wins,
+					team_results.allplay_losses,
+					team_results.allplay_draws
 				FROM team_results INNER JOIN league_managers ON 
 					(team_results.league_id=league_managers.league_id AND team_results.team=league_managers.external_id)
 				WHERE team_results.league_id=@leagueID AND team_results.week=@week
@@ -455,13 +532,17 @@ func (db *postgresDB) GetResults(ctx context.Context, leagueID int32, week int)
 	for rows.Next() {
 		var team, manager, id string
 		var matchID, score int32
-		if err := rows.Scan(&team, &manager, &id, &matchID, &score); err != nil {
+		var wins, losses, draws int
+		if err := rows.Scan(&team, &manager, &id, &matchID, &score, &wins, &losses, &draws); err != nil {
 			return nil, fmt.Errorf("error scanning team result: %w", err)
 		}
 		tr := &model.TeamResult{
-			TeamID:   id,
-			TeamName: first(team, manager),
-			Score:    score,
+			TeamID:        id,
+			TeamName:      first(team, manager),
+			Score:         score,
+			AllPlayWins:   wins,
+			AllPlayLosses: losses,
+			AllPlayDraws:  draws,
 		}
 
 		if m, found := resultMap[matchID]; found {
@@ -514,8 +595,80 @@ func (db *postgresDB) ListResultWeeks(ctx context.Context, leagueID int32) ([]in
 }
 
 func (db *postgresDB) SavePowerRanking(ctx context.Context, leagueID int32, pr *model.PowerRanking) (int32, error) {
-	const insertPRQuery = `INSERT INTO power_rankings (league_id, ranking_id, week) 
-			VALUES (@leagueID, @rankingID, @week) RETURNING id`
+	const insertPRQuery = `INSERT INTO power_rankings (
+				league_id,
+				ranking_id,
+				week,
+				bench_percent,
+				points_against_percent,
+				win_points,
+				streak_points,
+				points_weeks,
+				allplay_points,
+				schedule_points,
+				tie_breaks,
+				compare_id
+			) VALUES (
+				@leagueID,
+				@rankingID,
+				@week,
+				@benchPercent,
+				@pointsAgainstPercent,
+				@winPoints,
+				@streakPoints,
+				@pointsWeeks,
+				@allPlayPoints,
+				@schedulePoints,
+				@tieBreaks,
+				@compareID
+			) RETURNING id`
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	prArgs := pgx.NamedArgs{
+		"leagueID":  leagueID,
+		"rankingID": pr.RankingID,
+		"week":      pr.Week,
+
+		"benchPercent":         pr.Config.BenchPercent,
+		"pointsAgainstPercent": pr.Config.PointsAgainstPercent,
+		"winPoints":            pr.Config.WinPoints,
+		"streakPoints":         pr.Config.StreakPoints,
+		"pointsWeeks":          pr.Config.PointsWeeks,
+		"allPlayPoints":        pr.Config.AllPlayPoints,
+		"schedulePoints":       pr.Config.SchedulePoints,
+		"tieBreaks":            strings.Join(pr.Config.TieBreaks, ","),
+
+		"compareID": sql.NullInt32{
+			Int32: pr.CompareID,
+			Valid: pr.CompareID != 0,
+		},
+	}
+	err = tx.QueryRow(ctx, insertPRQuery, prArgs).Scan(&pr.ID)
+	if err != nil {
+		return 0, fmt.Errorf("error inserting power ranking: %w", err)
+	}
+	if pr.ID <= 0 {
+		return 0, fmt.Errorf("did not get a valid ID for power ranking, got: %d", pr.ID)
+	}
+
+	if err := insertPowerRankingTeams(ctx, tx, leagueID, pr); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("error commiting transaction: %w", err)
+	}
+
+	return pr.ID, nil
+}
+
+// Insert the teams, component scores and rosters of the power ranking, pr.ID must already be set.
+func insertPowerRankingTeams(ctx context.Context, tx pgx.Tx, leagueID int32, pr *model.PowerRanking) error {
 	const insertTeamPowerRankingQuery = `INSERT INTO team_power_rankings (
 				power_ranking_id,
 				league_id,
@@ -523,11 +676,7 @@ func (db *postgresDB) SavePowerRanking(ctx context.Context, leagueID int32, pr *
 				rank,
 				rank_change,
 				total_score,
-				roster_score,
-				record_score,
-				streak_score,
-				points_for_score,
-				points_against_score
+				position
 			) VALUES (
 			 	@powerRankingID,
 				@leagueID,
@@ -535,11 +684,22 @@ func (db *postgresDB) SavePowerRanking(ctx context.Context, leagueID int32, pr *
 				@rank,
 				@rankChange,
 				@totalScore,
-				@rosterScore,
-				@recordScore,
-				@streakScore,
-				@pointsForScore,
-				@pointsAgainstScore
+				@position
+			)`
+	const insertScoreQuery = `INSERT INTO team_power_ranking_scores (
+				power_ranking_id,
+				league_id,
+				team,
+				component,
+				position,
+				score
+			) VALUES (
+			 	@powerRankingID,
+				@leagueID,
+				@team,
+				@component,
+				@position,
+				@score
 			)`
 	const insertRosterQuery = `INSERT INTO power_rankings_rosters (
 				power_ranking_id,
@@ -549,7 +709,8 @@ func (db *postgresDB) SavePowerRanking(ctx context.Context, leagueID int32, pr *
 				nfl_team,
 				player_rank,
 				player_points,
-				starter
+				starter,
+				roster_spot
 			) VALUES (
 			 	@powerRankingID,
 				@leagueID,
@@ -558,44 +719,37 @@ func (db *postgresDB) SavePowerRanking(ctx context.Context, leagueID int32, pr *
 				@nflTeam,
 				@playerRank,
 				@playerPoints,
-				@starter
+				@starter,
+				@rosterSpot
 			)`
 
-	tx, err := db.pool.Begin(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("error starting transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
-
-	prArgs := pgx.NamedArgs{
-		"leagueID":  leagueID,
-		"rankingID": pr.RankingID,
-		"week":      pr.Week,
-	}
-	err = tx.QueryRow(ctx, insertPRQuery, prArgs).Scan(&pr.ID)
-	if err != nil {
-		return 0, fmt.Errorf("error inserting power ranking: %w", err)
-	}
-	if pr.ID <= 0 {
-		return 0, fmt.Errorf("did not get a valid ID for power ranking, got: %d", pr.ID)
-	}
-
-	for _, t := range pr.Teams {
+	// The teams are saved with their position so tied teams keep their tie-break order
+	for position, t := range pr.Teams {
 		teamArgs := pgx.NamedArgs{
-			"powerRankingID":     pr.ID,
-			"leagueID":           leagueID,
-			"team":               t.TeamID,
-			"rank":               t.Rank,
-			"rankChange":         t.RankChange,
-			"totalScore":         t.TotalScore,
-			"rosterScore":        t.RosterScore,
-			"recordScore":        t.RecordScore,
-			"streakScore":        t.StreakScore,
-			"pointsForScore":     t.PointsForScore,
-			"pointsAgainstScore": t.PointsAgainstScore,
+			"powerRankingID": pr.ID,
+			"leagueID":       leagueID,
+			"team":           t.TeamID,
+			"rank":           t.Rank,
+			"rankChange":     t.RankChange,
+			"totalScore":     t.TotalScore,
+			"position":       position,
 		}
 		if _, err := tx.Exec(ctx, insertTeamPowerRankingQuery, teamArgs); err != nil {
-			return 0, fmt.Errorf("error inserting team %s into power rankings: %w", t.TeamID, err)
+			return fmt.Errorf("error inserting team %s into power rankings: %w", t.TeamID, err)
+		}
+
+		for i, c := range pr.Components {
+			scoreArgs := pgx.NamedArgs{
+				"powerRankingID": pr.ID,
+				"leagueID":       leagueID,
+				"team":           t.TeamID,
+				"component":      c,
+				"position":       i,
+				"score":          t.Scores[c],
+			}
+			if _, err := tx.Exec(ctx, insertScoreQuery, scoreArgs); err != nil {
+				return fmt.Errorf("error inserting %s score for team %s: %w", c, t.TeamID, err)
+			}
 		}
 
 		for _, p := range t.Roster {
@@ -608,22 +762,137 @@ func (db *postgresDB) SavePowerRanking(ctx context.Context, leagueID int32, pr *
 				"playerRank":     p.Rank,
 				"playerPoints":   p.PowerRankingPoints,
 				"starter":        p.IsStarter,
+				"rosterSpot":     p.RosterSpot,
 			}
 			if _, err := tx.Exec(ctx, insertRosterQuery, rosterArgs); err != nil {
-				return 0, fmt.Errorf("error inserting player %s into power ranking rosters: %w", p.PlayerID, err)
+				return fmt.Errorf("error inserting player %s into power ranking rosters: %w", p.PlayerID, err)
 			}
 		}
 	}
+	return nil
+}
+
+func (db *postgresDB) ReplacePowerRanking(ctx context.Context, leagueID int32, pr *model.PowerRanking) error {
+	const updatePRQuery = `UPDATE power_rankings SET
+				ranking_id=@rankingID,
+				week=@week,
+				bench_percent=@benchPercent,
+				points_against_percent=@pointsAgainstPercent,
+				win_points=@winPoints,
+				streak_points=@streakPoints,
+				points_weeks=@pointsWeeks,
+				allplay_points=@allPlayPoints,
+				schedule_points=@schedulePoints,
+				tie_breaks=@tieBreaks,
+				compare_id=@compareID
+			WHERE id=@id AND league_id=@leagueID`
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	prArgs := pgx.NamedArgs{
+		"id":        pr.ID,
+		"leagueID":  leagueID,
+		"rankingID": pr.RankingID,
+		"week":      pr.Week,
+
+		"benchPercent":         pr.Config.BenchPercent,
+		"pointsAgainstPercent": pr.Config.PointsAgainstPercent,
+		"winPoints":            pr.Config.WinPoints,
+		"streakPoints":         pr.Config.StreakPoints,
+		"pointsWeeks":          pr.Config.PointsWeeks,
+		"allPlayPoints":        pr.Config.AllPlayPoints,
+		"schedulePoints":       pr.Config.SchedulePoints,
+		"tieBreaks":            strings.Join(pr.Config.TieBreaks, ","),
+
+		"compareID": sql.NullInt32{
+			Int32: pr.CompareID,
+			Valid: pr.CompareID != 0,
+		},
+	}
+	tag, err := tx.Exec(ctx, updatePRQuery, prArgs)
+	if err != nil {
+		return fmt.Errorf("error updating power ranking: %w", err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("power ranking %d not found for league %d", pr.ID, leagueID)
+	}
+
+	if err := deletePowerRankingTeams(ctx, tx, leagueID, pr.ID); err != nil {
+		return err
+	}
+	if err := insertPowerRankingTeams(ctx, tx, leagueID, pr); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return 0, fmt.Errorf("error commiting transaction: %w", err)
+		return fmt.Errorf("error commiting transaction: %w", err)
 	}
+	return nil
+}
 
-	return pr.ID, nil
+func (db *postgresDB) DeletePowerRanking(ctx context.Context, leagueID, powerRankingID int32) error {
+	const deletePRQuery = "DELETE FROM power_rankings WHERE id=@id AND league_id=@leagueID"
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := deletePowerRankingTeams(ctx, tx, leagueID, powerRankingID); err != nil {
+		return err
+	}
+
+	args := pgx.NamedArgs{
+		"id":       powerRankingID,
+		"leagueID": leagueID,
+	}
+	tag, err := tx.Exec(ctx, deletePRQuery, args)
+	if err != nil {
+		return fmt.Errorf("error deleting from power_rankings: %w", err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("power ranking %d not found for league %d", powerRankingID, leagueID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting delete power ranking transaction: %w", err)
+	}
+	return nil
+}
+
+// Delete everything that was saved for the teams of the power ranking, leaving the
+// power ranking itself.
+func deletePowerRankingTeams(ctx context.Context, tx pgx.Tx, leagueID, powerRankingID int32) error {
+	queries := []struct {
+		table string
+		query string
+	}{
+		{table: "power_rankings_rosters", query: "DELETE FROM power_rankings_rosters WHERE power_ranking_id=@id AND league_id=@leagueID"},
+		{table: "team_power_ranking_scores", query: "DELETE FROM team_power_ranking_scores WHERE power_ranking_id=@id AND league_id=@leagueID"},
+		{table: "team_power_rankings", query: "DELETE FROM team_power_rankings WHERE power_ranking_id=@id AND league_id=@leagueID"},
+	}
+
+	args := pgx.NamedArgs{
+		"id":       powerRankingID,
+		"leagueID": leagueID,
+	}
+	for _, q := range queries {
+		if _, err := tx.Exec(ctx, q.query, args); err != nil {
+			return fmt.Errorf("error deleting from %s: %w", q.table, err)
+		}
+	}
+	return nil
 }
 
 func (db *postgresDB) GetPowerRanking(ctx context.Context, leagueID, powerRankingID int32) (*model.PowerRanking, error) {
-	const prQuery = `SELECT ranking_id, week, created FROM power_rankings WHERE id=@id AND league_id=@leagueID`
+	const prQuery = `SELECT ranking_id, week, bench_percent, points_against_percent,
+				win_points, streak_points, points_weeks, allplay_points, schedule_points, tie_breaks, compare_id, created
+			FROM power_rankings WHERE id=@id AND league_id=@leagueID`
 
 	pr := model.PowerRanking{
 		ID: powerRankingID,
@@ -633,14 +902,24 @@ func (db *postgresDB) GetPowerRanking(ctx context.Context, leagueID, powerRankin
 		"leagueID": leagueID,
 	}
 	var created pgtype.Timestamptz
-	if err := db.pool.QueryRow(ctx, prQuery, args).Scan(&pr.RankingID, &pr.Week, &created); err != nil {
+	var compareID sql.NullInt32
+	var tieBreaks string
+	c := &pr.Config
+	err := db.pool.QueryRow(ctx, prQuery, args).Scan(&pr.RankingID, &pr.Week, &c.BenchPercent, &c.PointsAgainstPercent,
+		&c.WinPoints, &c.StreakPoints, &c.PointsWeeks, &c.AllPlayPoints, &c.SchedulePoints, &tieBreaks, &compareID, &created)
+	if err != nil {
 		return nil, fmt.Errorf("error querying by power ranking id: %w", err)
 	}
+	c.TieBreaks = splitTieBreaks(tieBreaks)
+	pr.CompareID = compareID.Int32
 	pr.Created = created.Time
 
 	if err := db.getPowerRankingTeams(ctx, &pr, leagueID); err != nil {
 		return nil, err
 	}
+	if err := db.getPowerRankingScores(ctx, &pr, leagueID); err != nil {
+		return nil, err
+	}
 
 	return &pr, nil
 }
@@ -683,12 +962,11 @@ func (db *postgresDB) ListPowerRankings(ctx context.Context, leagueID int32) ([]
 func (db *postgresDB) getPowerRankingTeams(ctx context.Context, pr *model.PowerRanking, leagueID int32) error {
 	const teamQuery = `SELECT 
 				t.team, m.team_name, m.manager_name, t.rank, 
-				t.rank_change, t.total_score, t.roster_score, t.record_score,
-				t.streak_score, t.points_for_score, t.points_against_score
+				t.rank_change, t.total_score
 			FROM team_power_rankings AS t INNER JOIN league_managers AS m 
 				ON (t.team=m.external_id AND t.league_id=m.league_id) 
 			WHERE t.power_ranking_id=@id AND t.league_id=@leagueID
-			ORDER BY rank;`
+			ORDER BY t.rank, t.position;`
 
 	args := pgx.NamedArgs{
 		"id":       pr.ID,
@@ -700,13 +978,13 @@ func (db *postgresDB) getPowerRankingTeams(ctx context.Context, pr *model.PowerR
 	}
 	for rows.Next() {
 		t := model.TeamPowerRanking{
+			Scores: make(map[string]int32),
 			Roster: make([]model.PowerRankingPlayer, 0, 15),
 		}
 
 		var teamName, managerName string
 		err := rows.Scan(&t.TeamID, &teamName, &managerName, &t.Rank,
-			&t.RankChange, &t.TotalScore, &t.RosterScore, &t.RecordScore,
-			&t.StreakScore, &t.PointsForScore, &t.PointsAgainstScore)
+			&t.RankChange, &t.TotalScore)
 		if err != nil {
 			return fmt.Errorf("error scanning team result: %w", err)
 		}
@@ -725,10 +1003,49 @@ func (db *postgresDB) getPowerRankingTeams(ctx context.Context, pr *model.PowerR
 	return nil
 }
 
+// Load the component scores for each team, the teams must already be loaded.
+func (db *postgresDB) getPowerRankingScores(ctx context.Context, pr *model.PowerRanking, leagueID int32) error {
+	const scoresQuery = `SELECT team, component, score
+			FROM team_power_ranking_scores
+			WHERE power_ranking_id=@id AND league_id=@leagueID
+			ORDER BY position, team`
+
+	args := pgx.NamedArgs{
+		"id":       pr.ID,
+		"leagueID": leagueID,
+	}
+	rows, err := db.pool.Query(ctx, scoresQuery, args)
+	if err != nil {
+		return fmt.Errorf("error getting power ranking scores: %w", err)
+	}
+
+	teams := make(map[string]*model.TeamPowerRanking)
+	for i := range pr.Teams {
+		teams[pr.Teams[i].TeamID] = &pr.Teams[i]
+	}
+
+	pr.Components = make([]string, 0, 8)
+	for rows.Next() {
+		var team, component string
+		var score int32
+		if err := rows.Scan(&team, &component, &score); err != nil {
+			return fmt.Errorf("error scanning power ranking score: %w", err)
+		}
+
+		if !slices.Contains(pr.Components, component) {
+			pr.Components = append(pr.Components, component)
+		}
+		if t, found := teams[team]; found {
+			t.Scores[component] = score
+		}
+	}
+	return rows.Err()
+}
+
 func (db *postgresDB) getPowerRankingPlayers(ctx context.Context, t *model.TeamPowerRanking, leagueID, powerRankingID int32) error {
 	const rosterQuery = `SELECT
 				r.player_id, p.name_first, p.name_last, p.position,
-				r.nfl_team, r.player_rank, r.player_points, r.starter
+				r.nfl_team, r.player_rank, r.player_points, r.starter, r.roster_spot
 			FROM power_rankings_rosters AS r INNER JOIN players AS p
 				ON (r.player_id=p.id)
 			WHERE r.power_ranking_id=@id AND r.league_id=@leagueID AND r.team=@teamID 
@@ -749,7 +1066,7 @@ func (db *postgresDB) getPowerRankingPlayers(ctx context.Context, t *model.TeamP
 		var pos DBPosition
 		var nflTeam DBNFLTeam
 		err := rows.Scan(&p.PlayerID, &p.FirstName, &p.LastName, &pos,
-			&nflTeam, &p.Rank, &p.PowerRankingPoints, &p.IsStarter)
+			&nflTeam, &p.Rank, &p.PowerRankingPoints, &p.IsStarter, &p.RosterSpot)
 		if err != nil {
 			return fmt.Errorf("error scanning team roster: %w", err)
 		}
@@ -766,7 +1083,7 @@ func (db *postgresDB) getPowerRankingPlayers(ctx context.Context, t *model.TeamP
 }
 
 func (db *postgresDB) getPlayer(ctx context.Context, id string) (*model.Player, error) {
-	const query = `SELECT id, yahoo_id, name_first, name_last, nickname1,
+	const query = `SELECT id, yahoo_id, espn_id, mfl_id, fleaflicker_id, name_first, name_last, nickname1,
 				  		position, team, weight_lb, height_in, birth_date,
 						rookie_year, years_exp, jersey_num, depth_chart_order,
 						college, active, created, updated
@@ -793,8 +1110,9 @@ func (db *postgresDB) getPlayer(ctx context.Context, id string) (*model.Player,
 func scanRanking(row pgx.Row) (*model.Ranking, error) {
 	var r model.Ranking
 	var date pgtype.Timestamptz
+	var scoring string
 
-	err := row.Scan(&r.ID, &date)
+	err := row.Scan(&r.ID, &date, &r.Source, &scoring)
 	if err != nil {
 		return nil, fmt.Errorf("error scanning row: %w", err)
 	}
@@ -802,6 +1120,7 @@ func scanRanking(row pgx.Row) (*model.Ranking, error) {
 		return nil, fmt.Errorf("ranking date is not valid: %w", err)
 	}
 	r.Date = date.Time.UTC()
+	r.Scoring = model.ParseScoringFormat(scoring)
 
 	return &r, nil
 }
@@ -105,7 +105,7 @@ func TestRankings(t *testing.T) {
 			t.Fatalf("error parsing ranking date: %v", err)
 		}
 
-		ranking, err := testDB.AddRanking(ctx, d, r.rankings)
+		ranking, err := testDB.AddRanking(ctx, d, model.RankingSourceFantasyPros, model.SCORING_HALF, r.rankings, nil)
 		if err != nil {
 			t.Fatalf("error adding ranking for test: %v", err)
 		}
@@ -139,6 +139,8 @@ func TestRankings(t *testing.T) {
 		t.Fatalf("error getting ranking by id: %v", err)
 	}
 	assertEquals(t, "getResult.Date", "2023-10-04", getResult.Date.Format(time.DateOnly))
+	assertEquals(t, "getResult.Source", model.RankingSourceFantasyPros, getResult.Source)
+	assertEquals(t, "getResult.Scoring", model.SCORING_HALF, getResult.Scoring)
 	expectedRankings := map[string]model.RankingPlayer{
 		p5.ID: {Rank: 1, ID: p5.ID, FirstName: p5.FirstName, LastName: p5.LastName, Position: p5.Position, Team: p5.Team},
 		p4.ID: {Rank: 2, ID: p4.ID, FirstName: p4.FirstName, LastName: p4.LastName, Position: p4.Position, Team: p4.Team},
@@ -149,6 +151,23 @@ func TestRankings(t *testing.T) {
 	if !reflect.DeepEqual(expectedRankings, getResult.Players) {
 		t.Errorf("expectedRanking != getResult.Players, got: %v", getResult.Players)
 	}
+
+	// The player's rank in each ranking, sorted by date
+	history, err := testDB.GetPlayerRankings(ctx, p3.ID)
+	if err != nil {
+		t.Fatalf("error getting player rankings: %v", err)
+	}
+	if len(history) != len(rankings) {
+		t.Fatalf("expected %d player rankings, got %d", len(rankings), len(history))
+	}
+	expectedRanks := []int32{3, 5, 3, 5, 3}
+	for i, h := range history {
+		assertEquals(t, "history.Date", expectedDates[len(expectedDates)-1-i], h.Date.Format(time.DateOnly))
+		assertEquals(t, "history.Rank", expectedRanks[i], h.Rank)
+		assertEquals(t, "history.Source", model.RankingSourceFantasyPros, h.Source)
+		assertEquals(t, "history.Scoring", model.SCORING_HALF, h.Scoring)
+	}
+	assertEquals(t, "last history.RankingID", rankingID, history[len(history)-1].RankingID)
 }
 
 func TestAddRanking_negativeCases(t *testing.T) {
@@ -212,13 +231,17 @@ func TestAddRanking_negativeCases(t *testing.T) {
 				}
 			}
 
-			res, err := testDB.AddRanking(ctx, rankingDate, tc.rankings)
+			res, err := testDB.AddRanking(ctx, rankingDate, model.RankingSourceCSV, model.SCORING_UNKNOWN, tc.rankings, nil)
 			assertError(t, tc.name, tc.err, err)
 			if res != nil {
 				t.Error("expected res to be nil")
 			}
 		})
 	}
+
+	rankingDate, _ := time.ParseInLocation(time.DateOnly, "2023-09-01", time.UTC)
+	_, err := testDB.AddRanking(ctx, rankingDate, "", model.SCORING_PPR, map[string]int32{p1.ID: 1}, nil)
+	assertError(t, "missing source", errors.New("rankings source must be provided"), err)
 }
 
 func TestLeagues(t *testing.T) {
@@ -563,7 +586,7 @@ func TestPowerRankings(t *testing.T) {
 	// Make the date before any of the ones in TestRankings() to keep
 	// the list order working.
 	rankingDate, _ := time.Parse(time.DateOnly, "2022-10-11")
-	ranking, err := testDB.AddRanking(ctx, rankingDate, playerRanks)
+	ranking, err := testDB.AddRanking(ctx, rankingDate, model.RankingSourceFantasyPros, model.SCORING_UNKNOWN, playerRanks, nil)
 	if err != nil {
 		t.Fatalf("error adding ranking: %v", err)
 	}
@@ -571,12 +594,17 @@ func TestPowerRankings(t *testing.T) {
 	pr1 := &model.PowerRanking{
 		RankingID: ranking.ID,
 		Week:      0,
+		Config: model.PowerRankingConfig{
+			BenchPercent: 50, PointsAgainstPercent: 20, WinPoints: 8, StreakPoints: 4, PointsWeeks: 2,
+			TieBreaks: []string{model.TieBreakRoster, model.TieBreakRecord},
+		},
+		Components: []string{"Roster"},
 		Teams: []model.TeamPowerRanking{
 			{
-				TeamID:      m1.ExternalID,
-				Rank:        1,
-				TotalScore:  10111,
-				RosterScore: 10111,
+				TeamID:     m1.ExternalID,
+				Rank:       1,
+				TotalScore: 10111,
+				Scores:     map[string]int32{"Roster": 10111},
 				Roster: []model.PowerRankingPlayer{
 					{
 						PlayerID:           p1.ID,
@@ -584,6 +612,7 @@ func TestPowerRankings(t *testing.T) {
 						NFLTeam:            model.TEAM_ARI,
 						PowerRankingPoints: 1000,
 						IsStarter:          true,
+						RosterSpot:         "WR",
 					},
 					{
 						PlayerID:           p2.ID,
@@ -595,10 +624,10 @@ func TestPowerRankings(t *testing.T) {
 				},
 			},
 			{
-				TeamID:      m2.ExternalID,
-				Rank:        2,
-				TotalScore:  10022,
-				RosterScore: 10022,
+				TeamID:     m2.ExternalID,
+				Rank:       2,
+				TotalScore: 10022,
+				Scores:     map[string]int32{"Roster": 10022},
 				Roster: []model.PowerRankingPlayer{
 					{
 						PlayerID:           p3.ID,
@@ -631,6 +660,15 @@ func TestPowerRankings(t *testing.T) {
 	if len(res.Teams) != 2 {
 		t.Errorf("unexpected number of teams, wanted 2 got %d", len(res.Teams))
 	}
+	if !reflect.DeepEqual(pr1.Config, res.Config) {
+		t.Errorf("unexpected power ranking config, wanted %v got %v", pr1.Config, res.Config)
+	}
+	if !reflect.DeepEqual(pr1.Components, res.Components) {
+		t.Errorf("unexpected power ranking components, wanted %v got %v", pr1.Components, res.Components)
+	}
+	if !reflect.DeepEqual(pr1.Teams[1].Scores, res.Teams[1].Scores) {
+		t.Errorf("unexpected scores for team 1, wanted %v got %v", pr1.Teams[1].Scores, res.Teams[1].Scores)
+	}
 	if res.Teams[0].Rank != 1 {
 		t.Errorf("Team 0 should have rank 1, not %d", res.Teams[0].Rank)
 	}
@@ -640,6 +678,9 @@ func TestPowerRankings(t *testing.T) {
 	if res.Teams[0].Roster[0].PlayerID != p1.ID {
 		t.Errorf("Unexpected player at top of roster for team 0 - wanted %s, got %s", p1.ID, res.Teams[0].Roster[0].PlayerID)
 	}
+	if res.Teams[0].Roster[0].RosterSpot != "WR" || res.Teams[0].Roster[1].RosterSpot != "" {
+		t.Errorf("Unexpected roster spots for team 0: %v", res.Teams[0].Roster)
+	}
 	if res.Teams[1].Rank != 2 {
 		t.Errorf("Team 1 should have rank 2, not %d", res.Teams[1].Rank)
 	}
@@ -652,14 +693,16 @@ func TestPowerRankings(t *testing.T) {
 
 	// Add a second power rankings and then list them.
 	pr2 := &model.PowerRanking{
-		RankingID: ranking.ID,
-		Week:      1,
+		RankingID:  ranking.ID,
+		Week:       1,
+		Components: []string{"Roster"},
+		CompareID:  id,
 		Teams: []model.TeamPowerRanking{
 			{
-				TeamID:      m2.ExternalID,
-				Rank:        1,
-				TotalScore:  11000,
-				RosterScore: 11000,
+				TeamID:     m2.ExternalID,
+				Rank:       1,
+				TotalScore: 11000,
+				Scores:     map[string]int32{"Roster": 11000},
 				Roster: []model.PowerRankingPlayer{
 					{
 						PlayerID:           p3.ID,
@@ -678,10 +721,10 @@ func TestPowerRankings(t *testing.T) {
 				},
 			},
 			{
-				TeamID:      m1.ExternalID,
-				Rank:        2,
-				TotalScore:  10111,
-				RosterScore: 10111,
+				TeamID:     m1.ExternalID,
+				Rank:       2,
+				TotalScore: 10111,
+				Scores:     map[string]int32{"Roster": 10111},
 				Roster: []model.PowerRankingPlayer{
 					{
 						PlayerID:           p1.ID,
@@ -719,6 +762,114 @@ func TestPowerRankings(t *testing.T) {
 	if rankings[1].Week != 0 {
 		t.Errorf("expected second power rankings to have week=1, got: %d", rankings[1].Week)
 	}
+
+	// Replace the second power ranking with a different week and only one team
+	pr2.Week = 2
+	pr2.Config.WinPoints = 12
+	pr2.Teams = pr2.Teams[:1]
+	if err := testDB.ReplacePowerRanking(ctx, l.ID, pr2); err != nil {
+		t.Fatalf("error replacing power ranking 2: %v", err)
+	}
+	res, err = testDB.GetPowerRanking(ctx, l.ID, pr2.ID)
+	if err != nil {
+		t.Fatalf("error looking up power ranking 2: %v", err)
+	}
+	if res.Week != 2 || res.Config.WinPoints != 12 || res.CompareID != id || len(res.Teams) != 1 || len(res.Teams[0].Roster) != 2 {
+		t.Errorf("unexpected power ranking after replacing it: %v", res)
+	}
+	if !res.Created.Equal(rankings[0].Created) {
+		t.Errorf("expected replacing the power ranking to keep when it was created, got: %v", res.Created)
+	}
+
+	if err := testDB.ReplacePowerRanking(ctx, l.ID+1000, pr2); err == nil {
+		t.Errorf("expected an error replacing a power ranking in a different league")
+	}
+
+	// Delete the first power ranking
+	if err := testDB.DeletePowerRanking(ctx, l.ID, id); err != nil {
+		t.Fatalf("error deleting power ranking 1: %v", err)
+	}
+	if _, err := testDB.GetPowerRanking(ctx, l.ID, id); err == nil {
+		t.Errorf("expected an error looking up a deleted power ranking")
+	}
+	if err := testDB.DeletePowerRanking(ctx, l.ID, id); err == nil {
+		t.Errorf("expected an error deleting a power ranking twice")
+	}
+	rankings, err = testDB.ListPowerRankings(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error listing power rankings: %v", err)
+	}
+	if len(rankings) != 1 || rankings[0].ID != pr2.ID {
+		t.Errorf("expected only power ranking 2 to be left, got: %v", rankings)
+	}
+	// Power ranking 2 was compared to the deleted one
+	res, err = testDB.GetPowerRanking(ctx, l.ID, pr2.ID)
+	if err != nil {
+		t.Fatalf("error looking up power ranking 2: %v", err)
+	}
+	if res.CompareID != 0 {
+		t.Errorf("expected power ranking 2 to no longer be compared to power ranking 1, got: %d", res.CompareID)
+	}
+}
+
+func TestPowerRankings_tiedTeams(t *testing.T) {
+	ctx := context.Background()
+	l := getLeague()
+	if err := testDB.AddLeague(ctx, l); err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		testDB.ArchiveLeague(ctx, l.ID) // Clean up after the test
+	}()
+
+	m1 := getLeagueManager()
+	m2 := getLeagueManager()
+	m3 := getLeagueManager()
+	for _, m := range []*model.LeagueManager{m1, m2, m3} {
+		if err := testDB.SaveLeagueManager(ctx, l.ID, m); err != nil {
+			t.Fatalf("error adding manager to league: %v", err)
+		}
+	}
+
+	p := getPlayer()
+	if err := testDB.SavePlayer(ctx, p); err != nil {
+		t.Fatalf("error adding player: %v", err)
+	}
+	rankingDate, _ := time.Parse(time.DateOnly, "2022-10-12")
+	ranking, err := testDB.AddRanking(ctx, rankingDate, model.RankingSourceFantasyPros, model.SCORING_UNKNOWN, map[string]int32{p.ID: 1}, nil)
+	if err != nil {
+		t.Fatalf("error adding ranking: %v", err)
+	}
+
+	// The tied teams are in tie-break order, which isn't the order of their ids
+	pr := &model.PowerRanking{
+		RankingID: ranking.ID,
+		Teams: []model.TeamPowerRanking{
+			{TeamID: m3.ExternalID, Rank: 1, TotalScore: 100},
+			{TeamID: m2.ExternalID, Rank: 1, TotalScore: 100},
+			{TeamID: m1.ExternalID, Rank: 3, TotalScore: 50},
+		},
+	}
+	id, err := testDB.SavePowerRanking(ctx, l.ID, pr)
+	if err != nil {
+		t.Fatalf("error saving power ranking: %v", err)
+	}
+
+	// Read it more than once, the order should always be the same
+	for range 3 {
+		res, err := testDB.GetPowerRanking(ctx, l.ID, id)
+		if err != nil {
+			t.Fatalf("error getting power ranking: %v", err)
+		}
+		order := make([]string, 0, len(res.Teams))
+		for _, team := range res.Teams {
+			order = append(order, team.TeamID)
+		}
+		expected := []string{m3.ExternalID, m2.ExternalID, m1.ExternalID}
+		if !reflect.DeepEqual(expected, order) {
+			t.Fatalf("expected teams in order %v, got: %v", expected, order)
+		}
+	}
 }
 
 func TestPowerRankings_leagueWithNoRankings(t *testing.T) {
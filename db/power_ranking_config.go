@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// Get the power ranking config for the league, if the league hasn't saved one
+// then the default config is returned.
+func (db *postgresDB) GetPowerRankingConfig(ctx context.Context, leagueID int32) (*model.PowerRankingConfig, error) {
+	const query = `SELECT bench_percent, points_against_percent, win_points, streak_points, points_weeks, allplay_points, schedule_points,
+				tie_breaks
+			FROM power_ranking_configs WHERE league_id=@leagueID`
+
+	var c model.PowerRankingConfig
+	var tieBreaks string
+	err := db.pool.QueryRow(ctx, query, pgx.NamedArgs{"leagueID": leagueID}).Scan(
+		&c.BenchPercent, &c.PointsAgainstPercent, &c.WinPoints, &c.StreakPoints, &c.PointsWeeks, &c.AllPlayPoints, &c.SchedulePoints,
+		&tieBreaks)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c = model.DefaultPowerRankingConfig()
+	} else if err != nil {
+		return nil, fmt.Errorf("error querying power ranking config for league %d: %w", leagueID, err)
+	} else {
+		c.TieBreaks = splitTieBreaks(tieBreaks)
+	}
+	return &c, nil
+}
+
+func (db *postgresDB) SavePowerRankingConfig(ctx context.Context, leagueID int32, c *model.PowerRankingConfig) error {
+	const query = `INSERT INTO power_ranking_configs
+				(league_id, bench_percent, points_against_percent, win_points, streak_points, points_weeks, allplay_points, schedule_points,
+				tie_breaks)
+			VALUES (@leagueID, @benchPercent, @pointsAgainstPercent, @winPoints, @streakPoints, @pointsWeeks, @allPlayPoints, @schedulePoints,
+				@tieBreaks)
+			ON CONFLICT (league_id) DO UPDATE SET
+				bench_percent=EXCLUDED.bench_percent,
+				points_against_percent=EXCLUDED.points_against_percent,
+				win_points=EXCLUDED.win_points,
+				streak_points=EXCLUDED.streak_points,
+				points_weeks=EXCLUDED.points_weeks,
+				allplay_points=EXCLUDED.allplay_points,
+				schedule_points=EXCLUDED.schedule_points,
+				tie_breaks=EXCLUDED.tie_breaks`
+
+	args := pgx.NamedArgs{
+		"leagueID":             leagueID,
+		"benchPercent":         c.BenchPercent,
+		"pointsAgainstPercent": c.PointsAgainstPercent,
+		"winPoints":            c.WinPoints,
+		"streakPoints":         c.StreakPoints,
+		"pointsWeeks":          c.PointsWeeks,
+		"allPlayPoints":        c.AllPlayPoints,
+		"schedulePoints":       c.SchedulePoints,
+		"tieBreaks":            strings.Join(c.TieBreaks, ","),
+	}
+	if _, err := db.pool.Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("error saving power ranking config for league %d: %w", leagueID, err)
+	}
+	return nil
+}
+
+// The tie-breaks are saved as a comma separated list.
+func splitTieBreaks(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestPowerRankingConfig(t *testing.T) {
+	ctx := context.Background()
+	l := getLeague()
+	if err := testDB.AddLeague(ctx, l); err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		testDB.ArchiveLeague(ctx, l.ID)
+	}()
+
+	// Leagues without a config use the default
+	c, err := testDB.GetPowerRankingConfig(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting power ranking config: %v", err)
+	}
+	if !reflect.DeepEqual(model.DefaultPowerRankingConfig(), *c) {
+		t.Errorf("expected the default config, got: %v", c)
+	}
+
+	c.BenchPercent = 25
+	c.PointsWeeks = 4
+	c.TieBreaks = []string{model.TieBreakRecord, model.TieBreakPointsFor}
+	if err := testDB.SavePowerRankingConfig(ctx, l.ID, c); err != nil {
+		t.Fatalf("error saving power ranking config: %v", err)
+	}
+	// Saving again should update the existing config
+	c.WinPoints = 12
+	if err := testDB.SavePowerRankingConfig(ctx, l.ID, c); err != nil {
+		t.Fatalf("error saving power ranking config a second time: %v", err)
+	}
+
+	res, err := testDB.GetPowerRankingConfig(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting power ranking config: %v", err)
+	}
+	expected := &model.PowerRankingConfig{
+		BenchPercent:         25,
+		PointsAgainstPercent: 30,
+		WinPoints:            12,
+		StreakPoints:         5,
+		PointsWeeks:          4,
+		TieBreaks:            []string{model.TieBreakRecord, model.TieBreakPointsFor},
+	}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("expected: %v, got: %v", expected, res)
+	}
+
+	// Teams can be sorted by just their id
+	res.TieBreaks = []string{}
+	if err := testDB.SavePowerRankingConfig(ctx, l.ID, res); err != nil {
+		t.Fatalf("error saving power ranking config without tie-breaks: %v", err)
+	}
+	res, err = testDB.GetPowerRankingConfig(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error getting power ranking config: %v", err)
+	}
+	if len(res.TieBreaks) != 0 {
+		t.Errorf("expected no tie-breaks, got: %v", res.TieBreaks)
+	}
+}
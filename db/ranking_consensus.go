@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func (db *postgresDB) AddConsensusRanking(ctx context.Context, date time.Time, scoring model.ScoringFormat, rankings map[string]int32, sources []model.ConsensusSource) (*model.Ranking, error) {
+	const insertSourceQuery = `INSERT INTO ranking_consensus_sources(ranking_id, source_ranking_id, weight, missing_rank)
+			VALUES (@rankingID, @sourceRankingID, @weight, @missingRank)`
+	const insertPlayerQuery = `INSERT INTO ranking_consensus_players(ranking_id, source_ranking_id, player_id, ranking)
+			VALUES (@rankingID, @sourceRankingID, @playerID, @ranking)`
+
+	if len(sources) == 0 {
+		return nil, errors.New("consensus rankings must have at least one source")
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	r, err := insertRanking(ctx, tx, date, model.RankingSourceConsensus, scoring, rankings)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sources {
+		args := pgx.NamedArgs{
+			"rankingID":       r.ID,
+			"sourceRankingID": s.RankingID,
+			"weight":          s.Weight,
+			"missingRank":     s.MissingRank,
+		}
+		if _, err := tx.Exec(ctx, insertSourceQuery, args); err != nil {
+			return nil, fmt.Errorf("error inserting consensus source %d: %w", s.RankingID, err)
+		}
+
+		for playerID, rank := range s.Ranks {
+			args := pgx.NamedArgs{
+				"rankingID":       r.ID,
+				"sourceRankingID": s.RankingID,
+				"playerID":        playerID,
+				"ranking":         rank,
+			}
+			if _, err := tx.Exec(ctx, insertPlayerQuery, args); err != nil {
+				return nil, fmt.Errorf("error inserting rank of player %s from source %d: %w", playerID, s.RankingID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error commiting add consensus ranking transaction: %w", err)
+	}
+
+	return r, nil
+}
+
+func (db *postgresDB) GetConsensusSources(ctx context.Context, rankingID int32) ([]model.ConsensusSource, error) {
+	const sourcesQuery = `SELECT s.source_ranking_id, s.weight, s.missing_rank, r.ranking_date, r.source, r.scoring_format
+			FROM ranking_consensus_sources s INNER JOIN rankings r ON s.source_ranking_id=r.id
+			WHERE s.ranking_id=@rankingID
+			ORDER BY s.weight DESC, s.source_ranking_id ASC`
+	const playersQuery = `SELECT source_ranking_id, player_id, ranking
+			FROM ranking_consensus_players WHERE ranking_id=@rankingID`
+
+	args := pgx.NamedArgs{"rankingID": rankingID}
+	rows, err := db.pool.Query(ctx, sourcesQuery, args)
+	if err != nil {
+		return nil, fmt.Errorf("error querying consensus sources for ranking %d: %w", rankingID, err)
+	}
+
+	sources := make([]model.ConsensusSource, 0, 4)
+	index := make(map[int32]int)
+	for rows.Next() {
+		var s model.ConsensusSource
+		var date pgtype.Timestamptz
+		var scoring string
+		if err := rows.Scan(&s.RankingID, &s.Weight, &s.MissingRank, &date, &s.Source, &scoring); err != nil {
+			return nil, fmt.Errorf("error reading consensus source: %w", err)
+		}
+		s.Date = date.Time.UTC()
+		s.Scoring = model.ParseScoringFormat(scoring)
+		s.Ranks = make(map[string]int32)
+		index[s.RankingID] = len(sources)
+		sources = append(sources, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading consensus sources: %w", err)
+	}
+
+	rows, err = db.pool.Query(ctx, playersQuery, args)
+	if err != nil {
+		return nil, fmt.Errorf("error querying consensus player ranks for ranking %d: %w", rankingID, err)
+	}
+	for rows.Next() {
+		var sourceID, rank int32
+		var playerID string
+		if err := rows.Scan(&sourceID, &playerID, &rank); err != nil {
+			return nil, fmt.Errorf("error reading consensus player rank: %w", err)
+		}
+		if i, found := index[sourceID]; found {
+			sources[i].Ranks[playerID] = rank
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading consensus player ranks: %w", err)
+	}
+
+	return sources, nil
+}
+
+// Remove the sources of a consensus ranking, if the ranking is one.
+func deleteConsensusSources(ctx context.Context, tx pgx.Tx, rankingID int32) error {
+	const deletePlayersQuery = "DELETE FROM ranking_consensus_players WHERE ranking_id=@rankingID"
+	const deleteSourcesQuery = "DELETE FROM ranking_consensus_sources WHERE ranking_id=@rankingID"
+
+	args := pgx.NamedArgs{"rankingID": rankingID}
+	if _, err := tx.Exec(ctx, deletePlayersQuery, args); err != nil {
+		return fmt.Errorf("error deleting from ranking_consensus_players: %w", err)
+	}
+	if _, err := tx.Exec(ctx, deleteSourcesQuery, args); err != nil {
+		return fmt.Errorf("error deleting from ranking_consensus_sources: %w", err)
+	}
+	return nil
+}
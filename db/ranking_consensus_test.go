@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestConsensusRanking(t *testing.T) {
+	p1 := getPlayerWithName("Bijan", "Robinson")
+	p2 := getPlayerWithName("Jahmyr", "Gibbs")
+	p3 := getPlayerWithName("De'Von", "Achane")
+
+	ctx := context.Background()
+	if err := errors.Join(testDB.SavePlayer(ctx, p1), testDB.SavePlayer(ctx, p2), testDB.SavePlayer(ctx, p3)); err != nil {
+		t.Fatalf("error inserting players: %v", err)
+	}
+
+	date, _ := time.ParseInLocation(time.DateOnly, "2024-08-20", time.UTC)
+	ranks1 := map[string]int32{p1.ID: 1, p2.ID: 2, p3.ID: 3}
+	source1, err := testDB.AddRanking(ctx, date, model.RankingSourceFantasyPros, model.SCORING_PPR, ranks1, nil)
+	if err != nil {
+		t.Fatalf("error adding first source: %v", err)
+	}
+	defer testDB.DeleteRanking(ctx, source1.ID)
+	ranks2 := map[string]int32{p2.ID: 1, p1.ID: 2}
+	source2, err := testDB.AddRanking(ctx, date, model.RankingSourceSleeper, model.SCORING_HALF, ranks2, nil)
+	if err != nil {
+		t.Fatalf("error adding second source: %v", err)
+	}
+	defer testDB.DeleteRanking(ctx, source2.ID)
+
+	sources := []model.ConsensusSource{
+		{RankingID: source2.ID, Weight: 0.5, MissingRank: 10, Ranks: ranks2},
+		{RankingID: source1.ID, Weight: 2, MissingRank: 4, Ranks: ranks1},
+	}
+	consensus, err := testDB.AddConsensusRanking(ctx, date, model.SCORING_PPR, map[string]int32{p1.ID: 1, p2.ID: 2, p3.ID: 3}, sources)
+	if err != nil {
+		t.Fatalf("error adding consensus ranking: %v", err)
+	}
+
+	r, err := testDB.GetRanking(ctx, consensus.ID)
+	if err != nil {
+		t.Fatalf("error getting consensus ranking: %v", err)
+	}
+	assertEquals(t, "r.Source", model.RankingSourceConsensus, r.Source)
+	assertEquals(t, "len(r.Players)", 3, len(r.Players))
+
+	// The highest weight is first, with the metadata of the source rankings
+	results, err := testDB.GetConsensusSources(ctx, consensus.ID)
+	if err != nil {
+		t.Fatalf("error getting consensus sources: %v", err)
+	}
+	expected := []model.ConsensusSource{
+		{RankingID: source1.ID, Weight: 2, MissingRank: 4, Date: date, Source: model.RankingSourceFantasyPros, Scoring: model.SCORING_PPR, Ranks: ranks1},
+		{RankingID: source2.ID, Weight: 0.5, MissingRank: 10, Date: date, Source: model.RankingSourceSleeper, Scoring: model.SCORING_HALF, Ranks: ranks2},
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("expected: %v, got: %v", expected, results)
+	}
+
+	// Other rankings don't have sources
+	results, err = testDB.GetConsensusSources(ctx, source1.ID)
+	if err != nil {
+		t.Fatalf("error getting consensus sources: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no sources, got: %v", results)
+	}
+
+	// The sources can't be deleted until the consensus ranking is
+	err = testDB.DeleteRanking(ctx, source1.ID)
+	if err == nil || !strings.Contains(err.Error(), "is used by a consensus ranking") {
+		t.Errorf("expected an error deleting a source ranking, got: %v", err)
+	}
+	if err := testDB.DeleteRanking(ctx, consensus.ID); err != nil {
+		t.Fatalf("error deleting consensus ranking: %v", err)
+	}
+	if _, err := testDB.AddConsensusRanking(ctx, date, model.SCORING_PPR, map[string]int32{p1.ID: 1}, nil); err == nil {
+		t.Error("expected an error adding a consensus ranking without sources")
+	}
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func insertUnmatchedRankingPlayers(ctx context.Context, tx pgx.Tx, rankingID int32, unmatched []model.UnmatchedRankingPlayer) error {
+	const query = `INSERT INTO ranking_unmatched(ranking_id, ranking, name, team, position, player_id)
+			VALUES (@rankingID, @ranking, @name, @team, @position, @playerID)`
+
+	for _, u := range unmatched {
+		team := ""
+		if u.Team != nil {
+			team = u.Team.String()
+		}
+		args := pgx.NamedArgs{
+			"rankingID": rankingID,
+			"ranking":   u.Rank,
+			"name":      u.Name,
+			"team":      team,
+			"position":  string(u.Position),
+			"playerID":  u.PlayerID,
+		}
+		if _, err := tx.Exec(ctx, query, args); err != nil {
+			return fmt.Errorf("error inserting unmatched player %s: %w", u.Name, err)
+		}
+	}
+	return nil
+}
+
+func (db *postgresDB) ListUnmatchedRankingPlayers(ctx context.Context, rankingID int32) ([]model.UnmatchedRankingPlayer, error) {
+	const query = `SELECT id, ranking_id, ranking, name, team, position, player_id
+			FROM ranking_unmatched WHERE ranking_id=@rankingID
+			ORDER BY ranking ASC, id ASC`
+
+	rows, err := db.pool.Query(ctx, query, pgx.NamedArgs{"rankingID": rankingID})
+	if err != nil {
+		return nil, fmt.Errorf("error querying unmatched players for ranking %d: %w", rankingID, err)
+	}
+
+	results := make([]model.UnmatchedRankingPlayer, 0, 16)
+	for rows.Next() {
+		var u model.UnmatchedRankingPlayer
+		var team, pos string
+		if err := rows.Scan(&u.ID, &u.RankingID, &u.Rank, &u.Name, &team, &pos, &u.PlayerID); err != nil {
+			return nil, fmt.Errorf("error reading unmatched player: %w", err)
+		}
+		if team != "" {
+			u.Team = model.ParseTeam(team)
+		}
+		u.Position = model.ParsePosition(pos)
+		results = append(results, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading unmatched players: %w", err)
+	}
+	return results, nil
+}
+
+func (db *postgresDB) ResolveUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32, playerID string) error {
+	const deleteQuery = `DELETE FROM ranking_unmatched WHERE id=@id AND ranking_id=@rankingID RETURNING ranking`
+	const insertQuery = `INSERT INTO player_rankings(ranking_id, player_id, ranking) VALUES (@rankingID, @playerID, @ranking)`
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"id":        unmatchedID,
+		"rankingID": rankingID,
+		"playerID":  playerID,
+	}
+	var rank int32
+	if err := tx.QueryRow(ctx, deleteQuery, args).Scan(&rank); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("unmatched player %d not found for ranking %d", unmatchedID, rankingID)
+		}
+		return fmt.Errorf("error deleting unmatched player %d: %w", unmatchedID, err)
+	}
+
+	args["ranking"] = rank
+	if _, err := tx.Exec(ctx, insertQuery, args); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.ConstraintName == "player_rankings_player_id_fkey" {
+				return fmt.Errorf("no player with id: %s", playerID)
+			}
+			if pgErr.ConstraintName == "player_rankings_pkey" {
+				return fmt.Errorf("player %s is already in ranking %d", playerID, rankingID)
+			}
+		}
+		return fmt.Errorf("error inserting player ranking: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing resolve unmatched player transaction: %w", err)
+	}
+	return nil
+}
+
+func (db *postgresDB) DeleteUnmatchedRankingPlayer(ctx context.Context, rankingID, unmatchedID int32) error {
+	const query = `DELETE FROM ranking_unmatched WHERE id=@id AND ranking_id=@rankingID`
+
+	tag, err := db.pool.Exec(ctx, query, pgx.NamedArgs{"id": unmatchedID, "rankingID": rankingID})
+	if err != nil {
+		return fmt.Errorf("error deleting unmatched player %d: %w", unmatchedID, err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("unmatched player %d not found for ranking %d", unmatchedID, rankingID)
+	}
+	return nil
+}
+
+func (db *postgresDB) GetPlayerAlias(ctx context.Context, name string, pos model.Position) (string, error) {
+	const query = `SELECT player_id FROM player_aliases WHERE name=@name AND position=@position`
+
+	var id string
+	err := db.pool.QueryRow(ctx, query, pgx.NamedArgs{"name": name, "position": string(pos)}).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrPlayerNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("error querying alias %s: %w", name, err)
+	}
+	return id, nil
+}
+
+func (db *postgresDB) SavePlayerAlias(ctx context.Context, name string, pos model.Position, playerID string) error {
+	const query = `INSERT INTO player_aliases(name, position, player_id) VALUES (@name, @position, @playerID)
+			ON CONFLICT (name, position) DO UPDATE SET player_id=EXCLUDED.player_id`
+
+	args := pgx.NamedArgs{
+		"name":     name,
+		"position": string(pos),
+		"playerID": playerID,
+	}
+	if _, err := db.pool.Exec(ctx, query, args); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "player_aliases_player_id_fkey" {
+			return fmt.Errorf("no player with id: %s", playerID)
+		}
+		return fmt.Errorf("error saving alias %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestUnmatchedRankingPlayers(t *testing.T) {
+	p1 := getPlayerWithName("Puka", "Nacua")
+	p2 := getPlayerWithName("Jaxon", "Smith-Njigba")
+
+	ctx := context.Background()
+	if err := errors.Join(testDB.SavePlayer(ctx, p1), testDB.SavePlayer(ctx, p2)); err != nil {
+		t.Fatalf("error inserting players: %v", err)
+	}
+
+	unmatched := []model.UnmatchedRankingPlayer{
+		{Rank: 3, Name: "Nobody Special", Position: model.POS_RB, PlayerID: "99999"},
+		{Rank: 2, Name: "JSN", Team: model.TEAM_SEA, Position: model.POS_WR},
+	}
+	date, _ := time.ParseInLocation(time.DateOnly, "2023-10-11", time.UTC)
+	ranking, err := testDB.AddRanking(ctx, date, model.RankingSourceCSV, model.SCORING_PPR, map[string]int32{p1.ID: 1}, unmatched)
+	if err != nil {
+		t.Fatalf("error adding ranking: %v", err)
+	}
+	defer testDB.DeleteRanking(ctx, ranking.ID)
+
+	results, err := testDB.ListUnmatchedRankingPlayers(ctx, ranking.ID)
+	if err != nil {
+		t.Fatalf("error listing unmatched players: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 unmatched players, got: %v", results)
+	}
+	expected := []model.UnmatchedRankingPlayer{
+		{ID: results[0].ID, RankingID: ranking.ID, Rank: 2, Name: "JSN", Team: model.TEAM_SEA, Position: model.POS_WR},
+		{ID: results[1].ID, RankingID: ranking.ID, Rank: 3, Name: "Nobody Special", Position: model.POS_RB, PlayerID: "99999"},
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("expected: %v, got: %v", expected, results)
+	}
+
+	r, err := testDB.GetRanking(ctx, ranking.ID)
+	if err != nil {
+		t.Fatalf("error getting ranking: %v", err)
+	}
+	assertEquals(t, "r.Unmatched", 2, r.Unmatched)
+
+	if err := testDB.ResolveUnmatchedRankingPlayer(ctx, ranking.ID, results[0].ID, p1.ID); err == nil {
+		t.Error("expected an error resolving to a player already in the ranking")
+	}
+	if err := testDB.ResolveUnmatchedRankingPlayer(ctx, ranking.ID, results[0].ID, p2.ID); err != nil {
+		t.Fatalf("error resolving unmatched player: %v", err)
+	}
+	if err := testDB.ResolveUnmatchedRankingPlayer(ctx, ranking.ID, results[0].ID, p2.ID); err == nil {
+		t.Error("expected an error resolving an unmatched player twice")
+	}
+	if err := testDB.DeleteUnmatchedRankingPlayer(ctx, ranking.ID+1, results[1].ID); err == nil {
+		t.Error("expected an error deleting an unmatched player from the wrong ranking")
+	}
+	if err := testDB.DeleteUnmatchedRankingPlayer(ctx, ranking.ID, results[1].ID); err != nil {
+		t.Fatalf("error deleting unmatched player: %v", err)
+	}
+
+	r, err = testDB.GetRanking(ctx, ranking.ID)
+	if err != nil {
+		t.Fatalf("error getting ranking: %v", err)
+	}
+	assertEquals(t, "r.Unmatched", 0, r.Unmatched)
+	if r.Players[p2.ID].Rank != 2 {
+		t.Errorf("expected the resolved player to be ranked 2nd, got: %v", r.Players)
+	}
+}
+
+func TestPlayerAliases(t *testing.T) {
+	p1 := getPlayerWithName("Kenneth", "Walker")
+	p2 := getPlayerWithName("Kenneth", "Gainwell")
+
+	ctx := context.Background()
+	if err := errors.Join(testDB.SavePlayer(ctx, p1), testDB.SavePlayer(ctx, p2)); err != nil {
+		t.Fatalf("error inserting players: %v", err)
+	}
+
+	if _, err := testDB.GetPlayerAlias(ctx, "ken walker", model.POS_RB); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("expected player not found, got: %v", err)
+	}
+
+	if err := testDB.SavePlayerAlias(ctx, "ken walker", model.POS_RB, p2.ID); err != nil {
+		t.Fatalf("error saving alias: %v", err)
+	}
+	// Saving the alias again replaces it
+	if err := testDB.SavePlayerAlias(ctx, "ken walker", model.POS_RB, p1.ID); err != nil {
+		t.Fatalf("error replacing alias: %v", err)
+	}
+	if err := testDB.SavePlayerAlias(ctx, "ken walker", model.POS_WR, "no-such-player"); err == nil {
+		t.Error("expected an error saving an alias for a player that doesn't exist")
+	}
+
+	id, err := testDB.GetPlayerAlias(ctx, "ken walker", model.POS_RB)
+	if err != nil {
+		t.Fatalf("error getting alias: %v", err)
+	}
+	assertEquals(t, "alias", p1.ID, id)
+
+	if _, err := testDB.GetPlayerAlias(ctx, "ken walker", model.POS_WR); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("expected the alias to be for a single position, got: %v", err)
+	}
+}
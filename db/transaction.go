@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// Transactions are saved by their platform id, so saving the same transaction
+// again replaces it instead of creating a duplicate. The ID field of each
+// transaction is set to the id in the DB.
+func (db *postgresDB) SaveTransactions(ctx context.Context, leagueID int32, transactions []model.Transaction) error {
+	const insertTransaction = `INSERT INTO transactions (league_id, external_id, type, week, waiver_bid, processed)
+			VALUES (@leagueID, @externalID, @type, @week, @waiverBid, @processed)
+			ON CONFLICT (league_id, external_id) DO UPDATE SET
+				type=EXCLUDED.type, week=EXCLUDED.week, waiver_bid=EXCLUDED.waiver_bid, processed=EXCLUDED.processed
+			RETURNING id`
+	const deleteAssets = `DELETE FROM transaction_assets WHERE transaction_id=@id`
+	const insertAsset = `INSERT INTO transaction_assets (
+				transaction_id, idx, asset_type, from_team, to_team, player_id,
+				pick_season, pick_round, pick_team, faab
+			) VALUES (
+				@id, @idx, @assetType, @fromTeam, @toTeam, @playerID,
+				@pickSeason, @pickRound, @pickTeam, @faab
+			)`
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for i := range transactions {
+		t := &transactions[i]
+		args := pgx.NamedArgs{
+			"leagueID":   leagueID,
+			"externalID": t.ExternalID,
+			"type":       t.Type,
+			"week":       t.Week,
+			"waiverBid":  t.WaiverBid,
+			"processed": pgtype.Timestamptz{
+				Time:             t.Processed.UTC(),
+				InfinityModifier: pgtype.Finite,
+				Valid:            true,
+			},
+		}
+		if err := tx.QueryRow(ctx, insertTransaction, args).Scan(&t.ID); err != nil {
+			return fmt.Errorf("error saving transaction %s: %w", t.ExternalID, err)
+		}
+
+		if _, err := tx.Exec(ctx, deleteAssets, pgx.NamedArgs{"id": t.ID}); err != nil {
+			return fmt.Errorf("error removing old assets for transaction %s: %w", t.ExternalID, err)
+		}
+
+		for idx, a := range t.Assets {
+			args := pgx.NamedArgs{
+				"id":         t.ID,
+				"idx":        idx,
+				"assetType":  a.Type,
+				"fromTeam":   nullString(a.FromTeamID),
+				"toTeam":     nullString(a.ToTeamID),
+				"playerID":   nullString(a.PlayerID),
+				"pickSeason": nullString(a.PickSeason),
+				"pickRound":  sql.NullInt16{Int16: int16(a.PickRound), Valid: a.Type == model.AssetDraftPick},
+				"pickTeam":   nullString(a.PickTeamID),
+				"faab":       sql.NullInt32{Int32: a.FAAB, Valid: a.Type == model.AssetFAAB},
+			}
+			if _, err := tx.Exec(ctx, insertAsset, args); err != nil {
+				return fmt.Errorf("error saving asset for transaction %s: %w", t.ExternalID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting transactions: %w", err)
+	}
+	return nil
+}
+
+func (db *postgresDB) GetTransactions(ctx context.Context, leagueID int32, week int) ([]model.Transaction, error) {
+	return db.queryTransactions(ctx, "league_id=@leagueID AND week=@week", pgx.NamedArgs{"leagueID": leagueID, "week": week})
+}
+
+func (db *postgresDB) ListTransactions(ctx context.Context, leagueID int32) ([]model.Transaction, error) {
+	return db.queryTransactions(ctx, "league_id=@leagueID", pgx.NamedArgs{"leagueID": leagueID})
+}
+
+// Load the transactions matching the where clause along with all of their assets.
+// Transactions are returned in the order they were processed.
+func (db *postgresDB) queryTransactions(ctx context.Context, where string, args pgx.NamedArgs) ([]model.Transaction, error) {
+	const query = `SELECT id, external_id, type, week, waiver_bid, processed FROM transactions
+			WHERE %s ORDER BY processed, id`
+	const assetQuery = `SELECT
+				a.transaction_id, a.asset_type, a.from_team, a.to_team, a.player_id,
+				p.name_first, p.name_last, a.pick_season, a.pick_round, a.pick_team, a.faab,
+				fm.team_name, fm.manager_name, tm.team_name, tm.manager_name, pm.team_name, pm.manager_name
+			FROM transaction_assets AS a
+				INNER JOIN transactions AS t ON (a.transaction_id=t.id)
+				LEFT JOIN players AS p ON (a.player_id=p.id)
+				LEFT JOIN league_managers AS fm ON (fm.league_id=t.league_id AND fm.external_id=a.from_team)
+				LEFT JOIN league_managers AS tm ON (tm.league_id=t.league_id AND tm.external_id=a.to_team)
+				LEFT JOIN league_managers AS pm ON (pm.league_id=t.league_id AND pm.external_id=a.pick_team)
+			WHERE a.transaction_id = ANY(@ids)
+			ORDER BY a.transaction_id, a.idx`
+
+	rows, err := db.pool.Query(ctx, fmt.Sprintf(query, where), args)
+	if err != nil {
+		return nil, fmt.Errorf("error querying transactions: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]model.Transaction, 0)
+	ids := make([]int32, 0)
+	for rows.Next() {
+		var t model.Transaction
+		var waiverBid sql.NullInt32
+		var processed pgtype.Timestamptz
+		if err := rows.Scan(&t.ID, &t.ExternalID, &t.Type, &t.Week, &waiverBid, &processed); err != nil {
+			return nil, fmt.Errorf("error scanning transaction: %w", err)
+		}
+		t.WaiverBid = waiverBid.Int32
+		t.Processed = processed.Time.UTC()
+		results = append(results, t)
+		ids = append(ids, t.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading transactions: %w", err)
+	}
+
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	byID := make(map[int32]*model.Transaction, len(results))
+	for i := range results {
+		byID[results[i].ID] = &results[i]
+	}
+
+	assetRows, err := db.pool.Query(ctx, assetQuery, pgx.NamedArgs{"ids": ids})
+	if err != nil {
+		return nil, fmt.Errorf("error querying transaction assets: %w", err)
+	}
+	defer assetRows.Close()
+	for assetRows.Next() {
+		var id int32
+		var a model.TransactionAsset
+		var fromTeam, toTeam, playerID, nameFirst, nameLast, pickSeason, pickTeam sql.NullString
+		var fromName, fromManager, toName, toManager, pickName, pickManager sql.NullString
+		var pickRound sql.NullInt16
+		var faab sql.NullInt32
+		err := assetRows.Scan(&id, &a.Type, &fromTeam, &toTeam, &playerID,
+			&nameFirst, &nameLast, &pickSeason, &pickRound, &pickTeam, &faab,
+			&fromName, &fromManager, &toName, &toManager, &pickName, &pickManager)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning transaction asset: %w", err)
+		}
+
+		a.FromTeamID = valueOrEmpty(fromTeam)
+		a.ToTeamID = valueOrEmpty(toTeam)
+		a.PlayerID = valueOrEmpty(playerID)
+		a.PickSeason = valueOrEmpty(pickSeason)
+		a.PickRound = int(pickRound.Int16)
+		a.PickTeamID = valueOrEmpty(pickTeam)
+		a.FAAB = faab.Int32
+		a.FromTeamName = first(valueOrEmpty(fromName), valueOrEmpty(fromManager))
+		a.ToTeamName = first(valueOrEmpty(toName), valueOrEmpty(toManager))
+		a.PickTeamName = first(valueOrEmpty(pickName), valueOrEmpty(pickManager))
+		a.PlayerName = strings.TrimSpace(fmt.Sprintf("%s %s", valueOrEmpty(nameFirst), valueOrEmpty(nameLast)))
+
+		if t, found := byID[id]; found {
+			t.Assets = append(t.Assets, a)
+		}
+	}
+	if err := assetRows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading transaction assets: %w", err)
+	}
+
+	return results, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestTransactions(t *testing.T) {
+	ctx := context.Background()
+	l := getLeague()
+
+	if err := testDB.AddLeague(ctx, l); err != nil {
+		t.Fatalf("error adding league: %v", err)
+	}
+	defer func() {
+		testDB.ArchiveLeague(ctx, l.ID)
+	}()
+
+	m1 := getLeagueManager()
+	m2 := getLeagueManager()
+	for _, m := range []*model.LeagueManager{m1, m2} {
+		if err := testDB.SaveLeagueManager(ctx, l.ID, m); err != nil {
+			t.Fatalf("error adding manager to league: %v", err)
+		}
+	}
+
+	players := make([]*model.Player, 4)
+	for i, name := range [][]string{{"Breece", "Hall"}, {"Nick", "Chubb"}, {"Travis", "Kelce"}, {"Tyler", "Lockett"}} {
+		players[i] = getPlayer()
+		players[i].FirstName = name[0]
+		players[i].LastName = name[1]
+		players[i].YahooID = ""
+		if err := testDB.SavePlayer(ctx, players[i]); err != nil {
+			t.Fatalf("error saving player: %v", err)
+		}
+	}
+	hall, chubb, kelce, lockett := players[0].ID, players[1].ID, players[2].ID, players[3].ID
+
+	processed := time.Date(2024, time.September, 11, 8, 0, 0, 0, time.UTC)
+	transactions := []model.Transaction{
+		{
+			ExternalID: "tr-2",
+			Type:       model.TransactionTrade,
+			Week:       2,
+			Processed:  processed.Add(24 * time.Hour),
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, FromTeamID: m1.ExternalID, ToTeamID: m2.ExternalID, PlayerID: kelce},
+				{Type: model.AssetDraftPick, FromTeamID: m2.ExternalID, ToTeamID: m1.ExternalID, PickSeason: "2025", PickRound: 1, PickTeamID: m2.ExternalID},
+				{Type: model.AssetFAAB, FromTeamID: m2.ExternalID, ToTeamID: m1.ExternalID, FAAB: 15},
+			},
+		},
+		{
+			ExternalID: "tr-1",
+			Type:       model.TransactionWaiver,
+			Week:       2,
+			Processed:  processed,
+			WaiverBid:  21,
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, ToTeamID: m1.ExternalID, PlayerID: hall},
+				{Type: model.AssetPlayer, FromTeamID: m1.ExternalID, PlayerID: chubb},
+			},
+		},
+		{
+			ExternalID: "tr-3",
+			Type:       model.TransactionAdd,
+			Week:       3,
+			Processed:  processed.Add(7 * 24 * time.Hour),
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, ToTeamID: m2.ExternalID, PlayerID: lockett},
+			},
+		},
+	}
+
+	if err := testDB.SaveTransactions(ctx, l.ID, transactions); err != nil {
+		t.Fatalf("error saving transactions: %v", err)
+	}
+	for _, tr := range transactions {
+		if tr.ID == 0 {
+			t.Errorf("transaction %s id was not set", tr.ExternalID)
+		}
+	}
+
+	// Saving again should replace the existing transactions instead of adding new ones
+	if err := testDB.SaveTransactions(ctx, l.ID, transactions); err != nil {
+		t.Fatalf("error saving transactions a second time: %v", err)
+	}
+
+	week2, err := testDB.GetTransactions(ctx, l.ID, 2)
+	if err != nil {
+		t.Fatalf("error getting transactions: %v", err)
+	}
+
+	expected := []model.Transaction{
+		{
+			ID:         transactions[1].ID,
+			ExternalID: "tr-1",
+			Type:       model.TransactionWaiver,
+			Week:       2,
+			Processed:  processed,
+			WaiverBid:  21,
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, ToTeamID: m1.ExternalID, ToTeamName: m1.TeamName, PlayerID: hall, PlayerName: "Breece Hall"},
+				{Type: model.AssetPlayer, FromTeamID: m1.ExternalID, FromTeamName: m1.TeamName, PlayerID: chubb, PlayerName: "Nick Chubb"},
+			},
+		},
+		{
+			ID:         transactions[0].ID,
+			ExternalID: "tr-2",
+			Type:       model.TransactionTrade,
+			Week:       2,
+			Processed:  processed.Add(24 * time.Hour),
+			Assets: []model.TransactionAsset{
+				{
+					Type:         model.AssetPlayer,
+					FromTeamID:   m1.ExternalID,
+					FromTeamName: m1.TeamName,
+					ToTeamID:     m2.ExternalID,
+					ToTeamName:   m2.TeamName,
+					PlayerID:     kelce,
+					PlayerName:   "Travis Kelce",
+				},
+				{
+					Type:         model.AssetDraftPick,
+					FromTeamID:   m2.ExternalID,
+					FromTeamName: m2.TeamName,
+					ToTeamID:     m1.ExternalID,
+					ToTeamName:   m1.TeamName,
+					PickSeason:   "2025",
+					PickRound:    1,
+					PickTeamID:   m2.ExternalID,
+					PickTeamName: m2.TeamName,
+				},
+				{
+					Type:         model.AssetFAAB,
+					FromTeamID:   m2.ExternalID,
+					FromTeamName: m2.TeamName,
+					ToTeamID:     m1.ExternalID,
+					ToTeamName:   m1.TeamName,
+					FAAB:         15,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, week2) {
+		t.Errorf("expected: %v, got: %v", expected, week2)
+	}
+
+	all, err := testDB.ListTransactions(ctx, l.ID)
+	if err != nil {
+		t.Fatalf("error listing transactions: %v", err)
+	}
+	if len(all) != 3 || all[2].ExternalID != "tr-3" {
+		t.Errorf("expected 3 transactions with tr-3 last, got: %v", all)
+	}
+
+	none, err := testDB.GetTransactions(ctx, l.ID, 10)
+	if err != nil {
+		t.Fatalf("error getting transactions for a week without any: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("expected no transactions for week 10, got: %v", none)
+	}
+}
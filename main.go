@@ -15,6 +15,9 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/mww/fantasy_manager_v2/controller"
 	"github.com/mww/fantasy_manager_v2/db"
+	"github.com/mww/fantasy_manager_v2/platforms/espn"
+	"github.com/mww/fantasy_manager_v2/platforms/fleaflicker"
+	"github.com/mww/fantasy_manager_v2/platforms/mfl"
 	"github.com/mww/fantasy_manager_v2/platforms/sleeper"
 	"github.com/mww/fantasy_manager_v2/platforms/yahoo"
 	"github.com/mww/fantasy_manager_v2/web"
@@ -47,6 +50,13 @@ func main() {
 	yahooClientSecret := os.Getenv("YAHOO_CLIENT_SECRET")
 	oauthRedirectURL := os.Getenv("OAUTH_REDIRECT_URL")
 
+	// The ESPN cookies are only needed for private leagues
+	espnS2 := os.Getenv("ESPN_S2")
+	espnSWID := os.Getenv("ESPN_SWID")
+
+	// The MFL api key is needed to list a user's leagues and for private leagues
+	mflAPIKey := os.Getenv("MFL_API_KEY")
+
 	clock := clock.New()
 	db, err := db.New(context.Background(), connString, clock)
 	if err != nil {
@@ -63,6 +73,21 @@ func main() {
 		log.Fatalf("error creating yahoo client: %v", err)
 	}
 
+	espnClient, err := espn.New(espnS2, espnSWID)
+	if err != nil {
+		log.Fatalf("error creating espn client: %v", err)
+	}
+
+	mflClient, err := mfl.New(mflAPIKey)
+	if err != nil {
+		log.Fatalf("error creating mfl client: %v", err)
+	}
+
+	fleaflickerClient, err := fleaflicker.New()
+	if err != nil {
+		log.Fatalf("error creating fleaflicker client: %v", err)
+	}
+
 	var yahooConfig *oauth2.Config
 
 	if yahooClientID != "" && yahooClientSecret != "" && oauthRedirectURL != "" {
@@ -77,7 +102,7 @@ func main() {
 		}
 	}
 
-	ctrl, err := controller.New(clock, db, sleeperClient, yahooClient, yahooConfig)
+	ctrl, err := controller.New(clock, db, sleeperClient, yahooClient, yahooConfig, espnClient, mflClient, fleaflickerClient)
 	if err != nil {
 		log.Fatalf("error creating a new controller: %v", err)
 	}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+type DraftType string
+
+const (
+	DraftSnake   DraftType = "snake"
+	DraftLinear  DraftType = "linear"
+	DraftAuction DraftType = "auction"
+)
+
+type Draft struct {
+	ID         int32
+	ExternalID string // The id assigned by the platform
+	Type       DraftType
+	Rounds     int
+	Started    time.Time
+	Picks      []DraftPick
+}
+
+type DraftPick struct {
+	Round    int
+	Pick     int // The overall pick number, starting at 1
+	TeamID   string
+	PlayerID string
+	IsKeeper bool
+	Cost     int32 // The auction price, 0 for non-auction drafts
+
+	// These are not persisted, they are filled in when loading a draft.
+	TeamName  string
+	FirstName string
+	LastName  string
+	Position  Position
+}
+
+// DraftGrade is how well a team drafted compared to a ranking of players.
+type DraftGrade struct {
+	TeamID   string
+	TeamName string
+	Grade    string
+	Score    int32 // The sum of the Surplus of all of the graded picks
+	Picks    []GradedPick
+}
+
+type GradedPick struct {
+	DraftPick
+	Slot      int   // Where the pick was made, for auctions this is the order of the cost
+	Rank      int32 // The player's rank at the time of the draft
+	SlotValue int32 // The value of a player ranked at the pick slot
+	Value     int32 // The value of the player that was picked
+	Surplus   int32 // Value - SlotValue, positive numbers are good picks
+}
@@ -0,0 +1,26 @@
+package model
+
+// Every team starts with this rating in their first season.
+const EloInitialRating = 1500
+
+// EloRating is a team's Elo rating after a week. Week 0 is the rating the team
+// started the season with.
+type EloRating struct {
+	TeamID string
+	Week   int
+	Rating float64
+}
+
+// EloHistory has the Elo ratings of every team in a league over a season.
+type EloHistory struct {
+	Weeks []int     // The weeks with ratings, week 0 is the start of the season
+	Teams []TeamElo // Sorted by the most recent rating, highest first
+}
+
+type TeamElo struct {
+	TeamID   string
+	TeamName string
+	Rating   float64   // The most recent rating
+	Change   float64   // How much the rating changed in the most recent week
+	History  []float64 // The rating after each of the weeks in EloHistory.Weeks
+}
@@ -0,0 +1,36 @@
+package model
+
+// Franchise chains the seasons of a league together. Some platforms, like
+// Sleeper, create a new league every season.
+type Franchise struct {
+	ID      int32
+	Name    string
+	Leagues []League // Sorted with the oldest season first
+}
+
+type FranchiseHistory struct {
+	Franchise Franchise
+	Seasons   []FranchiseSeason  // Sorted with the most recent season first
+	Managers  []FranchiseManager // Sorted by the number of seasons played, then wins
+}
+
+type FranchiseSeason struct {
+	LeagueID     int32
+	Year         string
+	Name         string
+	ChampionID   string // The team id of the champion, "" if there isn't one yet
+	ChampionName string
+	Standings    []LeagueStanding
+}
+
+// FranchiseManager is the all-time record of a manager across every season
+// of the franchise that they played in.
+type FranchiseManager struct {
+	ManagerName   string
+	TeamNames     []string // Every team name used, most recent first
+	Seasons       []string // Most recent first
+	Wins          int
+	Losses        int
+	Draws         int
+	Championships int
+}
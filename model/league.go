@@ -1,7 +1,17 @@
 package model
 
+import (
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+)
+
 var PlatformSleeper = "sleeper"
 var PlatformYahoo = "yahoo"
+var PlatformESPN = "espn"
+var PlatformMFL = "mfl"
+var PlatformFleaflicker = "fleaflicker"
 
 type League struct {
 	ID         int32
@@ -23,6 +33,37 @@ type LeagueStanding struct {
 	Scored   string
 }
 
+// Sort the standings and fill in the Rank of each team.
+func SortStandings(s []LeagueStanding) {
+	// Sort in order:
+	// - Highest number of wins
+	// - Smaller number of losses
+	// - Fantasy points scored
+	slices.SortFunc(s, func(a, b LeagueStanding) int {
+		if a.Wins == b.Wins {
+			if a.Losses == b.Losses {
+				fa, e1 := strconv.ParseFloat(a.Scored, 64)
+				fb, e2 := strconv.ParseFloat(b.Scored, 64)
+				if err := errors.Join(e1, e2); err != nil {
+					log.Printf("error parsing points scored: %v", err)
+					return 0
+				}
+				if fa > fb {
+					return -1
+				} else if fb > fa {
+					return 1
+				}
+				return 0
+			}
+			return a.Losses - b.Losses
+		}
+		return b.Wins - a.Wins
+	})
+	for i := range s {
+		s[i].Rank = i + 1
+	}
+}
+
 type LeagueManager struct {
 	ExternalID  string
 	TeamName    string
@@ -35,7 +76,11 @@ type TeamResult struct {
 	TeamID   string
 	TeamName string
 	Score    int32
-	JoinKey  string // Not persisted
+	// The team's record for the week if they had played every other team
+	AllPlayWins   int
+	AllPlayLosses int
+	AllPlayDraws  int
+	JoinKey       string // Not persisted
 }
 
 type Matchup struct {
@@ -44,3 +89,67 @@ type Matchup struct {
 	MatchupID int32
 	Week      int
 }
+
+// Set the all-play record of every team in the week's matchups, by comparing
+// each team's score to the score of every other team that week.
+func SetAllPlayRecords(matchups []Matchup) {
+	teams := make([]*TeamResult, 0, len(matchups)*2)
+	for _, m := range matchups {
+		for _, t := range []*TeamResult{m.TeamA, m.TeamB} {
+			if t != nil {
+				teams = append(teams, t)
+			}
+		}
+	}
+
+	for _, t := range teams {
+		t.AllPlayWins, t.AllPlayLosses, t.AllPlayDraws = 0, 0, 0
+		for _, o := range teams {
+			if o == t {
+				continue
+			}
+			if t.Score > o.Score {
+				t.AllPlayWins++
+			} else if t.Score < o.Score {
+				t.AllPlayLosses++
+			} else {
+				t.AllPlayDraws++
+			}
+		}
+	}
+}
+
+// AllPlayStanding compares a team's actual record with their all-play record
+// over the season.
+type AllPlayStanding struct {
+	TeamID        string
+	TeamName      string
+	Wins          int
+	Losses        int
+	Draws         int
+	AllPlayWins   int
+	AllPlayLosses int
+	AllPlayDraws  int
+	// The wins a team would expect based on their all-play record. Each week
+	// adds the team's all-play winning percentage for that week.
+	ExpectedWins float64
+	// Actual wins minus expected wins, a positive value means the team has been lucky.
+	Luck float64
+}
+
+// ScheduleStrength rates how hard a team's schedule has been, and how hard the
+// rest of it will be, using how their opponents have done so far this season.
+type ScheduleStrength struct {
+	TeamID   string
+	TeamName string
+
+	PastGames          int
+	PastOpponentWinPct float64 // The average winning percentage of the opponents already played
+	PastOpponentPoints float64 // The average points per game of the opponents already played
+	PastRank           int     // 1 is the hardest schedule
+
+	RemainingGames          int
+	RemainingOpponentWinPct float64
+	RemainingOpponentPoints float64
+	RemainingRank           int // 1 is the hardest schedule, 0 if there are no remaining games
+}
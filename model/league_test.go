@@ -0,0 +1,52 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortStandings(t *testing.T) {
+	input := []LeagueStanding{
+		{TeamID: "a", Wins: 5, Losses: 8, Scored: "1200.00"},
+		{TeamID: "b", Wins: 8, Losses: 5, Scored: "1300.50"},
+		{TeamID: "c", Wins: 8, Losses: 4, Draws: 1, Scored: "1100.00"},
+		{TeamID: "d", Wins: 8, Losses: 5, Scored: "1400.25"},
+	}
+	expected := []LeagueStanding{
+		{TeamID: "c", Rank: 1, Wins: 8, Losses: 4, Draws: 1, Scored: "1100.00"},
+		{TeamID: "d", Rank: 2, Wins: 8, Losses: 5, Scored: "1400.25"},
+		{TeamID: "b", Rank: 3, Wins: 8, Losses: 5, Scored: "1300.50"},
+		{TeamID: "a", Rank: 4, Wins: 5, Losses: 8, Scored: "1200.00"},
+	}
+
+	SortStandings(input)
+	if !reflect.DeepEqual(expected, input) {
+		t.Errorf("expected: %v, got: %v", expected, input)
+	}
+}
+
+func TestSetAllPlayRecords(t *testing.T) {
+	a := &TeamResult{TeamID: "a", Score: 100000}
+	b := &TeamResult{TeamID: "b", Score: 120000}
+	c := &TeamResult{TeamID: "c", Score: 90000}
+	d := &TeamResult{TeamID: "d", Score: 100000, AllPlayWins: 5}
+	matchups := []Matchup{
+		{TeamA: a, TeamB: b},
+		{TeamA: c, TeamB: d},
+	}
+
+	SetAllPlayRecords(matchups)
+
+	tests := map[*TeamResult][3]int{
+		a: {1, 1, 1},
+		b: {3, 0, 0},
+		c: {0, 3, 0},
+		d: {1, 1, 1}, // The existing record is replaced
+	}
+	for tr, expected := range tests {
+		got := [3]int{tr.AllPlayWins, tr.AllPlayLosses, tr.AllPlayDraws}
+		if got != expected {
+			t.Errorf("team %s expected all-play record %v, got: %v", tr.TeamID, expected, got)
+		}
+	}
+}
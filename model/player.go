@@ -13,6 +13,9 @@ const (
 type Player struct {
 	ID              string
 	YahooID         string
+	ESPNID          string
+	MFLID           string
+	FleaflickerID   string
 	FirstName       string
 	LastName        string
 	Nickname1       string
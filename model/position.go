@@ -14,6 +14,10 @@ const (
 	POS_TE      Position = "TE"
 	POS_DEF     Position = "DEF"
 	POS_K       Position = "K"
+	// Individual defensive players
+	POS_DL Position = "DL"
+	POS_LB Position = "LB"
+	POS_DB Position = "DB"
 )
 
 func ParsePosition(pos string) Position {
@@ -33,6 +37,12 @@ func ParsePosition(pos string) Position {
 		return POS_DEF
 	case "k":
 		return POS_K
+	case "dl", "de", "dt":
+		return POS_DL
+	case "lb":
+		return POS_LB
+	case "db", "cb", "s":
+		return POS_DB
 	default:
 		return POS_UNKNOWN
 	}
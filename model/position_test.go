@@ -20,6 +20,13 @@ func TestParsePosition(t *testing.T) {
 		{input: "FB", expected: POS_RB},
 		{input: "def", expected: POS_DEF},
 		{input: "k", expected: POS_K},
+		{input: "DL", expected: POS_DL},
+		{input: "DE", expected: POS_DL},
+		{input: "dt", expected: POS_DL},
+		{input: "LB", expected: POS_LB},
+		{input: "DB", expected: POS_DB},
+		{input: "CB", expected: POS_DB},
+		{input: "S", expected: POS_DB},
 	}
 
 	for _, tc := range tests {
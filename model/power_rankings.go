@@ -1,30 +1,141 @@
 package model
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type PowerRanking struct {
-	ID        int32
-	RankingID int32 // The ID of the ranking data to use
-	Week      int16 // week used to calculate win/loss and streaks
-	Teams     []TeamPowerRanking
-	Created   time.Time
+	ID         int32
+	RankingID  int32              // The ID of the ranking data to use
+	Week       int16              // week used to calculate win/loss and streaks
+	Config     PowerRankingConfig // The config the power ranking was calculated with
+	Components []string           // The names of the components in each team's score, in calculation order
+	Teams      []TeamPowerRanking
+	CompareID  int32 // The power ranking the rank changes were calculated from, 0 if there wasn't one
+	Created    time.Time
+}
+
+// DisplayRank formats a rank for showing to users. Teams with the same score share
+// a rank, which is shown with a "T-" prefix, like "T-3".
+func (pr *PowerRanking) DisplayRank(rank int) string {
+	count := 0
+	for _, t := range pr.Teams {
+		if t.Rank == rank {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Sprintf("T-%d", rank)
+	}
+	return strconv.Itoa(rank)
+}
+
+// PowerRankingHistory is every team's rank from the power rankings of each week of
+// a season. When a week has more than one power ranking the newest one is used.
+type PowerRankingHistory struct {
+	Weeks           []int
+	PowerRankingIDs []int32 // The power ranking used for each week
+	Teams           []TeamRankHistory
+}
+
+type TeamRankHistory struct {
+	TeamID   string
+	TeamName string
+	Ranks    []int // The rank for each week, 0 if the team wasn't in that week's power ranking
+	// The total value of the team's roster for each week, the same as the starter and
+	// bench values of the power ranking explanation. 0 if the team wasn't ranked.
+	RosterValues []int32
+}
+
+// PowerRankingExplanation breaks down how each team's score in a saved power
+// ranking was calculated, and how it changed from the previous power ranking.
+type PowerRankingExplanation struct {
+	PowerRanking *PowerRanking
+	PreviousID   int32 // The power ranking the changes are from, 0 if there wasn't one
+	PreviousWeek int16
+	Teams        []TeamExplanation // In the same order as the power ranking
+}
+
+type TeamExplanation struct {
+	TeamID      string
+	TeamName    string
+	Rank        int
+	RankChange  int
+	TotalScore  int32
+	TotalChange int32
+	Components  []ComponentExplanation // In calculation order
+
+	Starters     []PowerRankingPlayer // Sorted by rank, like the roster
+	Bench        []PowerRankingPlayer
+	StarterValue int32 // The sum of the starters' power ranking points
+	BenchValue   int32 // The sum of the bench players' power ranking points, after the bench percent
+
+	Wins   int // The record through the power ranking's week
+	Losses int
+	Draws  int
+	Streak int // Positive for a winning streak, negative for a losing streak
+
+	AveragePointsFor     float64 // Averaged over the config's PointsWeeks, in points
+	AveragePointsAgainst float64
+}
+
+type ComponentExplanation struct {
+	Name        string
+	Score       int32
+	Previous    int32
+	Change      int32
+	HasPrevious bool // False if the team or component wasn't in the previous power ranking
+}
+
+// PowerRankingConfig holds the weights used when calculating a power ranking.
+// Each league can have its own config.
+type PowerRankingConfig struct {
+	BenchPercent         int // The percent of a bench player's value that counts toward the roster score
+	PointsAgainstPercent int // The percent of the average points against that is added to the score
+	WinPoints            int // Points for each win, each loss subtracts the same amount
+	StreakPoints         int // Points for each game in the current win or losing streak
+	PointsWeeks          int // The number of recent weeks used to average points for and against
+	AllPlayPoints        int // Points for each expected win over .500 from the all-play record, 0 to not use it
+	SchedulePoints       int // Points for each game over .500 by the opponents already played, 0 to not use it
+	// The order of the tie-breaks used to sort teams with the same score. Teams that
+	// are still tied after all of them are sorted by team id.
+	TieBreaks []string
+}
+
+// The tie-breaks that can be used in a PowerRankingConfig.
+const (
+	TieBreakPointsFor  = "points_for"   // Most points scored through the week
+	TieBreakRecord     = "record"       // Best win percentage through the week
+	TieBreakHeadToHead = "head_to_head" // Best record in games against the other tied teams
+	TieBreakRoster     = "roster"       // Highest roster score
+)
+
+func DefaultTieBreaks() []string {
+	return []string{TieBreakPointsFor, TieBreakRecord, TieBreakHeadToHead, TieBreakRoster}
+}
+
+func DefaultPowerRankingConfig() PowerRankingConfig {
+	return PowerRankingConfig{
+		BenchPercent:         40,
+		PointsAgainstPercent: 30,
+		WinPoints:            10,
+		StreakPoints:         5,
+		PointsWeeks:          3,
+		TieBreaks:            DefaultTieBreaks(),
+	}
 }
 
 type TeamPowerRanking struct {
-	TeamID             string
-	TeamName           string
-	Rank               int
-	RankChange         int
-	TotalScore         int32
-	RosterScore        int32
-	RecordScore        int32
-	StreakScore        int32
-	PointsForScore     int32
-	PointsAgainstScore int32
-	Roster             []PowerRankingPlayer
+	TeamID     string
+	TeamName   string
+	Rank       int // Teams with the same total score share a rank
+	RankChange int
+	TotalScore int32            // The sum of all the component scores
+	Scores     map[string]int32 // The score from each component, keyed by the component name
+	Roster     []PowerRankingPlayer
 }
 
 type PowerRankingPlayer struct {
@@ -36,6 +147,7 @@ type PowerRankingPlayer struct {
 	NFLTeam            *NFLTeam
 	PowerRankingPoints int32
 	IsStarter          bool
+	RosterSpot         string // The roster spot the player was picked to start in, empty for the bench
 }
 
 func FromRankingPlayer(p *RankingPlayer) PowerRankingPlayer {
@@ -61,9 +173,42 @@ type YahooPlayer struct {
 	Pos       Position
 }
 
+type ESPNPlayer struct {
+	ESPNID    string
+	FirstName string
+	LastName  string
+	Pos       Position
+}
+
+type MFLPlayer struct {
+	MFLID     string
+	FirstName string
+	LastName  string
+	Pos       Position
+}
+
+type FleaflickerPlayer struct {
+	FleaflickerID string
+	FirstName     string
+	LastName      string
+	Pos           Position
+}
+
+// GetRosterSpot converts the name of a roster spot into the positions allowed in
+// it. It understands the flex spots used by both Sleeper and Yahoo, any other name
+// is treated as a single position.
 func GetRosterSpot(pos string) RosterSpot {
-	if strings.ToUpper(pos) == "FLEX" {
+	switch strings.ToUpper(pos) {
+	case "FLEX", "W/R/T":
 		return RosterSpot{Allowed: []Position{POS_RB, POS_WR, POS_TE}}
+	case "SUPER_FLEX", "Q/W/R/T":
+		return RosterSpot{Allowed: []Position{POS_QB, POS_RB, POS_WR, POS_TE}}
+	case "REC_FLEX", "W/T":
+		return RosterSpot{Allowed: []Position{POS_WR, POS_TE}}
+	case "WRRB_FLEX", "W/R":
+		return RosterSpot{Allowed: []Position{POS_RB, POS_WR}}
+	case "IDP_FLEX", "D":
+		return RosterSpot{Allowed: []Position{POS_DL, POS_LB, POS_DB}}
 	}
 	return RosterSpot{Allowed: []Position{ParsePosition(pos)}}
 }
@@ -72,6 +217,15 @@ type RosterSpot struct {
 	Allowed []Position
 }
 
+// The name of the roster spot, the positions allowed in it separated by a slash.
+func (rs *RosterSpot) String() string {
+	names := make([]string, 0, len(rs.Allowed))
+	for _, a := range rs.Allowed {
+		names = append(names, string(a))
+	}
+	return strings.Join(names, "/")
+}
+
 func (rs *RosterSpot) IsAllowed(pos Position) bool {
 	for _, a := range rs.Allowed {
 		if a == pos {
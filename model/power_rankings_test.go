@@ -0,0 +1,50 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetRosterSpot(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Position
+	}{
+		{input: "QB", expected: []Position{POS_QB}},
+		{input: "LB", expected: []Position{POS_LB}},
+		{input: "FLEX", expected: []Position{POS_RB, POS_WR, POS_TE}},
+		{input: "W/R/T", expected: []Position{POS_RB, POS_WR, POS_TE}},
+		{input: "SUPER_FLEX", expected: []Position{POS_QB, POS_RB, POS_WR, POS_TE}},
+		{input: "Q/W/R/T", expected: []Position{POS_QB, POS_RB, POS_WR, POS_TE}},
+		{input: "REC_FLEX", expected: []Position{POS_WR, POS_TE}},
+		{input: "W/T", expected: []Position{POS_WR, POS_TE}},
+		{input: "WRRB_FLEX", expected: []Position{POS_RB, POS_WR}},
+		{input: "W/R", expected: []Position{POS_RB, POS_WR}},
+		{input: "IDP_FLEX", expected: []Position{POS_DL, POS_LB, POS_DB}},
+		{input: "D", expected: []Position{POS_DL, POS_LB, POS_DB}},
+		{input: "BN", expected: []Position{POS_UNKNOWN}},
+	}
+
+	for _, tc := range tests {
+		spot := GetRosterSpot(tc.input)
+		if !reflect.DeepEqual(tc.expected, spot.Allowed) {
+			t.Errorf("input: '%s', expected: %v, got: %v", tc.input, tc.expected, spot.Allowed)
+		}
+	}
+}
+
+func TestRosterSpotString(t *testing.T) {
+	spot := GetRosterSpot("SUPER_FLEX")
+	if s := spot.String(); s != "QB/RB/WR/TE" {
+		t.Errorf("expected QB/RB/WR/TE, got: %s", s)
+	}
+}
+
+func TestDisplayRank(t *testing.T) {
+	pr := &PowerRanking{Teams: []TeamPowerRanking{{Rank: 1}, {Rank: 2}, {Rank: 2}, {Rank: 4}}}
+	for rank, expected := range map[int]string{1: "1", 2: "T-2", 4: "4"} {
+		if s := pr.DisplayRank(rank); s != expected {
+			t.Errorf("expected rank %d to be shown as %s, got: %s", rank, expected, s)
+		}
+	}
+}
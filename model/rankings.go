@@ -1,14 +1,94 @@
 package model
 
 import (
+	"strings"
 	"time"
 )
 
+// Where a ranking was imported from.
+const (
+	RankingSourceFantasyPros = "fantasypros"
+	RankingSourceCSV         = "csv"
+	RankingSourceJSON        = "json"
+	RankingSourceSleeper     = "sleeper"
+	RankingSourceYahoo       = "yahoo"
+	// Blended from other rankings, see ConsensusOptions.
+	RankingSourceConsensus = "consensus"
+)
+
+// The sources rankings can be imported from, in the order the formats are detected.
+var RankingSources = []string{
+	RankingSourceFantasyPros,
+	RankingSourceSleeper,
+	RankingSourceYahoo,
+	RankingSourceJSON,
+	RankingSourceCSV,
+}
+
+// The scoring format a ranking is for.
+type ScoringFormat string
+
+const (
+	SCORING_UNKNOWN   ScoringFormat = ""
+	SCORING_PPR       ScoringFormat = "ppr"
+	SCORING_HALF      ScoringFormat = "half"
+	SCORING_STANDARD  ScoringFormat = "standard"
+	SCORING_SUPERFLEX ScoringFormat = "superflex"
+	SCORING_DYNASTY   ScoringFormat = "dynasty"
+)
+
+var ScoringFormats = []ScoringFormat{
+	SCORING_PPR,
+	SCORING_HALF,
+	SCORING_STANDARD,
+	SCORING_SUPERFLEX,
+	SCORING_DYNASTY,
+}
+
+func ParseScoringFormat(s string) ScoringFormat {
+	switch strings.ToLower(s) {
+	case "ppr", "full", "full_ppr":
+		return SCORING_PPR
+	case "half", "half_ppr", "0.5":
+		return SCORING_HALF
+	case "standard", "std", "non_ppr":
+		return SCORING_STANDARD
+	case "superflex", "sf", "2qb":
+		return SCORING_SUPERFLEX
+	case "dynasty":
+		return SCORING_DYNASTY
+	default:
+		return SCORING_UNKNOWN
+	}
+}
+
+// The name of the scoring format to show to users.
+func (s ScoringFormat) Friendly() string {
+	switch s {
+	case SCORING_PPR:
+		return "PPR"
+	case SCORING_HALF:
+		return "Half PPR"
+	case SCORING_STANDARD:
+		return "Standard"
+	case SCORING_SUPERFLEX:
+		return "Superflex"
+	case SCORING_DYNASTY:
+		return "Dynasty"
+	default:
+		return "Unknown"
+	}
+}
+
 type Ranking struct {
-	ID   int32
-	Date time.Time
+	ID      int32
+	Date    time.Time
+	Source  string
+	Scoring ScoringFormat
 	// Map of players indexed by player id
 	Players map[string]RankingPlayer
+	// The number of rows that still need to be matched to a player. Only set by GetRanking.
+	Unmatched int
 }
 
 type RankingPlayer struct {
@@ -19,3 +99,108 @@ type RankingPlayer struct {
 	Position  Position
 	Team      *NFLTeam
 }
+
+// A row in an imported ranking that couldn't be matched to a single player.
+type UnmatchedRankingPlayer struct {
+	ID        int32
+	RankingID int32
+	Rank      int32
+	Name      string
+	Team      *NFLTeam // nil if the rankings didn't have a team
+	Position  Position
+	PlayerID  string // The id of the player in the rankings file, empty if it didn't have one
+	// The players it might be, best match first
+	Candidates []RankingCandidate
+}
+
+// A player that might be the player for an unmatched row.
+type RankingCandidate struct {
+	Player Player
+	// How similar the player's name, team and position are to the row, from 0 to 100.
+	Score int
+}
+
+// How to read an uploaded rankings file.
+type RankingImportOptions struct {
+	// One of the RankingSources, if empty the source is detected from the file.
+	Source  string
+	Scoring ScoringFormat
+	// The columns to read from a generic CSV file.
+	Columns RankingColumns
+}
+
+// The header names of the columns in a generic CSV file. Any that are empty use
+// the common names for the column, e.g. "Rank" or "RK" for the rank.
+type RankingColumns struct {
+	Rank     string
+	Name     string
+	Team     string
+	Position string
+}
+
+// The changes between two rankings of the same players. From and To only have
+// the ranking metadata, not the players.
+type RankingComparison struct {
+	From     Ranking
+	To       Ranking
+	Position Position        // POS_UNKNOWN if every position was compared
+	Risers   []RankingChange // The players that moved up, biggest rise first
+	Fallers  []RankingChange // The players that moved down, biggest fall first
+	New      []RankingChange // The players only in To, by rank
+	Dropped  []RankingChange // The players only in From, by their rank in From
+}
+
+// How a player's rank changed between two rankings.
+type RankingChange struct {
+	Player RankingPlayer
+	From   int32 // 0 if the player is new
+	To     int32 // 0 if the player was dropped
+	Change int32 // How many spots the player moved up, negative if they moved down
+}
+
+// A player's rank in one of the rankings.
+type PlayerRankingPoint struct {
+	RankingID int32
+	Date      time.Time
+	Source    string
+	Scoring   ScoringFormat
+	Rank      int32
+}
+
+// How to blend several rankings into a consensus ranking. Each player's consensus rank
+// comes from the weighted average of their rank in each of the sources.
+type ConsensusOptions struct {
+	Date    time.Time
+	Scoring ScoringFormat
+	Sources []ConsensusSource
+}
+
+// One of the rankings a consensus ranking is blended from.
+type ConsensusSource struct {
+	RankingID int32
+	Weight    float64
+	// The rank used for players that aren't in the ranking. If it is 0 when blending,
+	// one more than the worst rank in the ranking is used.
+	MissingRank int32
+	// The metadata of the ranking, and the rank it gave each player by player id.
+	Date    time.Time
+	Source  string
+	Scoring ScoringFormat
+	Ranks   map[string]int32
+}
+
+// A consensus ranking with how its sources ranked every player.
+type ConsensusRanking struct {
+	Ranking *Ranking
+	Sources []ConsensusSource
+	Players []ConsensusPlayer // Sorted by consensus rank
+}
+
+type ConsensusPlayer struct {
+	Player RankingPlayer
+	Ranks  []int32 // The rank from each source in the same order as Sources, 0 if the player was missing
+	// The weighted average and standard deviation of the ranks from the sources, with the
+	// missing rank used when the player wasn't in a source.
+	Average float64
+	StdDev  float64
+}
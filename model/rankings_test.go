@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestParseScoringFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ScoringFormat
+	}{
+		{input: "ppr", expected: SCORING_PPR},
+		{input: "PPR", expected: SCORING_PPR},
+		{input: "half", expected: SCORING_HALF},
+		{input: "half_ppr", expected: SCORING_HALF},
+		{input: "standard", expected: SCORING_STANDARD},
+		{input: "STD", expected: SCORING_STANDARD},
+		{input: "superflex", expected: SCORING_SUPERFLEX},
+		{input: "2qb", expected: SCORING_SUPERFLEX},
+		{input: "dynasty", expected: SCORING_DYNASTY},
+		{input: "", expected: SCORING_UNKNOWN},
+		{input: "other", expected: SCORING_UNKNOWN},
+	}
+
+	for _, tc := range tests {
+		a := ParseScoringFormat(tc.input)
+		if a != tc.expected {
+			t.Errorf("input: '%s', expected: '%s', got '%s'", tc.input, tc.expected, a)
+		}
+	}
+}
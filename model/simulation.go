@@ -0,0 +1,45 @@
+package model
+
+// SimulationConfig controls how the rest of a season is simulated.
+type SimulationConfig struct {
+	Simulations  int    // The number of seasons to simulate
+	PlayoffTeams int    // The number of teams that make the playoffs
+	Byes         int    // The number of top seeds that get a first round bye
+	Seed         uint64 // Seeds the random numbers, the same seed always gives the same results
+}
+
+func DefaultSimulationConfig() SimulationConfig {
+	return SimulationConfig{
+		Simulations:  1000,
+		PlayoffTeams: 6,
+		Byes:         2,
+	}
+}
+
+// SeasonSimulation is the result of simulating the rest of the regular season
+// many times.
+type SeasonSimulation struct {
+	Config         SimulationConfig
+	Week           int // The last week with results, the simulation starts after it
+	RemainingWeeks int
+	Teams          []TeamSimulation // Sorted by the chance of making the playoffs
+}
+
+type TeamSimulation struct {
+	TeamID   string
+	TeamName string
+	// The team's current record and the distribution their weekly scores are
+	// drawn from, in points.
+	Wins         int
+	Losses       int
+	Draws        int
+	AverageScore float64
+	StdDev       float64
+
+	ExpectedWins float64 // The average number of wins at the end of the season
+	PlayoffPct   float64 // The percent of simulations where the team made the playoffs
+	ByePct       float64
+	// SeedPct[i] is the percent of simulations where the team finished as seed i+1.
+	// There is an entry for every team in the league.
+	SeedPct []float64
+}
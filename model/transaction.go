@@ -0,0 +1,142 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TransactionType string
+
+const (
+	TransactionAdd    TransactionType = "add"    // Free agent pickup, possibly with a drop
+	TransactionDrop   TransactionType = "drop"   // Only drops players
+	TransactionWaiver TransactionType = "waiver" // Waiver claim, possibly with a FAAB bid
+	TransactionTrade  TransactionType = "trade"
+)
+
+type AssetType string
+
+const (
+	AssetPlayer    AssetType = "player"
+	AssetDraftPick AssetType = "pick"
+	AssetFAAB      AssetType = "faab"
+)
+
+// Transaction is a completed roster move in a league.
+type Transaction struct {
+	ID         int32
+	ExternalID string // The id assigned by the platform
+	Type       TransactionType
+	Week       int
+	Processed  time.Time
+	WaiverBid  int32 // FAAB bid for a waiver claim, 0 if there wasn't one
+	Assets     []TransactionAsset
+}
+
+// TransactionAsset is one thing that moved between teams in a transaction.
+// A player picked up from free agency or waivers has no FromTeamID, and a
+// dropped player has no ToTeamID.
+type TransactionAsset struct {
+	Type       AssetType
+	FromTeamID string
+	ToTeamID   string
+	PlayerID   string // Set when Type is AssetPlayer
+	PickSeason string // Set when Type is AssetDraftPick
+	PickRound  int    // Set when Type is AssetDraftPick
+	PickTeamID string // The team that originally owned the draft pick
+	FAAB       int32  // Set when Type is AssetFAAB
+
+	// These are not persisted, they are filled in when loading transactions.
+	FromTeamName string
+	ToTeamName   string
+	PickTeamName string
+	PlayerName   string
+}
+
+// Summary returns a one line, human readable description of the transaction.
+func (t *Transaction) Summary() string {
+	if t.Type == TransactionTrade {
+		return t.tradeSummary()
+	}
+
+	var team string
+	adds := make([]string, 0, 1)
+	drops := make([]string, 0, 1)
+	for i := range t.Assets {
+		a := &t.Assets[i]
+		if a.ToTeamID != "" {
+			team = first(team, a.ToTeamName, a.ToTeamID)
+			adds = append(adds, a.describe())
+		} else {
+			team = first(team, a.FromTeamName, a.FromTeamID)
+			drops = append(drops, a.describe())
+		}
+	}
+
+	parts := make([]string, 0, 2)
+	if len(adds) > 0 {
+		verb := "added"
+		if t.Type == TransactionWaiver {
+			verb = "claimed"
+		}
+		s := fmt.Sprintf("%s %s", verb, strings.Join(adds, ", "))
+		if t.WaiverBid > 0 {
+			s = fmt.Sprintf("%s for $%d", s, t.WaiverBid)
+		}
+		parts = append(parts, s)
+	}
+	if len(drops) > 0 {
+		parts = append(parts, fmt.Sprintf("dropped %s", strings.Join(drops, ", ")))
+	}
+	return fmt.Sprintf("%s %s", team, strings.Join(parts, " and "))
+}
+
+// A trade can have any number of teams, so describe what each team receives.
+func (t *Transaction) tradeSummary() string {
+	order := make([]string, 0, 2)
+	received := make(map[string][]string)
+	for i := range t.Assets {
+		a := &t.Assets[i]
+		team := first(a.ToTeamName, a.ToTeamID)
+		if _, found := received[team]; !found {
+			order = append(order, team)
+		}
+		received[team] = append(received[team], a.describe())
+	}
+
+	parts := make([]string, 0, len(order))
+	for _, team := range order {
+		parts = append(parts, fmt.Sprintf("%s receives %s", team, strings.Join(received[team], ", ")))
+	}
+	return fmt.Sprintf("Trade: %s", strings.Join(parts, "; "))
+}
+
+func (a *TransactionAsset) describe() string {
+	switch a.Type {
+	case AssetDraftPick:
+		// Not every platform says which season a traded pick is for
+		s := fmt.Sprintf("round %d pick", a.PickRound)
+		if a.PickSeason != "" {
+			s = fmt.Sprintf("%s %s", a.PickSeason, s)
+		}
+		if a.PickTeamID != "" && a.PickTeamID != a.FromTeamID {
+			s = fmt.Sprintf("%s (via %s)", s, first(a.PickTeamName, a.PickTeamID))
+		}
+		return s
+	case AssetFAAB:
+		return fmt.Sprintf("$%d FAAB", a.FAAB)
+	default:
+		return first(a.PlayerName, a.PlayerID)
+	}
+}
+
+// Return the first non-empty string
+func first(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package model
+
+import "testing"
+
+func TestTransactionSummary(t *testing.T) {
+	tests := map[string]struct {
+		tx       Transaction
+		expected string
+	}{
+		"add": {
+			tx: Transaction{Type: TransactionAdd, Assets: []TransactionAsset{
+				{Type: AssetPlayer, ToTeamID: "1", ToTeamName: "Puk Nukem", PlayerName: "Luke Schoonmaker"},
+			}},
+			expected: "Puk Nukem added Luke Schoonmaker",
+		},
+		"drop": {
+			tx: Transaction{Type: TransactionDrop, Assets: []TransactionAsset{
+				{Type: AssetPlayer, FromTeamID: "1", FromTeamName: "gee17", PlayerName: "Cedric Tillman"},
+			}},
+			expected: "gee17 dropped Cedric Tillman",
+		},
+		"waiver with bid": {
+			tx: Transaction{Type: TransactionWaiver, WaiverBid: 17, Assets: []TransactionAsset{
+				{Type: AssetPlayer, ToTeamID: "1", ToTeamName: "Puk Nukem", PlayerName: "Andrei Iosivas"},
+				{Type: AssetPlayer, FromTeamID: "1", FromTeamName: "Puk Nukem", PlayerName: "Zach Ertz"},
+			}},
+			expected: "Puk Nukem claimed Andrei Iosivas for $17 and dropped Zach Ertz",
+		},
+		"missing names": {
+			tx: Transaction{Type: TransactionAdd, Assets: []TransactionAsset{
+				{Type: AssetPlayer, ToTeamID: "team-1", PlayerID: "1234"},
+			}},
+			expected: "team-1 added 1234",
+		},
+		"trade": {
+			tx: Transaction{Type: TransactionTrade, Assets: []TransactionAsset{
+				{Type: AssetPlayer, FromTeamID: "1", ToTeamID: "7", ToTeamName: "Jolly Roger", PlayerName: "Zay Jones"},
+				{Type: AssetDraftPick, FromTeamID: "1", ToTeamID: "7", ToTeamName: "Jolly Roger", PickSeason: "2025", PickRound: 2, PickTeamID: "1"},
+				{Type: AssetFAAB, FromTeamID: "1", ToTeamID: "7", ToTeamName: "Jolly Roger", FAAB: 10},
+				{Type: AssetPlayer, FromTeamID: "7", ToTeamID: "1", ToTeamName: "Puk Nukem", PlayerName: "Ameer Abdullah"},
+				{Type: AssetDraftPick, FromTeamID: "7", ToTeamID: "1", ToTeamName: "Puk Nukem", PickSeason: "2025", PickRound: 3, PickTeamID: "4", PickTeamName: "No-Bell Prizes"},
+			}},
+			expected: "Trade: Jolly Roger receives Zay Jones, 2025 round 2 pick, $10 FAAB; Puk Nukem receives Ameer Abdullah, 2025 round 3 pick (via No-Bell Prizes)",
+		},
+		"pick without a season": {
+			tx: Transaction{Type: TransactionTrade, Assets: []TransactionAsset{
+				{Type: AssetPlayer, FromTeamID: "1", ToTeamID: "7", ToTeamName: "Jolly Roger", PlayerName: "Zay Jones"},
+				{Type: AssetDraftPick, FromTeamID: "7", ToTeamID: "1", ToTeamName: "Puk Nukem", PickRound: 3, PickTeamID: "7"},
+			}},
+			expected: "Trade: Jolly Roger receives Zay Jones; Puk Nukem receives round 3 pick",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if s := tc.tx.Summary(); s != tc.expected {
+				t.Errorf("expected: %s, got: %s", tc.expected, s)
+			}
+		})
+	}
+}
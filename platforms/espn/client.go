@@ -0,0 +1,449 @@
+package espn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/platforms/espn/internal"
+)
+
+const (
+	ESPNURL    = "https://lm-api-reads.fantasy.espn.com"
+	ESPNFanURL = "https://fan.api.espn.com"
+)
+
+// ESPN lineup slot ids, these are the keys used in lineupSlotCounts.
+const (
+	slotQB   = 0
+	slotRB   = 2
+	slotRBWR = 3
+	slotWR   = 4
+	slotWRTE = 5
+	slotTE   = 6
+	slotOP   = 7
+	slotDST  = 16
+	slotK    = 17
+	slotFlex = 23
+)
+
+// The order that starting roster spots are returned in. ESPN does not provide
+// an order so use the same order that is shown on the ESPN roster page.
+var starterSlotOrder = []int{slotQB, slotRB, slotWR, slotTE, slotRBWR, slotWRTE, slotFlex, slotOP, slotDST, slotK}
+
+type Client interface {
+	// Get all of the leagues for the user (identified by their SWID) for a year.
+	GetLeaguesForUser(swid, year string) ([]model.League, error)
+
+	GetLeagueName(leagueID, year string) (string, error)
+
+	// Get all of the league managers for a specific league.
+	GetLeagueManagers(leagueID, year string) ([]model.LeagueManager, error)
+
+	// Sort the managers in a stable and logical order.
+	SortManagers(m []model.LeagueManager)
+
+	// Get the matchup for a specific week for a league. Also returns the individual
+	// scores for all the players. Players are identified by their ESPN id and need
+	// to be converted before they can be saved.
+	GetMatchupResults(leagueID, year string, week int) ([]model.Matchup, []PlayerScore, error)
+
+	// Load the rosters for all teams. Players are identified by their ESPN id.
+	GetRosters(leagueID, year string) ([]Roster, error)
+
+	// Get a list of all the positions a user needs to start in the league.
+	// This is used to select a starting lineup in the power rankings.
+	GetStarters(leagueID, year string) ([]model.RosterSpot, error)
+
+	GetLeagueStandings(leagueID, year string) ([]model.LeagueStanding, error)
+}
+
+// PlayerScore is the score for a single player identified by their ESPN id.
+type PlayerScore struct {
+	Player model.ESPNPlayer
+	Score  int32
+}
+
+// Roster is all of the players on a team identified by their ESPN ids.
+type Roster struct {
+	TeamID  string
+	Players []model.ESPNPlayer
+}
+
+type client struct {
+	url        string
+	fanURL     string
+	espnS2     string
+	swid       string
+	httpClient *http.Client
+}
+
+// Create a new ESPN client. The espnS2 and swid values are the cookies from a
+// logged in ESPN session, they are only needed to access private leagues.
+func New(espnS2, swid string) (Client, error) {
+	if (espnS2 == "") != (swid == "") {
+		return nil, errors.New("both espn_s2 and SWID must be provided for private leagues")
+	}
+
+	c := &client{
+		url:    ESPNURL,
+		fanURL: ESPNFanURL,
+		espnS2: espnS2,
+		swid:   swid,
+		httpClient: &http.Client{
+			Timeout: 1 * time.Minute,
+		},
+	}
+	return c, nil
+}
+
+func NewForTest(url string) Client {
+	return &client{
+		url:        url,
+		fanURL:     url,
+		espnS2:     "test_espn_s2",
+		swid:       "{TEST-SWID}",
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (c *client) GetLeaguesForUser(swid, year string) ([]model.League, error) {
+	if swid == "" {
+		swid = c.swid
+	}
+	season, err := strconv.Atoi(year)
+	if err != nil {
+		return nil, fmt.Errorf("invalid year %s: %w", year, err)
+	}
+
+	var fan internal.Fan
+	p := fmt.Sprintf("/apis/v2/fans/%s?displayHiddenPrefs=true&context=fantasy&useCookieAuth=true", url.PathEscape(swid))
+	if err := c.espnRequest(&fan, c.fanURL, p); err != nil {
+		return nil, err
+	}
+
+	res := make([]model.League, 0, len(fan.Preferences))
+	for _, p := range fan.Preferences {
+		if p.MetaData == nil || p.MetaData.Entry == nil {
+			continue
+		}
+		e := p.MetaData.Entry
+		if e.Abbrev != "FFL" || e.SeasonID != season {
+			continue
+		}
+		for _, g := range e.Groups {
+			res = append(res, model.League{
+				Platform:   model.PlatformESPN,
+				ExternalID: fmt.Sprint(g.GroupID),
+				Name:       g.GroupName,
+				Year:       year,
+				Archived:   false,
+			})
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, errors.New("no leagues found")
+	}
+	return res, nil
+}
+
+func (c *client) GetLeagueName(leagueID, year string) (string, error) {
+	league, err := c.getLeague(leagueID, year, "view=mSettings")
+	if err != nil {
+		return "", err
+	}
+	if league.Settings == nil || league.Settings.Name == "" {
+		return "", errors.New("league name not found")
+	}
+	return league.Settings.Name, nil
+}
+
+func (c *client) GetLeagueManagers(leagueID, year string) ([]model.LeagueManager, error) {
+	league, err := c.getLeague(leagueID, year, "view=mTeam")
+	if err != nil {
+		return nil, err
+	}
+	if len(league.Teams) == 0 {
+		return nil, errors.New("no managers found")
+	}
+
+	members := make(map[string]string)
+	for _, m := range league.Members {
+		members[m.ID] = m.DisplayName
+	}
+
+	resp := make([]model.LeagueManager, 0, len(league.Teams))
+	for _, t := range league.Teams {
+		m := model.LeagueManager{
+			ExternalID:  fmt.Sprint(t.ID),
+			TeamName:    teamName(&t),
+			ManagerName: members[t.PrimaryOwner],
+		}
+		resp = append(resp, m)
+	}
+	c.SortManagers(resp)
+	return resp, nil
+}
+
+func (c *client) SortManagers(m []model.LeagueManager) {
+	// ESPN team ids are small integers, sort numerically by them.
+	slices.SortFunc(m, func(a, b model.LeagueManager) int {
+		ai, e1 := strconv.Atoi(a.ExternalID)
+		bi, e2 := strconv.Atoi(b.ExternalID)
+		if err := errors.Join(e1, e2); err != nil {
+			return 0
+		}
+		return ai - bi
+	})
+}
+
+func (c *client) GetMatchupResults(leagueID, year string, week int) ([]model.Matchup, []PlayerScore, error) {
+	league, err := c.getLeague(leagueID, year, "view=mMatchupScore&view=mBoxscore&scoringPeriodId=%d", week)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matches := make([]model.Matchup, 0, 8)
+	playerScores := make([]PlayerScore, 0, 128)
+	for _, m := range league.Schedule {
+		if m.MatchupPeriodID != week {
+			continue
+		}
+		if m.Home == nil || m.Away == nil {
+			// This is a bye, usually in the playoffs
+			continue
+		}
+
+		matches = append(matches, model.Matchup{
+			TeamA:     toTeamResult(m.Home),
+			TeamB:     toTeamResult(m.Away),
+			MatchupID: m.ID,
+			Week:      week,
+		})
+
+		for _, t := range []*internal.MatchupTeam{m.Home, m.Away} {
+			if t.RosterForCurrentScoringPeriod == nil {
+				continue
+			}
+			for _, e := range t.RosterForCurrentScoringPeriod.Entries {
+				if e.PlayerPoolEntry == nil || e.PlayerPoolEntry.Player == nil {
+					continue
+				}
+				playerScores = append(playerScores, PlayerScore{
+					Player: toESPNPlayer(e.PlayerPoolEntry.Player),
+					Score:  int32(math.Round(e.PlayerPoolEntry.AppliedStatTotal * 1000)),
+				})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, nil, fmt.Errorf("no matchups found for week %d", week)
+	}
+
+	slices.SortFunc(matches, func(a, b model.Matchup) int {
+		return int(a.MatchupID - b.MatchupID)
+	})
+	return matches, playerScores, nil
+}
+
+func (c *client) GetRosters(leagueID, year string) ([]Roster, error) {
+	league, err := c.getLeague(leagueID, year, "view=mRoster")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Roster, 0, len(league.Teams))
+	for _, t := range league.Teams {
+		r := Roster{TeamID: fmt.Sprint(t.ID)}
+		if t.Roster != nil {
+			r.Players = make([]model.ESPNPlayer, 0, len(t.Roster.Entries))
+			for _, e := range t.Roster.Entries {
+				if e.PlayerPoolEntry == nil || e.PlayerPoolEntry.Player == nil {
+					continue
+				}
+				r.Players = append(r.Players, toESPNPlayer(e.PlayerPoolEntry.Player))
+			}
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (c *client) GetStarters(leagueID, year string) ([]model.RosterSpot, error) {
+	league, err := c.getLeague(leagueID, year, "view=mSettings")
+	if err != nil {
+		return nil, err
+	}
+	if league.Settings == nil || league.Settings.RosterSettings == nil {
+		return nil, errors.New("settings has no roster positions")
+	}
+
+	counts := make(map[int]int)
+	for k, v := range league.Settings.RosterSettings.LineupSlotCounts {
+		slot, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse lineup slot id '%s': %w", k, err)
+		}
+		counts[slot] = v
+	}
+
+	response := make([]model.RosterSpot, 0, 10)
+	for _, slot := range starterSlotOrder {
+		spot := rosterSpotForSlot(slot)
+		for range counts[slot] {
+			response = append(response, spot)
+		}
+	}
+
+	if len(response) == 0 {
+		return nil, errors.New("no roster positions found")
+	}
+	return response, nil
+}
+
+func (c *client) GetLeagueStandings(leagueID, year string) ([]model.LeagueStanding, error) {
+	league, err := c.getLeague(leagueID, year, "view=mTeam")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.LeagueStanding, 0, len(league.Teams))
+	for _, t := range league.Teams {
+		s := model.LeagueStanding{TeamID: fmt.Sprint(t.ID)}
+		if t.Record != nil && t.Record.Overall != nil {
+			s.Wins = t.Record.Overall.Wins
+			s.Losses = t.Record.Overall.Losses
+			s.Draws = t.Record.Overall.Ties
+			s.Scored = fmt.Sprintf("%.2f", t.Record.Overall.PointsFor)
+		}
+		results = append(results, s)
+	}
+
+	model.SortStandings(results)
+	return results, nil
+}
+
+func (c *client) getLeague(leagueID, year, query string, args ...any) (*internal.League, error) {
+	var league internal.League
+	p := fmt.Sprintf("/apis/v3/games/ffl/seasons/%s/segments/0/leagues/%s?%s", year, leagueID, fmt.Sprintf(query, args...))
+	if err := c.espnRequest(&league, c.url, p); err != nil {
+		return nil, err
+	}
+	return &league, nil
+}
+
+// Sends the request to ESPN and uses a JSON parser to read the result into res.
+// Returns an error if any or if the status code of the result is not 200.
+func (c *client) espnRequest(res any, baseURL, path string) error {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", baseURL, path), nil)
+	if err != nil {
+		return fmt.Errorf("error creating espn http request: %w", err)
+	}
+	if c.espnS2 != "" && c.swid != "" {
+		req.AddCookie(&http.Cookie{Name: "espn_s2", Value: c.espnS2})
+		req.AddCookie(&http.Cookie{Name: "SWID", Value: c.swid})
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending espn http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code from espn: %d", resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(res)
+	if err != nil {
+		return fmt.Errorf("error parsing response from espn: %w", err)
+	}
+
+	return nil
+}
+
+func teamName(t *internal.Team) string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s", t.Location, t.Nickname))
+}
+
+func toTeamResult(t *internal.MatchupTeam) *model.TeamResult {
+	return &model.TeamResult{
+		TeamID: fmt.Sprint(t.TeamID),
+		Score:  int32(math.Round(t.TotalPoints * 1000)),
+	}
+}
+
+func toESPNPlayer(p *internal.Player) model.ESPNPlayer {
+	pos := parsePosition(p.DefaultPositionID)
+	res := model.ESPNPlayer{
+		ESPNID:    fmt.Sprint(p.ID),
+		FirstName: p.FirstName,
+		LastName:  p.LastName,
+		Pos:       pos,
+	}
+	if pos == model.POS_DEF {
+		// ESPN names defenses like "Seahawks D/ST", only the nickname is useful for matching.
+		res.FirstName = ""
+		res.LastName = strings.TrimSpace(strings.TrimSuffix(p.FullName, "D/ST"))
+	}
+	return res
+}
+
+func parsePosition(id int) model.Position {
+	switch id {
+	case 1:
+		return model.POS_QB
+	case 2:
+		return model.POS_RB
+	case 3:
+		return model.POS_WR
+	case 4:
+		return model.POS_TE
+	case 5:
+		return model.POS_K
+	case 16:
+		return model.POS_DEF
+	default:
+		return model.POS_UNKNOWN
+	}
+}
+
+func rosterSpotForSlot(slot int) model.RosterSpot {
+	switch slot {
+	case slotQB:
+		return model.GetRosterSpot("QB")
+	case slotRB:
+		return model.GetRosterSpot("RB")
+	case slotWR:
+		return model.GetRosterSpot("WR")
+	case slotTE:
+		return model.GetRosterSpot("TE")
+	case slotRBWR:
+		return model.RosterSpot{Allowed: []model.Position{model.POS_RB, model.POS_WR}}
+	case slotWRTE:
+		return model.RosterSpot{Allowed: []model.Position{model.POS_WR, model.POS_TE}}
+	case slotFlex:
+		return model.GetRosterSpot("FLEX")
+	case slotOP:
+		return model.RosterSpot{Allowed: []model.Position{model.POS_QB, model.POS_RB, model.POS_WR, model.POS_TE}}
+	case slotDST:
+		return model.GetRosterSpot("DEF")
+	case slotK:
+		return model.GetRosterSpot("K")
+	default:
+		return model.RosterSpot{}
+	}
+}
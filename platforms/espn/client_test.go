@@ -0,0 +1,269 @@
+package espn
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New("", ""); err != nil {
+		t.Errorf("unexpected error creating client without cookies: %v", err)
+	}
+	if _, err := New("s2", "{SWID}"); err != nil {
+		t.Errorf("unexpected error creating client with cookies: %v", err)
+	}
+	if _, err := New("s2", ""); err == nil {
+		t.Errorf("expected an error when only espn_s2 is provided")
+	}
+}
+
+func TestGetLeaguesForUser(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := NewForTest(fakeESPN.URL())
+
+	leagues, err := c.GetLeaguesForUser(testutils.ESPNSWID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting leagues: %v", err)
+	}
+
+	expected := []model.League{
+		{Platform: model.PlatformESPN, ExternalID: testutils.ESPNLeagueID, Name: "ESPN Fantasy Friends", Year: "2024"},
+		{Platform: model.PlatformESPN, ExternalID: "1234567", Name: "Work League", Year: "2024"},
+	}
+	if !reflect.DeepEqual(expected, leagues) {
+		t.Errorf("expected: %v, got: %v", expected, leagues)
+	}
+
+	if _, err := c.GetLeaguesForUser(testutils.ESPNSWID, "2020"); err == nil {
+		t.Errorf("expected an error when there are no leagues for the year")
+	}
+	if _, err := c.GetLeaguesForUser("{UNKNOWN}", "2024"); err == nil {
+		t.Errorf("expected an error for an unknown user")
+	}
+}
+
+func TestGetLeagueName(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := NewForTest(fakeESPN.URL())
+
+	name, err := c.GetLeagueName(testutils.ESPNLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting league name: %v", err)
+	}
+	if name != "ESPN Fantasy Friends" {
+		t.Errorf("league name was not expected value, got: %s", name)
+	}
+
+	if _, err := c.GetLeagueName("987", "2024"); err == nil {
+		t.Errorf("expected an error for an unknown league")
+	}
+}
+
+func TestGetLeagueName_noCookies(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := &client{url: fakeESPN.URL(), fanURL: fakeESPN.URL(), httpClient: http.DefaultClient}
+	if _, err := c.GetLeagueName(testutils.ESPNLeagueID, "2024"); err == nil {
+		t.Errorf("expected an error when cookies are not sent")
+	}
+}
+
+func TestGetLeagueManagers(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := NewForTest(fakeESPN.URL())
+
+	managers, err := c.GetLeagueManagers(testutils.ESPNLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting managers: %v", err)
+	}
+
+	expected := []model.LeagueManager{
+		{ExternalID: "1", TeamName: "Hurts So Good", ManagerName: "espnuser1"},
+		{ExternalID: "2", TeamName: "Tucker Time", ManagerName: "tuckerfan"},
+		{ExternalID: "3", TeamName: "Lockett Down", ManagerName: "lockettfan"},
+		{ExternalID: "4", TeamName: "Fourth Place Forever", ManagerName: "lastplace"},
+	}
+	if !reflect.DeepEqual(expected, managers) {
+		t.Errorf("expected: %v, got: %v", expected, managers)
+	}
+}
+
+func TestSortManagers(t *testing.T) {
+	c := NewForTest("")
+
+	input := []model.LeagueManager{
+		{ExternalID: "10"},
+		{ExternalID: "2"},
+		{ExternalID: "1"},
+		{ExternalID: "7"},
+	}
+	expected := []model.LeagueManager{
+		{ExternalID: "1"},
+		{ExternalID: "2"},
+		{ExternalID: "7"},
+		{ExternalID: "10"},
+	}
+
+	c.SortManagers(input)
+	if !reflect.DeepEqual(expected, input) {
+		t.Errorf("expected: %v, got: %v", expected, input)
+	}
+}
+
+func TestGetMatchupResults(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := NewForTest(fakeESPN.URL())
+
+	matchups, scores, err := c.GetMatchupResults(testutils.ESPNLeagueID, "2024", 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting matchup results: %v", err)
+	}
+
+	expected := []model.Matchup{
+		{
+			MatchupID: 1,
+			Week:      1,
+			TeamA:     &model.TeamResult{TeamID: "1", Score: 49860},
+			TeamB:     &model.TeamResult{TeamID: "2", Score: 33700},
+		},
+		{
+			MatchupID: 2,
+			Week:      1,
+			TeamA:     &model.TeamResult{TeamID: "3", Score: 38200},
+			TeamB:     &model.TeamResult{TeamID: "4", Score: 36120},
+		},
+	}
+	if !reflect.DeepEqual(expected, matchups) {
+		t.Errorf("expected: %v, got: %v", expected, matchups)
+	}
+
+	if len(scores) != 12 {
+		t.Fatalf("expected 12 player scores, got %d", len(scores))
+	}
+	// Matchup 2 is listed first in the schedule, so Lockett is the first player score.
+	lockett := PlayerScore{
+		Player: model.ESPNPlayer{ESPNID: "2577327", FirstName: "Tyler", LastName: "Lockett", Pos: model.POS_WR},
+		Score:  11400,
+	}
+	if !reflect.DeepEqual(lockett, scores[0]) {
+		t.Errorf("expected: %v, got: %v", lockett, scores[0])
+	}
+	// 8.12 points would be 8119 if the score was truncated instead of rounded
+	henry := slices.IndexFunc(scores, func(s PlayerScore) bool { return s.Player.ESPNID == "3046439" })
+	if henry == -1 || scores[henry].Score != 8120 {
+		t.Errorf("expected Hunter Henry to score 8120, got: %v", scores)
+	}
+
+	if _, _, err := c.GetMatchupResults(testutils.ESPNLeagueID, "2024", 5); err == nil {
+		t.Errorf("expected an error for a week without results")
+	}
+}
+
+func TestGetRosters(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := NewForTest(fakeESPN.URL())
+
+	rosters, err := c.GetRosters(testutils.ESPNLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting rosters: %v", err)
+	}
+	if len(rosters) != 4 {
+		t.Fatalf("expected 4 rosters, got %d", len(rosters))
+	}
+
+	expected := Roster{
+		TeamID: "1",
+		Players: []model.ESPNPlayer{
+			{ESPNID: "4040715", FirstName: "Jalen", LastName: "Hurts", Pos: model.POS_QB},
+			{ESPNID: "4430807", FirstName: "Bijan", LastName: "Robinson", Pos: model.POS_RB},
+			{ESPNID: "-16026", FirstName: "", LastName: "Seahawks", Pos: model.POS_DEF},
+		},
+	}
+	if !reflect.DeepEqual(expected, rosters[0]) {
+		t.Errorf("expected: %v, got: %v", expected, rosters[0])
+	}
+}
+
+func TestGetStarters(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := NewForTest(fakeESPN.URL())
+
+	starters, err := c.GetStarters(testutils.ESPNLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting starters: %v", err)
+	}
+
+	expected := []model.RosterSpot{
+		model.GetRosterSpot("QB"),
+		model.GetRosterSpot("RB"),
+		model.GetRosterSpot("RB"),
+		model.GetRosterSpot("WR"),
+		model.GetRosterSpot("WR"),
+		model.GetRosterSpot("TE"),
+		model.GetRosterSpot("FLEX"),
+		model.GetRosterSpot("DEF"),
+		model.GetRosterSpot("K"),
+	}
+	if !reflect.DeepEqual(expected, starters) {
+		t.Errorf("expected: %v, got: %v", expected, starters)
+	}
+}
+
+func TestGetLeagueStandings(t *testing.T) {
+	fakeESPN := testutils.NewFakeESPNServer()
+	defer fakeESPN.Close()
+
+	c := NewForTest(fakeESPN.URL())
+
+	standings, err := c.GetLeagueStandings(testutils.ESPNLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting standings: %v", err)
+	}
+
+	expected := []model.LeagueStanding{
+		{TeamID: "3", Rank: 1, Wins: 10, Losses: 3, Scored: "1600.00"},
+		{TeamID: "1", Rank: 2, Wins: 8, Losses: 5, Scored: "1500.12"},
+		{TeamID: "2", Rank: 3, Wins: 8, Losses: 5, Scored: "1450.50"},
+		{TeamID: "4", Rank: 4, Wins: 0, Losses: 13, Scored: "1000.00"},
+	}
+	if !reflect.DeepEqual(expected, standings) {
+		t.Errorf("expected: %v, got: %v", expected, standings)
+	}
+}
+
+func TestRosterSpotForSlot(t *testing.T) {
+	tests := []struct {
+		slot     int
+		expected []model.Position
+	}{
+		{slot: slotRBWR, expected: []model.Position{model.POS_RB, model.POS_WR}},
+		{slot: slotWRTE, expected: []model.Position{model.POS_WR, model.POS_TE}},
+		{slot: slotOP, expected: []model.Position{model.POS_QB, model.POS_RB, model.POS_WR, model.POS_TE}},
+		{slot: 99, expected: nil},
+	}
+
+	for _, tc := range tests {
+		spot := rosterSpotForSlot(tc.slot)
+		if !reflect.DeepEqual(tc.expected, spot.Allowed) {
+			t.Errorf("slot %d - expected: %v, got: %v", tc.slot, tc.expected, spot.Allowed)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package internal
+
+// The structures in this file model the JSON responses from the ESPN fantasy
+// football API. Only the fields that are actually used are included.
+
+type League struct {
+	ID       int64     `json:"id"`
+	SeasonID int       `json:"seasonId"`
+	Settings *Settings `json:"settings"`
+	Members  []Member  `json:"members"`
+	Teams    []Team    `json:"teams"`
+	Schedule []Matchup `json:"schedule"`
+	Status   *Status   `json:"status"`
+}
+
+type Settings struct {
+	Name           string          `json:"name"`
+	RosterSettings *RosterSettings `json:"rosterSettings"`
+}
+
+type RosterSettings struct {
+	// Maps the lineup slot id to the number of players that start in that slot.
+	LineupSlotCounts map[string]int `json:"lineupSlotCounts"`
+}
+
+type Status struct {
+	CurrentMatchupPeriod int `json:"currentMatchupPeriod"`
+}
+
+type Member struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+}
+
+type Team struct {
+	ID           int     `json:"id"`
+	Abbrev       string  `json:"abbrev"`
+	Name         string  `json:"name"`
+	Location     string  `json:"location"`
+	Nickname     string  `json:"nickname"`
+	PrimaryOwner string  `json:"primaryOwner"`
+	PlayoffSeed  int     `json:"playoffSeed"`
+	Record       *Record `json:"record"`
+	Roster       *Roster `json:"roster"`
+}
+
+type Record struct {
+	Overall *RecordDetails `json:"overall"`
+}
+
+type RecordDetails struct {
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Ties          int     `json:"ties"`
+	PointsFor     float64 `json:"pointsFor"`
+	PointsAgainst float64 `json:"pointsAgainst"`
+}
+
+type Roster struct {
+	Entries []RosterEntry `json:"entries"`
+}
+
+type RosterEntry struct {
+	PlayerID        int              `json:"playerId"`
+	LineupSlotID    int              `json:"lineupSlotId"`
+	PlayerPoolEntry *PlayerPoolEntry `json:"playerPoolEntry"`
+}
+
+type PlayerPoolEntry struct {
+	ID               int     `json:"id"`
+	AppliedStatTotal float64 `json:"appliedStatTotal"`
+	Player           *Player `json:"player"`
+}
+
+type Player struct {
+	ID                int    `json:"id"`
+	FullName          string `json:"fullName"`
+	FirstName         string `json:"firstName"`
+	LastName          string `json:"lastName"`
+	DefaultPositionID int    `json:"defaultPositionId"`
+}
+
+type Matchup struct {
+	ID              int32        `json:"id"`
+	MatchupPeriodID int          `json:"matchupPeriodId"`
+	Home            *MatchupTeam `json:"home"`
+	Away            *MatchupTeam `json:"away"`
+}
+
+type MatchupTeam struct {
+	TeamID                        int     `json:"teamId"`
+	TotalPoints                   float64 `json:"totalPoints"`
+	RosterForCurrentScoringPeriod *Roster `json:"rosterForCurrentScoringPeriod"`
+}
+
+// Fan is the response from the ESPN fan API which lists all the fantasy
+// entries (leagues) that a user belongs to.
+type Fan struct {
+	ID          string       `json:"id"`
+	Preferences []Preference `json:"preferences"`
+}
+
+type Preference struct {
+	MetaData *PreferenceMetaData `json:"metaData"`
+}
+
+type PreferenceMetaData struct {
+	Entry *Entry `json:"entry"`
+}
+
+type Entry struct {
+	Abbrev   string  `json:"abbrev"` // "FFL" for fantasy football
+	SeasonID int     `json:"seasonId"`
+	Groups   []Group `json:"groups"`
+}
+
+type Group struct {
+	GroupID   int64  `json:"groupId"`
+	GroupName string `json:"groupName"`
+}
@@ -0,0 +1,359 @@
+package fleaflicker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/platforms/fleaflicker/internal"
+)
+
+const FleaflickerURL = "https://www.fleaflicker.com"
+
+type Client interface {
+	// Get all of the leagues for the user (identified by their email) for a year.
+	GetLeaguesForUser(email, year string) ([]model.League, error)
+
+	GetLeagueName(leagueID, year string) (string, error)
+
+	// Get all of the league managers for a specific league.
+	GetLeagueManagers(leagueID, year string) ([]model.LeagueManager, error)
+
+	// Sort the managers in a stable and logical order.
+	SortManagers(m []model.LeagueManager)
+
+	// Get the matchup for a specific week for a league. Also returns the individual
+	// scores for all the players. Players are identified by their Fleaflicker id and
+	// need to be converted before they can be saved.
+	GetMatchupResults(leagueID, year string, week int) ([]model.Matchup, []PlayerScore, error)
+
+	// Load the rosters for all teams. Players are identified by their Fleaflicker id.
+	GetRosters(leagueID, year string) ([]Roster, error)
+
+	// Get a list of all the positions a user needs to start in the league.
+	// This is used to select a starting lineup in the power rankings.
+	GetStarters(leagueID, year string) ([]model.RosterSpot, error)
+
+	GetLeagueStandings(leagueID, year string) ([]model.LeagueStanding, error)
+}
+
+// PlayerScore is the score for a single player identified by their Fleaflicker id.
+type PlayerScore struct {
+	Player model.FleaflickerPlayer
+	Score  int32
+}
+
+// Roster is all of the players on a team identified by their Fleaflicker ids.
+type Roster struct {
+	TeamID  string
+	Players []model.FleaflickerPlayer
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+func New() (Client, error) {
+	c := &client{
+		url: FleaflickerURL,
+		httpClient: &http.Client{
+			Timeout: 1 * time.Minute,
+		},
+	}
+	return c, nil
+}
+
+func NewForTest(url string) Client {
+	return &client{
+		url:        url,
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (c *client) GetLeaguesForUser(email, year string) ([]model.League, error) {
+	var resp internal.UserLeagues
+	if err := c.fleaflickerRequest(&resp, "FetchUserLeagues", url.Values{"email": {email}, "season": {year}}); err != nil {
+		return nil, err
+	}
+	if len(resp.Leagues) == 0 {
+		return nil, errors.New("no leagues found")
+	}
+
+	res := make([]model.League, 0, len(resp.Leagues))
+	for _, l := range resp.Leagues {
+		res = append(res, model.League{
+			Platform:   model.PlatformFleaflicker,
+			ExternalID: fmt.Sprint(l.ID),
+			Name:       l.Name,
+			Year:       year,
+			Archived:   false,
+		})
+	}
+	return res, nil
+}
+
+func (c *client) GetLeagueName(leagueID, year string) (string, error) {
+	standings, err := c.getStandings(leagueID, year)
+	if err != nil {
+		return "", err
+	}
+	if standings.League == nil || standings.League.Name == "" {
+		return "", errors.New("league name not found")
+	}
+	return standings.League.Name, nil
+}
+
+func (c *client) GetLeagueManagers(leagueID, year string) ([]model.LeagueManager, error) {
+	standings, err := c.getStandings(leagueID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]model.LeagueManager, 0, 12)
+	for _, d := range standings.Divisions {
+		for _, t := range d.Teams {
+			m := model.LeagueManager{
+				ExternalID: fmt.Sprint(t.ID),
+				TeamName:   t.Name,
+			}
+			if len(t.Owners) > 0 {
+				m.ManagerName = t.Owners[0].DisplayName
+			}
+			resp = append(resp, m)
+		}
+	}
+	if len(resp) == 0 {
+		return nil, errors.New("no managers found")
+	}
+
+	c.SortManagers(resp)
+	return resp, nil
+}
+
+func (c *client) SortManagers(m []model.LeagueManager) {
+	// Fleaflicker team ids are numbers, sort numerically by them.
+	slices.SortFunc(m, func(a, b model.LeagueManager) int {
+		ai, e1 := strconv.ParseInt(a.ExternalID, 10, 64)
+		bi, e2 := strconv.ParseInt(b.ExternalID, 10, 64)
+		if err := errors.Join(e1, e2); err != nil {
+			return 0
+		}
+		if ai < bi {
+			return -1
+		} else if ai > bi {
+			return 1
+		}
+		return 0
+	})
+}
+
+func (c *client) GetMatchupResults(leagueID, year string, week int) ([]model.Matchup, []PlayerScore, error) {
+	var scoreboard internal.LeagueScoreboard
+	args := url.Values{"league_id": {leagueID}, "season": {year}, "scoring_period": {fmt.Sprint(week)}}
+	if err := c.fleaflickerRequest(&scoreboard, "FetchLeagueScoreboard", args); err != nil {
+		return nil, nil, err
+	}
+	if len(scoreboard.Games) == 0 {
+		return nil, nil, fmt.Errorf("no matchups found for week %d", week)
+	}
+
+	matches := make([]model.Matchup, 0, len(scoreboard.Games))
+	playerScores := make([]PlayerScore, 0, 128)
+	for i, g := range scoreboard.Games {
+		if g.Home == nil || g.Away == nil {
+			return nil, nil, errors.New("at least one matchup is not complete with 2 teams")
+		}
+		matches = append(matches, model.Matchup{
+			TeamA:     &model.TeamResult{TeamID: fmt.Sprint(g.Home.ID), Score: toScore(g.HomeScore)},
+			TeamB:     &model.TeamResult{TeamID: fmt.Sprint(g.Away.ID), Score: toScore(g.AwayScore)},
+			MatchupID: int32(i + 1),
+			Week:      week,
+		})
+
+		var boxscore internal.LeagueBoxscore
+		args := url.Values{"league_id": {leagueID}, "fantasy_game_id": {g.ID}, "scoring_period": {fmt.Sprint(week)}}
+		if err := c.fleaflickerRequest(&boxscore, "FetchLeagueBoxscore", args); err != nil {
+			return nil, nil, err
+		}
+		for _, l := range boxscore.Lineups {
+			for _, s := range l.Slots {
+				for _, p := range []*internal.LeaguePlayer{s.Home, s.Away} {
+					if p == nil || p.ProPlayer == nil {
+						continue
+					}
+					playerScores = append(playerScores, PlayerScore{
+						Player: toFleaflickerPlayer(p.ProPlayer),
+						Score:  toPoints(p.ViewingActualPoints),
+					})
+				}
+			}
+		}
+	}
+
+	return matches, playerScores, nil
+}
+
+func (c *client) GetRosters(leagueID, year string) ([]Roster, error) {
+	var resp internal.LeagueRosters
+	if err := c.fleaflickerRequest(&resp, "FetchLeagueRosters", url.Values{"league_id": {leagueID}, "season": {year}}); err != nil {
+		return nil, err
+	}
+
+	results := make([]Roster, 0, len(resp.Rosters))
+	for _, r := range resp.Rosters {
+		if r.Team == nil {
+			continue
+		}
+		roster := Roster{
+			TeamID:  fmt.Sprint(r.Team.ID),
+			Players: make([]model.FleaflickerPlayer, 0, len(r.Players)),
+		}
+		for _, p := range r.Players {
+			if p.ProPlayer == nil {
+				continue
+			}
+			roster.Players = append(roster.Players, toFleaflickerPlayer(p.ProPlayer))
+		}
+		results = append(results, roster)
+	}
+	return results, nil
+}
+
+func (c *client) GetStarters(leagueID, year string) ([]model.RosterSpot, error) {
+	var rules internal.LeagueRules
+	if err := c.fleaflickerRequest(&rules, "FetchLeagueRules", url.Values{"league_id": {leagueID}, "season": {year}}); err != nil {
+		return nil, err
+	}
+
+	response := make([]model.RosterSpot, 0, 10)
+	for _, p := range rules.RosterPositions {
+		if p.Group != "START" {
+			continue
+		}
+		spot := model.RosterSpot{}
+		for _, e := range p.Eligibility {
+			if pos := parsePosition(e); pos != model.POS_UNKNOWN {
+				spot.Allowed = append(spot.Allowed, pos)
+			}
+		}
+		if len(spot.Allowed) == 0 {
+			continue
+		}
+		for range p.Start {
+			response = append(response, spot)
+		}
+	}
+
+	if len(response) == 0 {
+		return nil, errors.New("no roster positions found")
+	}
+	return response, nil
+}
+
+func (c *client) GetLeagueStandings(leagueID, year string) ([]model.LeagueStanding, error) {
+	standings, err := c.getStandings(leagueID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.LeagueStanding, 0, 12)
+	for _, d := range standings.Divisions {
+		for _, t := range d.Teams {
+			s := model.LeagueStanding{TeamID: fmt.Sprint(t.ID)}
+			if t.RecordOverall != nil {
+				s.Wins = t.RecordOverall.Wins
+				s.Losses = t.RecordOverall.Losses
+				s.Draws = t.RecordOverall.Ties
+			}
+			pf := 0.0
+			if t.PointsFor != nil {
+				pf = t.PointsFor.Value
+			}
+			s.Scored = fmt.Sprintf("%.2f", pf)
+			results = append(results, s)
+		}
+	}
+
+	model.SortStandings(results)
+	return results, nil
+}
+
+func (c *client) getStandings(leagueID, year string) (*internal.LeagueStandings, error) {
+	var standings internal.LeagueStandings
+	if err := c.fleaflickerRequest(&standings, "FetchLeagueStandings", url.Values{"league_id": {leagueID}, "season": {year}}); err != nil {
+		return nil, err
+	}
+	return &standings, nil
+}
+
+// Sends the request to Fleaflicker and uses a JSON parser to read the result into res.
+// Returns an error if any or if the status code of the result is not 200.
+func (c *client) fleaflickerRequest(res any, method string, args url.Values) error {
+	args.Set("sport", "NFL")
+	u := fmt.Sprintf("%s/api/%s?%s", c.url, method, args.Encode())
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("error creating fleaflicker http request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending fleaflicker http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e internal.Error
+		if err := json.NewDecoder(resp.Body).Decode(&e); err == nil && e.ErrorMessage != "" {
+			return fmt.Errorf("unexpected status code from fleaflicker: %d - %s", resp.StatusCode, e.ErrorMessage)
+		}
+		return fmt.Errorf("unexpected status code from fleaflicker: %d", resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(res)
+	if err != nil {
+		return fmt.Errorf("error parsing response from fleaflicker: %w", err)
+	}
+
+	return nil
+}
+
+func toFleaflickerPlayer(p *internal.ProPlayer) model.FleaflickerPlayer {
+	return model.FleaflickerPlayer{
+		FleaflickerID: fmt.Sprint(p.ID),
+		FirstName:     p.NameFirst,
+		LastName:      p.NameLast,
+		Pos:           parsePosition(p.Position),
+	}
+}
+
+func toScore(s *internal.Score) int32 {
+	if s == nil {
+		return 0
+	}
+	return toPoints(s.Score)
+}
+
+func toPoints(v *internal.Value) int32 {
+	if v == nil {
+		return 0
+	}
+	return int32(math.Round(v.Value * 1000))
+}
+
+func parsePosition(pos string) model.Position {
+	switch strings.ToUpper(pos) {
+	case "D/ST":
+		return model.POS_DEF
+	default:
+		return model.ParsePosition(pos)
+	}
+}
@@ -0,0 +1,195 @@
+package fleaflicker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestGetLeaguesForUser(t *testing.T) {
+	fakeFlea := testutils.NewFakeFleaflickerServer()
+	defer fakeFlea.Close()
+
+	c := NewForTest(fakeFlea.URL())
+
+	leagues, err := c.GetLeaguesForUser(testutils.FleaflickerEmail, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting leagues: %v", err)
+	}
+
+	expected := []model.League{
+		{Platform: model.PlatformFleaflicker, ExternalID: testutils.FleaflickerLeagueID, Name: "Fleaflicker Friends League", Year: "2024"},
+		{Platform: model.PlatformFleaflicker, ExternalID: "350001", Name: "Office League", Year: "2024"},
+	}
+	if !reflect.DeepEqual(expected, leagues) {
+		t.Errorf("expected: %v, got: %v", expected, leagues)
+	}
+
+	if _, err := c.GetLeaguesForUser("unknown@example.com", "2024"); err == nil {
+		t.Errorf("expected an error for an unknown user")
+	}
+}
+
+func TestGetLeagueName(t *testing.T) {
+	fakeFlea := testutils.NewFakeFleaflickerServer()
+	defer fakeFlea.Close()
+
+	c := NewForTest(fakeFlea.URL())
+
+	name, err := c.GetLeagueName(testutils.FleaflickerLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting league name: %v", err)
+	}
+	if name != "Fleaflicker Friends League" {
+		t.Errorf("league name was not expected value, got: %s", name)
+	}
+
+	if _, err := c.GetLeagueName("987", "2024"); err == nil {
+		t.Errorf("expected an error for an unknown league")
+	}
+}
+
+func TestGetLeagueManagers(t *testing.T) {
+	fakeFlea := testutils.NewFakeFleaflickerServer()
+	defer fakeFlea.Close()
+
+	c := NewForTest(fakeFlea.URL())
+
+	managers, err := c.GetLeagueManagers(testutils.FleaflickerLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting managers: %v", err)
+	}
+
+	expected := []model.LeagueManager{
+		{ExternalID: "1744001", TeamName: "Flea Flickers", ManagerName: "flickeruser"},
+		{ExternalID: "1744002", TeamName: "Kicker Kings", ManagerName: "kickerfan"},
+		{ExternalID: "1744003", TeamName: "Bench Warmers", ManagerName: "benchfan"},
+		{ExternalID: "1744004", TeamName: "Cellar Dwellers", ManagerName: "lastplace"},
+	}
+	if !reflect.DeepEqual(expected, managers) {
+		t.Errorf("expected: %v, got: %v", expected, managers)
+	}
+}
+
+func TestGetMatchupResults(t *testing.T) {
+	fakeFlea := testutils.NewFakeFleaflickerServer()
+	defer fakeFlea.Close()
+
+	c := NewForTest(fakeFlea.URL())
+
+	matchups, scores, err := c.GetMatchupResults(testutils.FleaflickerLeagueID, "2024", 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting matchup results: %v", err)
+	}
+
+	expected := []model.Matchup{
+		{
+			MatchupID: 1,
+			Week:      1,
+			TeamA:     &model.TeamResult{TeamID: "1744001", Score: 49860},
+			TeamB:     &model.TeamResult{TeamID: "1744002", Score: 33700},
+		},
+		{
+			MatchupID: 2,
+			Week:      1,
+			TeamA:     &model.TeamResult{TeamID: "1744003", Score: 38200},
+			TeamB:     &model.TeamResult{TeamID: "1744004", Score: 36120},
+		},
+	}
+	if !reflect.DeepEqual(expected, matchups) {
+		t.Errorf("expected: %v, got: %v", expected, matchups)
+	}
+
+	if len(scores) != 12 {
+		t.Fatalf("expected 12 player scores, got %d", len(scores))
+	}
+	hurts := PlayerScore{
+		Player: model.FleaflickerPlayer{FleaflickerID: "15286", FirstName: "Jalen", LastName: "Hurts", Pos: model.POS_QB},
+		Score:  24560,
+	}
+	if !reflect.DeepEqual(hurts, scores[0]) {
+		t.Errorf("expected: %v, got: %v", hurts, scores[0])
+	}
+
+	if _, _, err := c.GetMatchupResults(testutils.FleaflickerLeagueID, "2024", 5); err == nil {
+		t.Errorf("expected an error for a week without results")
+	}
+}
+
+func TestGetRosters(t *testing.T) {
+	fakeFlea := testutils.NewFakeFleaflickerServer()
+	defer fakeFlea.Close()
+
+	c := NewForTest(fakeFlea.URL())
+
+	rosters, err := c.GetRosters(testutils.FleaflickerLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting rosters: %v", err)
+	}
+	if len(rosters) != 4 {
+		t.Fatalf("expected 4 rosters, got %d", len(rosters))
+	}
+
+	expected := Roster{
+		TeamID: "1744001",
+		Players: []model.FleaflickerPlayer{
+			{FleaflickerID: "15286", FirstName: "Jalen", LastName: "Hurts", Pos: model.POS_QB},
+			{FleaflickerID: "17012", FirstName: "Bijan", LastName: "Robinson", Pos: model.POS_RB},
+			{FleaflickerID: "2339", FirstName: "Seattle", LastName: "Seahawks", Pos: model.POS_DEF},
+		},
+	}
+	if !reflect.DeepEqual(expected, rosters[0]) {
+		t.Errorf("expected: %v, got: %v", expected, rosters[0])
+	}
+}
+
+func TestGetStarters(t *testing.T) {
+	fakeFlea := testutils.NewFakeFleaflickerServer()
+	defer fakeFlea.Close()
+
+	c := NewForTest(fakeFlea.URL())
+
+	starters, err := c.GetStarters(testutils.FleaflickerLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting starters: %v", err)
+	}
+
+	expected := []model.RosterSpot{
+		model.GetRosterSpot("QB"),
+		model.GetRosterSpot("RB"),
+		model.GetRosterSpot("RB"),
+		model.GetRosterSpot("WR"),
+		model.GetRosterSpot("WR"),
+		model.GetRosterSpot("TE"),
+		model.GetRosterSpot("FLEX"),
+		model.GetRosterSpot("K"),
+		model.GetRosterSpot("DEF"),
+	}
+	if !reflect.DeepEqual(expected, starters) {
+		t.Errorf("expected: %v, got: %v", expected, starters)
+	}
+}
+
+func TestGetLeagueStandings(t *testing.T) {
+	fakeFlea := testutils.NewFakeFleaflickerServer()
+	defer fakeFlea.Close()
+
+	c := NewForTest(fakeFlea.URL())
+
+	standings, err := c.GetLeagueStandings(testutils.FleaflickerLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting standings: %v", err)
+	}
+
+	expected := []model.LeagueStanding{
+		{TeamID: "1744003", Rank: 1, Wins: 10, Losses: 3, Scored: "1600.00"},
+		{TeamID: "1744001", Rank: 2, Wins: 8, Losses: 5, Scored: "1500.12"},
+		{TeamID: "1744002", Rank: 3, Wins: 8, Losses: 5, Scored: "1450.50"},
+		{TeamID: "1744004", Rank: 4, Wins: 0, Losses: 13, Scored: "1000.00"},
+	}
+	if !reflect.DeepEqual(expected, standings) {
+		t.Errorf("expected: %v, got: %v", expected, standings)
+	}
+}
@@ -0,0 +1,117 @@
+package internal
+
+// The structures in this file model the JSON responses from the Fleaflicker
+// API. Only the fields that are actually used are included.
+
+type LeagueStandings struct {
+	League    *League    `json:"league"`
+	Divisions []Division `json:"divisions"`
+}
+
+type League struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type Division struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Teams []Team `json:"teams"`
+}
+
+type Team struct {
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	Owners        []User  `json:"owners"`
+	RecordOverall *Record `json:"recordOverall"`
+	PointsFor     *Value  `json:"pointsFor"`
+}
+
+type User struct {
+	ID          int64  `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type Record struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Ties   int `json:"ties"`
+}
+
+type Value struct {
+	Value     float64 `json:"value"`
+	Formatted string  `json:"formatted"`
+}
+
+type Score struct {
+	Score *Value `json:"score"`
+}
+
+type UserLeagues struct {
+	Leagues []League `json:"leagues"`
+}
+
+type LeagueScoreboard struct {
+	Games []Game `json:"games"`
+}
+
+type Game struct {
+	ID        string `json:"id"`
+	Away      *Team  `json:"away"`
+	Home      *Team  `json:"home"`
+	AwayScore *Score `json:"awayScore"`
+	HomeScore *Score `json:"homeScore"`
+}
+
+type LeagueBoxscore struct {
+	Lineups []Lineup `json:"lineups"`
+}
+
+type Lineup struct {
+	Group string `json:"group"` // START or BENCH
+	Slots []Slot `json:"slots"`
+}
+
+type Slot struct {
+	Position *RosterPosition `json:"position"`
+	Home     *LeaguePlayer   `json:"home"`
+	Away     *LeaguePlayer   `json:"away"`
+}
+
+type LeaguePlayer struct {
+	ProPlayer           *ProPlayer `json:"proPlayer"`
+	ViewingActualPoints *Value     `json:"viewingActualPoints"`
+}
+
+type ProPlayer struct {
+	ID                  int64  `json:"id"`
+	NameFull            string `json:"nameFull"`
+	NameFirst           string `json:"nameFirst"`
+	NameLast            string `json:"nameLast"`
+	Position            string `json:"position"`
+	ProTeamAbbreviation string `json:"proTeamAbbreviation"`
+}
+
+type LeagueRosters struct {
+	Rosters []Roster `json:"rosters"`
+}
+
+type Roster struct {
+	Team    *Team          `json:"team"`
+	Players []LeaguePlayer `json:"players"`
+}
+
+type LeagueRules struct {
+	RosterPositions []RosterPosition `json:"rosterPositions"`
+}
+
+type RosterPosition struct {
+	Label       string   `json:"label"`
+	Group       string   `json:"group"` // START, BENCH or INJURED
+	Eligibility []string `json:"eligibility"`
+	Start       int      `json:"start"`
+}
+
+type Error struct {
+	ErrorMessage string `json:"errorMessage"`
+}
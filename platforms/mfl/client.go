@@ -0,0 +1,445 @@
+package mfl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/platforms/mfl/internal"
+)
+
+const MFLURL = "https://api.myfantasyleague.com"
+
+type Client interface {
+	// Get all of the leagues for the user that owns the API key for a year.
+	GetLeaguesForUser(year string) ([]model.League, error)
+
+	GetLeagueName(leagueID, year string) (string, error)
+
+	// Get all of the franchises for a specific league.
+	GetLeagueManagers(leagueID, year string) ([]model.LeagueManager, error)
+
+	// Sort the managers in a stable and logical order.
+	SortManagers(m []model.LeagueManager)
+
+	// Get the matchup for a specific week for a league. Also returns the individual
+	// scores for all the players. Players are identified by their MFL id and need
+	// to be converted before they can be saved.
+	GetMatchupResults(leagueID, year string, week int) ([]model.Matchup, []PlayerScore, error)
+
+	// Load the rosters for all franchises. Players are identified by their MFL id.
+	GetRosters(leagueID, year string) ([]Roster, error)
+
+	// Get a list of all the positions a user needs to start in the league.
+	// This is used to select a starting lineup in the power rankings.
+	GetStarters(leagueID, year string) ([]model.RosterSpot, error)
+
+	GetLeagueStandings(leagueID, year string) ([]model.LeagueStanding, error)
+}
+
+// PlayerScore is the score for a single player identified by their MFL id.
+type PlayerScore struct {
+	Player model.MFLPlayer
+	Score  int32
+}
+
+// Roster is all of the players on a franchise identified by their MFL ids.
+type Roster struct {
+	TeamID  string
+	Players []model.MFLPlayer
+}
+
+type client struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// Create a new MFL client. The apiKey is only needed to list a user's leagues
+// and to access private leagues.
+func New(apiKey string) (Client, error) {
+	c := &client{
+		url:    MFLURL,
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Timeout: 1 * time.Minute,
+		},
+	}
+	return c, nil
+}
+
+func NewForTest(url string) Client {
+	return &client{
+		url:        url,
+		apiKey:     "test_api_key",
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (c *client) GetLeaguesForUser(year string) ([]model.League, error) {
+	if c.apiKey == "" {
+		return nil, errors.New("an MFL api key is required to list leagues")
+	}
+
+	resp, err := c.mflRequest(year, "myleagues", url.Values{"YEAR": {year}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Leagues == nil || len(resp.Leagues.League) == 0 {
+		return nil, errors.New("no leagues found")
+	}
+
+	res := make([]model.League, 0, len(resp.Leagues.League))
+	for _, l := range resp.Leagues.League {
+		res = append(res, model.League{
+			Platform:   model.PlatformMFL,
+			ExternalID: l.LeagueID,
+			Name:       l.Name,
+			Year:       year,
+			Archived:   false,
+		})
+	}
+	return res, nil
+}
+
+func (c *client) GetLeagueName(leagueID, year string) (string, error) {
+	league, err := c.getLeague(leagueID, year)
+	if err != nil {
+		return "", err
+	}
+	if league.Name == "" {
+		return "", errors.New("league name not found")
+	}
+	return league.Name, nil
+}
+
+func (c *client) GetLeagueManagers(leagueID, year string) ([]model.LeagueManager, error) {
+	league, err := c.getLeague(leagueID, year)
+	if err != nil {
+		return nil, err
+	}
+	if league.Franchises == nil || len(league.Franchises.Franchise) == 0 {
+		return nil, errors.New("no managers found")
+	}
+
+	resp := make([]model.LeagueManager, 0, len(league.Franchises.Franchise))
+	for _, f := range league.Franchises.Franchise {
+		m := model.LeagueManager{
+			ExternalID:  f.ID,
+			TeamName:    f.Name,
+			ManagerName: f.OwnerName,
+		}
+		resp = append(resp, m)
+	}
+	c.SortManagers(resp)
+	return resp, nil
+}
+
+func (c *client) SortManagers(m []model.LeagueManager) {
+	// MFL franchise ids are zero padded numbers like 0001
+	slices.SortFunc(m, func(a, b model.LeagueManager) int {
+		ai, e1 := strconv.Atoi(a.ExternalID)
+		bi, e2 := strconv.Atoi(b.ExternalID)
+		if err := errors.Join(e1, e2); err != nil {
+			return 0
+		}
+		return ai - bi
+	})
+}
+
+func (c *client) GetMatchupResults(leagueID, year string, week int) ([]model.Matchup, []PlayerScore, error) {
+	resp, err := c.mflRequest(year, "weeklyResults", url.Values{"L": {leagueID}, "W": {fmt.Sprint(week)}})
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.WeeklyResults == nil || len(resp.WeeklyResults.Matchup) == 0 {
+		return nil, nil, fmt.Errorf("no matchups found for week %d", week)
+	}
+
+	matches := make([]model.Matchup, 0, len(resp.WeeklyResults.Matchup))
+	scores := make(map[string]int32)
+	playerIDs := make([]string, 0, 128)
+	for i, m := range resp.WeeklyResults.Matchup {
+		if len(m.Franchise) != 2 {
+			return nil, nil, errors.New("at least one matchup is not complete with 2 teams")
+		}
+
+		teams := make([]*model.TeamResult, 0, 2)
+		for _, f := range m.Franchise {
+			score, err := parseScore(f.Score)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing score for franchise %s: %w", f.ID, err)
+			}
+			teams = append(teams, &model.TeamResult{TeamID: f.ID, Score: score})
+
+			for _, p := range f.Player {
+				s, err := parseScore(p.Score)
+				if err != nil {
+					return nil, nil, fmt.Errorf("error parsing score for player %s: %w", p.ID, err)
+				}
+				scores[p.ID] = s
+				playerIDs = append(playerIDs, p.ID)
+			}
+		}
+
+		matches = append(matches, model.Matchup{
+			TeamA:     teams[0],
+			TeamB:     teams[1],
+			MatchupID: int32(i + 1),
+			Week:      week,
+		})
+	}
+
+	players, err := c.getPlayers(year, playerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	playerScores := make([]PlayerScore, 0, len(playerIDs))
+	for _, id := range playerIDs {
+		playerScores = append(playerScores, PlayerScore{Player: players[id], Score: scores[id]})
+	}
+	return matches, playerScores, nil
+}
+
+func (c *client) GetRosters(leagueID, year string) ([]Roster, error) {
+	resp, err := c.mflRequest(year, "rosters", url.Values{"L": {leagueID}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rosters == nil {
+		return nil, errors.New("no rosters found")
+	}
+
+	playerIDs := make([]string, 0, 256)
+	for _, f := range resp.Rosters.Franchise {
+		for _, p := range f.Player {
+			playerIDs = append(playerIDs, p.ID)
+		}
+	}
+	players, err := c.getPlayers(year, playerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Roster, 0, len(resp.Rosters.Franchise))
+	for _, f := range resp.Rosters.Franchise {
+		r := Roster{
+			TeamID:  f.ID,
+			Players: make([]model.MFLPlayer, 0, len(f.Player)),
+		}
+		for _, p := range f.Player {
+			r.Players = append(r.Players, players[p.ID])
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// MFL starting lineups are a total count of starters along with a limit for each
+// position. The limit is either a fixed number or a range like "2-3". Every position
+// gets spots for its minimum, and the remaining spots are flex spots that can be filled
+// by any position that allows more than its minimum.
+func (c *client) GetStarters(leagueID, year string) ([]model.RosterSpot, error) {
+	league, err := c.getLeague(leagueID, year)
+	if err != nil {
+		return nil, err
+	}
+	if league.Starters == nil || len(league.Starters.Position) == 0 {
+		return nil, errors.New("league has no starting positions")
+	}
+
+	total, err := strconv.Atoi(league.Starters.Count)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing starter count '%s': %w", league.Starters.Count, err)
+	}
+
+	response := make([]model.RosterSpot, 0, total)
+	flex := model.RosterSpot{}
+	for _, p := range league.Starters.Position {
+		pos := parsePosition(p.Name)
+		if pos == model.POS_UNKNOWN {
+			continue
+		}
+		minCount, maxCount, err := parseLimit(p.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for range minCount {
+			response = append(response, model.RosterSpot{Allowed: []model.Position{pos}})
+		}
+		if maxCount > minCount {
+			flex.Allowed = append(flex.Allowed, pos)
+		}
+	}
+
+	for len(response) < total && len(flex.Allowed) > 0 {
+		response = append(response, flex)
+	}
+
+	if len(response) == 0 {
+		return nil, errors.New("no roster positions found")
+	}
+	return response, nil
+}
+
+func (c *client) GetLeagueStandings(leagueID, year string) ([]model.LeagueStanding, error) {
+	resp, err := c.mflRequest(year, "leagueStandings", url.Values{"L": {leagueID}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.LeagueStandings == nil {
+		return nil, errors.New("no standings found")
+	}
+
+	results := make([]model.LeagueStanding, 0, len(resp.LeagueStandings.Franchise))
+	for _, f := range resp.LeagueStandings.Franchise {
+		s := model.LeagueStanding{TeamID: f.ID}
+		var pf float64
+		var e1, e2, e3, e4 error
+		s.Wins, e1 = strconv.Atoi(f.Wins)
+		s.Losses, e2 = strconv.Atoi(f.Losses)
+		s.Draws, e3 = strconv.Atoi(f.Ties)
+		pf, e4 = strconv.ParseFloat(f.PF, 64)
+		if err := errors.Join(e1, e2, e3, e4); err != nil {
+			return nil, fmt.Errorf("error parsing standings for franchise %s: %w", f.ID, err)
+		}
+		s.Scored = fmt.Sprintf("%.2f", pf)
+		results = append(results, s)
+	}
+
+	model.SortStandings(results)
+	return results, nil
+}
+
+func (c *client) getLeague(leagueID, year string) (*internal.League, error) {
+	resp, err := c.mflRequest(year, "league", url.Values{"L": {leagueID}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.League == nil {
+		return nil, fmt.Errorf("league %s not found", leagueID)
+	}
+	return resp.League, nil
+}
+
+// Look up the details of all the players by their MFL ids.
+func (c *client) getPlayers(year string, ids []string) (map[string]model.MFLPlayer, error) {
+	results := make(map[string]model.MFLPlayer)
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	resp, err := c.mflRequest(year, "players", url.Values{"PLAYERS": {strings.Join(ids, ",")}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Players != nil {
+		for _, p := range resp.Players.Player {
+			results[p.ID] = toMFLPlayer(&p)
+		}
+	}
+
+	for _, id := range ids {
+		if _, found := results[id]; !found {
+			return nil, fmt.Errorf("player details not found for mfl id %s", id)
+		}
+	}
+	return results, nil
+}
+
+// Sends an export request to MFL and uses a JSON parser to read the result.
+// Returns an error if any, if the status code of the result is not 200, or
+// if MFL returned an error message.
+func (c *client) mflRequest(year, requestType string, args url.Values) (*internal.Response, error) {
+	args.Set("TYPE", requestType)
+	args.Set("JSON", "1")
+	if c.apiKey != "" {
+		args.Set("APIKEY", c.apiKey)
+	}
+
+	u := fmt.Sprintf("%s/%s/export?%s", c.url, year, args.Encode())
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating mfl http request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending mfl http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from mfl: %d", resp.StatusCode)
+	}
+
+	var res internal.Response
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("error parsing response from mfl: %w", err)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("error from mfl: %s", res.Error.Message)
+	}
+
+	return &res, nil
+}
+
+func toMFLPlayer(p *internal.Player) model.MFLPlayer {
+	res := model.MFLPlayer{
+		MFLID: p.ID,
+		Pos:   parsePosition(p.Position),
+	}
+
+	// Names are in the "Last, First" format. Defenses are "Seahawks, Seattle".
+	last, first, found := strings.Cut(p.Name, ",")
+	if found {
+		res.FirstName = strings.TrimSpace(first)
+		res.LastName = strings.TrimSpace(last)
+	} else {
+		res.LastName = strings.TrimSpace(p.Name)
+	}
+	return res
+}
+
+func parsePosition(pos string) model.Position {
+	switch strings.ToUpper(pos) {
+	case "PK":
+		return model.POS_K
+	default:
+		return model.ParsePosition(pos)
+	}
+}
+
+// Parse a starter limit, which is either "1" or a range like "2-3".
+func parseLimit(limit string) (int, int, error) {
+	minStr, maxStr, isRange := strings.Cut(limit, "-")
+	if !isRange {
+		maxStr = minStr
+	}
+
+	minCount, e1 := strconv.Atoi(strings.TrimSpace(minStr))
+	maxCount, e2 := strconv.Atoi(strings.TrimSpace(maxStr))
+	if err := errors.Join(e1, e2); err != nil {
+		return 0, 0, fmt.Errorf("error parsing starter limit '%s': %w", limit, err)
+	}
+	return minCount, maxCount, nil
+}
+
+func parseScore(score string) (int32, error) {
+	if score == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int32(math.Round(f * 1000)), nil
+}
@@ -0,0 +1,242 @@
+package mfl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mww/fantasy_manager_v2/model"
+	"github.com/mww/fantasy_manager_v2/platforms/mfl/internal"
+	"github.com/mww/fantasy_manager_v2/testutils"
+)
+
+func TestGetLeaguesForUser(t *testing.T) {
+	fakeMFL := testutils.NewFakeMFLServer()
+	defer fakeMFL.Close()
+
+	c := NewForTest(fakeMFL.URL())
+
+	leagues, err := c.GetLeaguesForUser("2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting leagues: %v", err)
+	}
+
+	expected := []model.League{
+		{Platform: model.PlatformMFL, ExternalID: testutils.MFLLeagueID, Name: "MFL Dynasty League", Year: "2024"},
+	}
+	if !reflect.DeepEqual(expected, leagues) {
+		t.Errorf("expected: %v, got: %v", expected, leagues)
+	}
+
+	noKey, _ := New("")
+	if _, err := noKey.GetLeaguesForUser("2024"); err == nil {
+		t.Errorf("expected an error when there is no api key")
+	}
+}
+
+func TestGetLeagueName(t *testing.T) {
+	fakeMFL := testutils.NewFakeMFLServer()
+	defer fakeMFL.Close()
+
+	c := NewForTest(fakeMFL.URL())
+
+	name, err := c.GetLeagueName(testutils.MFLLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting league name: %v", err)
+	}
+	if name != "MFL Dynasty League" {
+		t.Errorf("league name was not expected value, got: %s", name)
+	}
+
+	if _, err := c.GetLeagueName("987", "2024"); err == nil {
+		t.Errorf("expected an error for an unknown league")
+	}
+}
+
+func TestGetLeagueManagers(t *testing.T) {
+	fakeMFL := testutils.NewFakeMFLServer()
+	defer fakeMFL.Close()
+
+	c := NewForTest(fakeMFL.URL())
+
+	managers, err := c.GetLeagueManagers(testutils.MFLLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting managers: %v", err)
+	}
+
+	expected := []model.LeagueManager{
+		{ExternalID: "0001", TeamName: "Dynasty Dawgs", ManagerName: "Alice"},
+		{ExternalID: "0002", TeamName: "Kicking It", ManagerName: "Bob"},
+		{ExternalID: "0003", TeamName: "Lockett Smith", ManagerName: "Carol"},
+		{ExternalID: "0004", TeamName: "Bottom Feeders", ManagerName: "Dave"},
+	}
+	if !reflect.DeepEqual(expected, managers) {
+		t.Errorf("expected: %v, got: %v", expected, managers)
+	}
+}
+
+func TestGetMatchupResults(t *testing.T) {
+	fakeMFL := testutils.NewFakeMFLServer()
+	defer fakeMFL.Close()
+
+	c := NewForTest(fakeMFL.URL())
+
+	matchups, scores, err := c.GetMatchupResults(testutils.MFLLeagueID, "2024", 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting matchup results: %v", err)
+	}
+
+	expected := []model.Matchup{
+		{
+			MatchupID: 1,
+			Week:      1,
+			TeamA:     &model.TeamResult{TeamID: "0001", Score: 49860},
+			TeamB:     &model.TeamResult{TeamID: "0002", Score: 33700},
+		},
+		{
+			MatchupID: 2,
+			Week:      1,
+			TeamA:     &model.TeamResult{TeamID: "0003", Score: 38200},
+			TeamB:     &model.TeamResult{TeamID: "0004", Score: 36120},
+		},
+	}
+	if !reflect.DeepEqual(expected, matchups) {
+		t.Errorf("expected: %v, got: %v", expected, matchups)
+	}
+
+	if len(scores) != 12 {
+		t.Fatalf("expected 12 player scores, got %d", len(scores))
+	}
+	hurts := PlayerScore{
+		Player: model.MFLPlayer{MFLID: "14107", FirstName: "Jalen", LastName: "Hurts", Pos: model.POS_QB},
+		Score:  24560,
+	}
+	if !reflect.DeepEqual(hurts, scores[0]) {
+		t.Errorf("expected: %v, got: %v", hurts, scores[0])
+	}
+
+	if _, _, err := c.GetMatchupResults(testutils.MFLLeagueID, "2024", 5); err == nil {
+		t.Errorf("expected an error for a week without results")
+	}
+}
+
+func TestGetRosters(t *testing.T) {
+	fakeMFL := testutils.NewFakeMFLServer()
+	defer fakeMFL.Close()
+
+	c := NewForTest(fakeMFL.URL())
+
+	rosters, err := c.GetRosters(testutils.MFLLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting rosters: %v", err)
+	}
+	if len(rosters) != 4 {
+		t.Fatalf("expected 4 rosters, got %d", len(rosters))
+	}
+
+	expected := Roster{
+		TeamID: "0001",
+		Players: []model.MFLPlayer{
+			{MFLID: "14107", FirstName: "Jalen", LastName: "Hurts", Pos: model.POS_QB},
+			{MFLID: "16605", FirstName: "Bijan", LastName: "Robinson", Pos: model.POS_RB},
+			{MFLID: "0521", FirstName: "Seattle", LastName: "Seahawks", Pos: model.POS_DEF},
+		},
+	}
+	if !reflect.DeepEqual(expected, rosters[0]) {
+		t.Errorf("expected: %v, got: %v", expected, rosters[0])
+	}
+}
+
+func TestGetStarters(t *testing.T) {
+	fakeMFL := testutils.NewFakeMFLServer()
+	defer fakeMFL.Close()
+
+	c := NewForTest(fakeMFL.URL())
+
+	starters, err := c.GetStarters(testutils.MFLLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting starters: %v", err)
+	}
+
+	expected := []model.RosterSpot{
+		model.GetRosterSpot("QB"),
+		model.GetRosterSpot("RB"),
+		model.GetRosterSpot("RB"),
+		model.GetRosterSpot("WR"),
+		model.GetRosterSpot("WR"),
+		model.GetRosterSpot("TE"),
+		model.GetRosterSpot("K"),
+		model.GetRosterSpot("DEF"),
+		model.GetRosterSpot("FLEX"),
+	}
+	if !reflect.DeepEqual(expected, starters) {
+		t.Errorf("expected: %v, got: %v", expected, starters)
+	}
+}
+
+func TestGetLeagueStandings(t *testing.T) {
+	fakeMFL := testutils.NewFakeMFLServer()
+	defer fakeMFL.Close()
+
+	c := NewForTest(fakeMFL.URL())
+
+	standings, err := c.GetLeagueStandings(testutils.MFLLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting standings: %v", err)
+	}
+
+	expected := []model.LeagueStanding{
+		{TeamID: "0003", Rank: 1, Wins: 10, Losses: 3, Scored: "1600.00"},
+		{TeamID: "0001", Rank: 2, Wins: 8, Losses: 5, Scored: "1500.12"},
+		{TeamID: "0002", Rank: 3, Wins: 8, Losses: 5, Scored: "1450.50"},
+		{TeamID: "0004", Rank: 4, Wins: 0, Losses: 13, Scored: "1000.00"},
+	}
+	if !reflect.DeepEqual(expected, standings) {
+		t.Errorf("expected: %v, got: %v", expected, standings)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		input    string
+		min, max int
+		err      bool
+	}{
+		{input: "1", min: 1, max: 1},
+		{input: "2-3", min: 2, max: 3},
+		{input: "0-2", min: 0, max: 2},
+		{input: "a-2", err: true},
+		{input: "", err: true},
+	}
+
+	for _, tc := range tests {
+		minCount, maxCount, err := parseLimit(tc.input)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error parsing '%s'", tc.input)
+			}
+			continue
+		}
+		if err != nil || minCount != tc.min || maxCount != tc.max {
+			t.Errorf("parseLimit(%s) - expected %d, %d, got %d, %d (err: %v)", tc.input, tc.min, tc.max, minCount, maxCount, err)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	var single internal.Franchises
+	if err := json.Unmarshal([]byte(`{"franchise": {"id": "0001"}}`), &single); err != nil {
+		t.Fatalf("error parsing single franchise: %v", err)
+	}
+	if len(single.Franchise) != 1 || single.Franchise[0].ID != "0001" {
+		t.Errorf("unexpected result for single franchise: %v", single)
+	}
+
+	var multiple internal.Franchises
+	if err := json.Unmarshal([]byte(`{"franchise": [{"id": "0001"}, {"id": "0002"}]}`), &multiple); err != nil {
+		t.Fatalf("error parsing multiple franchises: %v", err)
+	}
+	if len(multiple.Franchise) != 2 || multiple.Franchise[1].ID != "0002" {
+		t.Errorf("unexpected result for multiple franchises: %v", multiple)
+	}
+}
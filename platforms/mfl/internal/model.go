@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// The structures in this file model the JSON responses from the MFL export API.
+// MFL returns all values as strings, and when a list only has a single element
+// it is returned as an object instead of an array. List handles that case.
+
+// List is a JSON list that MFL may encode as a single object when it has only one element.
+type List[T any] []T
+
+func (l *List[T]) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(b, &items); err != nil {
+			return err
+		}
+		*l = items
+		return nil
+	}
+
+	var item T
+	if err := json.Unmarshal(b, &item); err != nil {
+		return err
+	}
+	*l = []T{item}
+	return nil
+}
+
+type Response struct {
+	Error           *Error           `json:"error"`
+	League          *League          `json:"league"`
+	Leagues         *Leagues         `json:"leagues"`
+	WeeklyResults   *WeeklyResults   `json:"weeklyResults"`
+	Rosters         *Rosters         `json:"rosters"`
+	Players         *Players         `json:"players"`
+	LeagueStandings *LeagueStandings `json:"leagueStandings"`
+}
+
+type Error struct {
+	Message string `json:"$t"`
+}
+
+type League struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Franchises *Franchises `json:"franchises"`
+	Starters   *Starters   `json:"starters"`
+}
+
+type Franchises struct {
+	Franchise List[Franchise] `json:"franchise"`
+}
+
+type Franchise struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	OwnerName string `json:"owner_name"`
+}
+
+type Starters struct {
+	Count    string                `json:"count"`
+	Position List[StarterPosition] `json:"position"`
+}
+
+type StarterPosition struct {
+	Name  string `json:"name"`
+	Limit string `json:"limit"` // Either a single number "1" or a range "1-3"
+}
+
+type Leagues struct {
+	League List[LeagueSummary] `json:"league"`
+}
+
+type LeagueSummary struct {
+	LeagueID string `json:"league_id"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+}
+
+type WeeklyResults struct {
+	Week    string        `json:"week"`
+	Matchup List[Matchup] `json:"matchup"`
+}
+
+type Matchup struct {
+	Franchise List[FranchiseResult] `json:"franchise"`
+}
+
+type FranchiseResult struct {
+	ID     string             `json:"id"`
+	Score  string             `json:"score"`
+	Result string             `json:"result"`
+	Player List[PlayerResult] `json:"player"`
+}
+
+type PlayerResult struct {
+	ID     string `json:"id"`
+	Score  string `json:"score"`
+	Status string `json:"status"`
+}
+
+type Rosters struct {
+	Franchise List[FranchiseRoster] `json:"franchise"`
+}
+
+type FranchiseRoster struct {
+	ID     string             `json:"id"`
+	Player List[RosterPlayer] `json:"player"`
+}
+
+type RosterPlayer struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type Players struct {
+	Player List[Player] `json:"player"`
+}
+
+type Player struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"` // In "Last, First" format
+	Position string `json:"position"`
+	Team     string `json:"team"`
+}
+
+type LeagueStandings struct {
+	Franchise List[FranchiseStanding] `json:"franchise"`
+}
+
+type FranchiseStanding struct {
+	ID     string `json:"id"`
+	Wins   string `json:"h2hw"`
+	Losses string `json:"h2hl"`
+	Ties   string `json:"h2ht"`
+	PF     string `json:"pf"`
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
 	"slices"
 	"strconv"
@@ -15,6 +16,9 @@ import (
 
 const SleeperURL = "https://api.sleeper.app"
 
+// The last week of the NFL regular season, used for leagues without playoffs.
+const lastRegularSeasonWeek = 18
+
 type Client interface {
 	LoadPlayers() ([]model.Player, error)
 
@@ -26,6 +30,13 @@ type Client interface {
 
 	GetLeagueName(leagueID string) (string, error)
 
+	// Sleeper creates a new league every season. Get the league for the season
+	// before this one, or nil if this is the first season of the league.
+	GetPreviousLeague(leagueID string) (*model.League, error)
+
+	// Get the roster id of the league champion, or "" if the season isn't over.
+	GetLeagueWinner(leagueID string) (string, error)
+
 	// Get all of the league managers for a specific league.
 	GetLeagueManagers(leagueID string) ([]model.LeagueManager, error)
 
@@ -36,6 +47,10 @@ type Client interface {
 	// Also returns the individual scores for all the players.
 	GetMatchupResults(leagueID string, week int) ([]model.Matchup, []model.PlayerScore, error)
 
+	// Get the regular season matchups for every week after week, the scores are
+	// not set. The join key of each team is the roster id.
+	GetSchedule(leagueID string, week int) ([]model.Matchup, error)
+
 	// Load the rosters for all users.
 	GetRosters(leagueID string) ([]model.Roster, error)
 
@@ -44,6 +59,14 @@ type Client interface {
 	GetStarters(leagueID string) ([]model.RosterSpot, error)
 
 	GetLeagueStandings(leagueID string) ([]model.LeagueStanding, error)
+
+	// Get the completed transactions for a week. The team ids in the assets are
+	// the roster ids, which is the join key for the league managers.
+	GetTransactions(leagueID string, week int) ([]model.Transaction, error)
+
+	// Get all of the completed drafts for a league along with their picks. The team
+	// ids of the picks are the roster ids, which is the join key for the league managers.
+	GetDrafts(leagueID string) ([]model.Draft, error)
 }
 
 type client struct {
@@ -144,6 +167,50 @@ func (c *client) GetLeagueName(leagueID string) (string, error) {
 	return league.Name, nil
 }
 
+func (c *client) GetPreviousLeague(leagueID string) (*model.League, error) {
+	var league struct {
+		PreviousLeagueID string `json:"previous_league_id"`
+	}
+	if err := c.sleeperRequest(&league, "/v1/league/%s", leagueID); err != nil {
+		return nil, err
+	}
+	// The first season of a league either has no previous id or it is "0"
+	if league.PreviousLeagueID == "" || league.PreviousLeagueID == "0" {
+		return nil, nil
+	}
+
+	var prev struct {
+		LeagueID string `json:"league_id"`
+		Name     string `json:"name"`
+		Season   string `json:"season"`
+	}
+	if err := c.sleeperRequest(&prev, "/v1/league/%s", league.PreviousLeagueID); err != nil {
+		return nil, err
+	}
+	if prev.LeagueID == "" {
+		return nil, fmt.Errorf("previous league %s not found", league.PreviousLeagueID)
+	}
+
+	return &model.League{
+		Platform:   model.PlatformSleeper,
+		ExternalID: prev.LeagueID,
+		Name:       prev.Name,
+		Year:       prev.Season,
+	}, nil
+}
+
+func (c *client) GetLeagueWinner(leagueID string) (string, error) {
+	var league struct {
+		Metadata struct {
+			WinnerRosterID string `json:"latest_league_winner_roster_id"`
+		} `json:"metadata"`
+	}
+	if err := c.sleeperRequest(&league, "/v1/league/%s", leagueID); err != nil {
+		return "", err
+	}
+	return league.Metadata.WinnerRosterID, nil
+}
+
 func (c *client) GetLeagueManagers(leagueID string) ([]model.LeagueManager, error) {
 	var rosters []struct {
 		OwnerID  string `json:"owner_id"`
@@ -262,6 +329,38 @@ func (c *client) GetMatchupResults(leagueID string, week int) ([]model.Matchup,
 	return matches, playerScores, nil
 }
 
+func (c *client) GetSchedule(leagueID string, week int) ([]model.Matchup, error) {
+	var league struct {
+		Settings struct {
+			PlayoffWeekStart int `json:"playoff_week_start"`
+		} `json:"settings"`
+	}
+	if err := c.sleeperRequest(&league, "/v1/league/%s", leagueID); err != nil {
+		return nil, err
+	}
+
+	// Sleeper doesn't put the playoff matchups in the weekly matchups, so stop
+	// at the start of the playoffs.
+	end := lastRegularSeasonWeek + 1
+	if league.Settings.PlayoffWeekStart > 0 {
+		end = league.Settings.PlayoffWeekStart
+	}
+
+	results := make([]model.Matchup, 0, max(end-week, 0)*6)
+	for w := week + 1; w < end; w++ {
+		matchups, _, err := c.GetMatchupResults(leagueID, w)
+		if err != nil {
+			return nil, fmt.Errorf("error getting matchups for week %d: %w", w, err)
+		}
+		for _, m := range matchups {
+			m.TeamA.Score = 0
+			m.TeamB.Score = 0
+			results = append(results, m)
+		}
+	}
+	return results, nil
+}
+
 func (c *client) GetRosters(leagueID string) ([]model.Roster, error) {
 	var rosters []struct {
 		OwnerID string   `json:"owner_id"`
@@ -328,32 +427,172 @@ func (c *client) GetLeagueStandings(leagueID string) ([]model.LeagueStanding, er
 		results = append(results, s)
 	}
 
-	// Sort in order:
-	// - Highest number of wins
-	// - Smaller number of losses
-	// - Fantasy points scored
-	slices.SortFunc(results, func(a, b model.LeagueStanding) int {
-		if a.Wins == b.Wins {
-			if a.Losses == b.Losses {
-				fa, e1 := strconv.ParseFloat(a.Scored, 64)
-				fb, e2 := strconv.ParseFloat(b.Scored, 64)
-				if err := errors.Join(e1, e2); err != nil {
-					log.Printf("error parsing points scored: %v", err)
-					return 0
-				}
-				if fa > fb {
-					return -1
-				} else if fb > fa {
-					return 1
-				}
-				return 0
+	model.SortStandings(results)
+	return results, nil
+}
+
+func (c *client) GetTransactions(leagueID string, week int) ([]model.Transaction, error) {
+	type draftPick struct {
+		Season          string `json:"season"`
+		Round           int    `json:"round"`
+		RosterID        int    `json:"roster_id"`
+		PreviousOwnerID int    `json:"previous_owner_id"`
+		OwnerID         int    `json:"owner_id"`
+	}
+	type budget struct {
+		Sender   int   `json:"sender"`
+		Receiver int   `json:"receiver"`
+		Amount   int32 `json:"amount"`
+	}
+	type settings struct {
+		WaiverBid int32 `json:"waiver_bid"`
+	}
+	var data []struct {
+		TransactionID string         `json:"transaction_id"`
+		Type          string         `json:"type"`
+		Status        string         `json:"status"`
+		StatusUpdated int64          `json:"status_updated"`
+		Settings      *settings      `json:"settings"`
+		Adds          map[string]int `json:"adds"`
+		Drops         map[string]int `json:"drops"`
+		DraftPicks    []draftPick    `json:"draft_picks"`
+		WaiverBudget  []budget       `json:"waiver_budget"`
+	}
+	if err := c.sleeperRequest(&data, "/v1/league/%s/transactions/%d", leagueID, week); err != nil {
+		return nil, err
+	}
+
+	results := make([]model.Transaction, 0, len(data))
+	for _, d := range data {
+		if d.Status != "complete" {
+			continue
+		}
+
+		t := model.Transaction{
+			ExternalID: d.TransactionID,
+			Week:       week,
+			Processed:  time.UnixMilli(d.StatusUpdated).UTC(),
+		}
+		switch {
+		case d.Type == "trade":
+			t.Type = model.TransactionTrade
+		case d.Type == "waiver":
+			t.Type = model.TransactionWaiver
+		case len(d.Adds) > 0:
+			t.Type = model.TransactionAdd
+		default:
+			t.Type = model.TransactionDrop
+		}
+		if d.Settings != nil {
+			t.WaiverBid = d.Settings.WaiverBid
+		}
+
+		// A player in a trade shows up in both adds and drops. Sort the
+		// player ids so the order of the assets is stable.
+		for _, id := range slices.Sorted(maps.Keys(d.Adds)) {
+			a := model.TransactionAsset{Type: model.AssetPlayer, PlayerID: id, ToTeamID: fmt.Sprint(d.Adds[id])}
+			if from, found := d.Drops[id]; found {
+				a.FromTeamID = fmt.Sprint(from)
+			}
+			t.Assets = append(t.Assets, a)
+		}
+		for _, id := range slices.Sorted(maps.Keys(d.Drops)) {
+			if _, found := d.Adds[id]; !found {
+				t.Assets = append(t.Assets, model.TransactionAsset{Type: model.AssetPlayer, PlayerID: id, FromTeamID: fmt.Sprint(d.Drops[id])})
 			}
-			return a.Losses - b.Losses
 		}
-		return b.Wins - a.Wins
+		for _, p := range d.DraftPicks {
+			t.Assets = append(t.Assets, model.TransactionAsset{
+				Type:       model.AssetDraftPick,
+				FromTeamID: fmt.Sprint(p.PreviousOwnerID),
+				ToTeamID:   fmt.Sprint(p.OwnerID),
+				PickSeason: p.Season,
+				PickRound:  p.Round,
+				PickTeamID: fmt.Sprint(p.RosterID),
+			})
+		}
+		for _, b := range d.WaiverBudget {
+			t.Assets = append(t.Assets, model.TransactionAsset{
+				Type:       model.AssetFAAB,
+				FromTeamID: fmt.Sprint(b.Sender),
+				ToTeamID:   fmt.Sprint(b.Receiver),
+				FAAB:       b.Amount,
+			})
+		}
+
+		results = append(results, t)
+	}
+
+	slices.SortFunc(results, func(a, b model.Transaction) int {
+		return a.Processed.Compare(b.Processed)
 	})
-	for i := range results {
-		results[i].Rank = i + 1
+	return results, nil
+}
+
+func (c *client) GetDrafts(leagueID string) ([]model.Draft, error) {
+	var drafts []struct {
+		DraftID   string `json:"draft_id"`
+		Type      string `json:"type"`
+		Status    string `json:"status"`
+		StartTime int64  `json:"start_time"`
+		Settings  struct {
+			Rounds int `json:"rounds"`
+		} `json:"settings"`
+	}
+	if err := c.sleeperRequest(&drafts, "/v1/league/%s/drafts", leagueID); err != nil {
+		return nil, err
+	}
+
+	results := make([]model.Draft, 0, len(drafts))
+	for _, d := range drafts {
+		if d.Status != "complete" {
+			continue
+		}
+
+		draft := model.Draft{
+			ExternalID: d.DraftID,
+			Type:       model.DraftType(d.Type),
+			Rounds:     d.Settings.Rounds,
+			Started:    time.UnixMilli(d.StartTime).UTC(),
+		}
+
+		var picks []struct {
+			Round    int    `json:"round"`
+			PickNo   int    `json:"pick_no"`
+			RosterID int    `json:"roster_id"`
+			PlayerID string `json:"player_id"`
+			IsKeeper *bool  `json:"is_keeper"`
+			Metadata struct {
+				Amount string `json:"amount"`
+			} `json:"metadata"`
+		}
+		if err := c.sleeperRequest(&picks, "/v1/draft/%s/picks", d.DraftID); err != nil {
+			return nil, err
+		}
+
+		for _, p := range picks {
+			pick := model.DraftPick{
+				Round:    p.Round,
+				Pick:     p.PickNo,
+				TeamID:   fmt.Sprint(p.RosterID),
+				PlayerID: p.PlayerID,
+				IsKeeper: p.IsKeeper != nil && *p.IsKeeper,
+			}
+			// The amount is an empty string for drafts that aren't auctions
+			if p.Metadata.Amount != "" {
+				cost, err := strconv.Atoi(p.Metadata.Amount)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing auction amount for pick %d: %w", p.PickNo, err)
+				}
+				pick.Cost = int32(cost)
+			}
+			draft.Picks = append(draft.Picks, pick)
+		}
+		slices.SortFunc(draft.Picks, func(a, b model.DraftPick) int {
+			return a.Pick - b.Pick
+		})
+
+		results = append(results, draft)
 	}
 	return results, nil
 }
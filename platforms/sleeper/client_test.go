@@ -9,6 +9,7 @@ import (
 	"slices"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/mww/fantasy_manager_v2/model"
 	"github.com/mww/fantasy_manager_v2/testutils"
@@ -213,6 +214,55 @@ func TestGetLeagueName(t *testing.T) {
 	}
 }
 
+func TestGetPreviousLeague(t *testing.T) {
+	fakeSleeper := testutils.NewFakeSleeperServer()
+	defer fakeSleeper.Close()
+	c := NewForTest(fakeSleeper.URL())
+
+	prev, err := c.GetPreviousLeague(testutils.SleeperLeagueID)
+	if err != nil {
+		t.Fatalf("unexpected error getting previous league: %v", err)
+	}
+	expected := &model.League{
+		Platform:   model.PlatformSleeper,
+		ExternalID: testutils.SleeperPreviousLeagueID,
+		Name:       "Footclan & Friends Dynasty",
+		Year:       "2022",
+	}
+	if !reflect.DeepEqual(expected, prev) {
+		t.Errorf("expected: %v, got: %v", expected, prev)
+	}
+
+	// The first season of the league doesn't have a previous league
+	prev, err = c.GetPreviousLeague(testutils.SleeperPreviousLeagueID)
+	if err != nil {
+		t.Fatalf("unexpected error getting previous league: %v", err)
+	}
+	if prev != nil {
+		t.Errorf("expected no previous league, got: %v", prev)
+	}
+}
+
+func TestGetLeagueWinner(t *testing.T) {
+	fakeSleeper := testutils.NewFakeSleeperServer()
+	defer fakeSleeper.Close()
+	c := NewForTest(fakeSleeper.URL())
+
+	tests := map[string]string{
+		testutils.SleeperLeagueID:         "7",
+		testutils.SleeperPreviousLeagueID: "1",
+	}
+	for leagueID, expected := range tests {
+		winner, err := c.GetLeagueWinner(leagueID)
+		if err != nil {
+			t.Fatalf("unexpected error getting league winner: %v", err)
+		}
+		if winner != expected {
+			t.Errorf("expected winner of %s to be %s, got: %s", leagueID, expected, winner)
+		}
+	}
+}
+
 func TestGetLeagueManagers(t *testing.T) {
 	fakeSleeper := testutils.NewFakeSleeperServer()
 	defer fakeSleeper.Close()
@@ -307,6 +357,54 @@ func TestGetMatchupResults(t *testing.T) {
 	}
 }
 
+func TestGetSchedule(t *testing.T) {
+	fakeSleeper := testutils.NewFakeSleeperServer()
+	defer fakeSleeper.Close()
+	c := NewForTest(fakeSleeper.URL())
+
+	// The playoffs start in week 15, so only weeks 13 and 14 are left
+	expected := []model.Matchup{
+		{
+			TeamA:     &model.TeamResult{JoinKey: "1"},
+			TeamB:     &model.TeamResult{JoinKey: "6"},
+			MatchupID: 1,
+			Week:      13,
+		},
+		{
+			TeamA:     &model.TeamResult{JoinKey: "4"},
+			TeamB:     &model.TeamResult{JoinKey: "7"},
+			MatchupID: 2,
+			Week:      13,
+		},
+		{
+			TeamA:     &model.TeamResult{JoinKey: "1"},
+			TeamB:     &model.TeamResult{JoinKey: "7"},
+			MatchupID: 1,
+			Week:      14,
+		},
+		{
+			TeamA:     &model.TeamResult{JoinKey: "4"},
+			TeamB:     &model.TeamResult{JoinKey: "6"},
+			MatchupID: 2,
+			Week:      14,
+		},
+	}
+
+	matchups, err := c.GetSchedule(testutils.SleeperLeagueID, 12)
+	if err != nil {
+		t.Fatalf("unexpected error getting schedule: %v", err)
+	}
+	if !reflect.DeepEqual(expected, matchups) {
+		t.Errorf("schedule was not the expected one, got: %v", matchups)
+	}
+
+	// Nothing is left once the regular season is over
+	matchups, err = c.GetSchedule(testutils.SleeperLeagueID, 14)
+	if err != nil || len(matchups) != 0 {
+		t.Errorf("expected an empty schedule, got: %v, err: %v", matchups, err)
+	}
+}
+
 func TestGetRosters(t *testing.T) {
 	fakeSleeper := testutils.NewFakeSleeperServer()
 	defer fakeSleeper.Close()
@@ -426,3 +524,108 @@ func TestGetLeagueStandings(t *testing.T) {
 		t.Errorf("standings are not expected values, got: %v", standings)
 	}
 }
+
+func TestGetTransactions(t *testing.T) {
+	fakeSleeper := testutils.NewFakeSleeperServer()
+	defer fakeSleeper.Close()
+	c := NewForTest(fakeSleeper.URL())
+
+	transactions, err := c.GetTransactions(testutils.SleeperLeagueID, 2)
+	if err != nil {
+		t.Fatalf("error getting transactions: %v", err)
+	}
+
+	expected := []model.Transaction{
+		{
+			ExternalID: "1130418405146984448",
+			Type:       model.TransactionWaiver,
+			Week:       2,
+			Processed:  time.UnixMilli(1726041612345).UTC(),
+			WaiverBid:  17,
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, PlayerID: "10226", ToTeamID: "1"},
+				{Type: model.AssetPlayer, PlayerID: "1339", FromTeamID: "1"},
+			},
+		},
+		{
+			ExternalID: "1130800011112222333",
+			Type:       model.TransactionAdd,
+			Week:       2,
+			Processed:  time.UnixMilli(1726132520000).UTC(),
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, PlayerID: "10871", ToTeamID: "4"},
+			},
+		},
+		{
+			ExternalID: "1130801234567890123",
+			Type:       model.TransactionDrop,
+			Week:       2,
+			Processed:  time.UnixMilli(1726140000000).UTC(),
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, PlayerID: "10444", FromTeamID: "6"},
+			},
+		},
+		{
+			ExternalID: "1131000000000000001",
+			Type:       model.TransactionTrade,
+			Week:       2,
+			Processed:  time.UnixMilli(1726250000000).UTC(),
+			Assets: []model.TransactionAsset{
+				{Type: model.AssetPlayer, PlayerID: "2359", FromTeamID: "7", ToTeamID: "1"},
+				{Type: model.AssetPlayer, PlayerID: "4080", FromTeamID: "1", ToTeamID: "7"},
+				{Type: model.AssetDraftPick, FromTeamID: "1", ToTeamID: "7", PickSeason: "2025", PickRound: 2, PickTeamID: "1"},
+				{Type: model.AssetFAAB, FromTeamID: "1", ToTeamID: "7", FAAB: 10},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, transactions) {
+		t.Errorf("expected: %v, got: %v", expected, transactions)
+	}
+
+	transactions, err = c.GetTransactions(testutils.SleeperLeagueID, 3)
+	if err != nil {
+		t.Fatalf("error getting transactions: %v", err)
+	}
+	if len(transactions) != 0 {
+		t.Errorf("expected no transactions for week 3, got: %v", transactions)
+	}
+}
+
+func TestGetDrafts(t *testing.T) {
+	fakeSleeper := testutils.NewFakeSleeperServer()
+	defer fakeSleeper.Close()
+	c := NewForTest(fakeSleeper.URL())
+
+	drafts, err := c.GetDrafts(testutils.SleeperLeagueID)
+	if err != nil {
+		t.Fatalf("error getting drafts: %v", err)
+	}
+
+	// The draft that hasn't started yet should not be included
+	if len(drafts) != 1 {
+		t.Fatalf("expected 1 draft, got: %d", len(drafts))
+	}
+
+	d := drafts[0]
+	if d.ExternalID != testutils.SleeperDraftID || d.Type != model.DraftSnake || d.Rounds != 3 {
+		t.Errorf("unexpected draft values: %v", d)
+	}
+	if !d.Started.Equal(time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected draft start time: %v", d.Started)
+	}
+	if len(d.Picks) != 12 {
+		t.Fatalf("expected 12 picks, got: %d", len(d.Picks))
+	}
+
+	expected := map[int]model.DraftPick{
+		1:  {Round: 1, Pick: 1, TeamID: "1", PlayerID: "1166"},
+		5:  {Round: 2, Pick: 5, TeamID: "7", PlayerID: "1352"},
+		12: {Round: 3, Pick: 12, TeamID: "7", PlayerID: "4080", IsKeeper: true},
+	}
+	for _, p := range d.Picks {
+		if e, found := expected[p.Pick]; found && !reflect.DeepEqual(e, p) {
+			t.Errorf("expected pick: %v, got: %v", e, p)
+		}
+	}
+}
@@ -18,6 +18,7 @@ var (
 type sleeperPlayer struct {
 	ID              string    `json:"player_id"`
 	YahooID         int       `json:"yahoo_id"`
+	ESPNID          int       `json:"espn_id"`
 	FirstName       string    `json:"first_name"`
 	LastName        string    `json:"last_name"`
 	Position        string    `json:"position"`
@@ -40,7 +41,8 @@ type metadata struct {
 func (p *sleeperPlayer) toPlayer() *model.Player {
 	return &model.Player{
 		ID:              p.ID,
-		YahooID:         formatYahooID(p.YahooID),
+		YahooID:         formatPlatformID(p.YahooID),
+		ESPNID:          formatPlatformID(p.ESPNID),
 		FirstName:       p.FirstName,
 		LastName:        p.LastName,
 		Position:        model.ParsePosition(p.Position),
@@ -57,7 +59,7 @@ func (p *sleeperPlayer) toPlayer() *model.Player {
 	}
 }
 
-func formatYahooID(id int) string {
+func formatPlatformID(id int) string {
 	if id == 0 {
 		return ""
 	}
@@ -4,7 +4,12 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/mww/fantasy_manager_v2/model"
 	"github.com/mww/fantasy_manager_v2/platforms/yahoo/internal"
@@ -12,20 +17,79 @@ import (
 
 const YahooURL = "https://fantasysports.yahooapis.com"
 
+// PlayerScore is the number of points a player scored for a team in a week.
+type PlayerScore struct {
+	Player model.YahooPlayer
+	Score  int32
+}
+
+// Transaction is a completed transaction with the Yahoo players it moved. The
+// PlayerID of each player asset is the Yahoo id of the player until it is
+// converted, draft pick assets don't have a player.
+type Transaction struct {
+	Transaction model.Transaction
+	Players     []model.YahooPlayer
+}
+
+// Draft is a completed draft with the Yahoo player for each pick, Players[i]
+// is the player for Draft.Picks[i].
+type Draft struct {
+	Draft   model.Draft
+	Players []model.YahooPlayer
+}
+
 type Client struct {
 	url string
+
+	// Yahoo uses a different game key for every NFL season, cache the
+	// mapping from year to game key since it never changes.
+	mu       sync.Mutex
+	gameKeys map[string]string
 }
 
 func New() (*Client, error) {
-	return &Client{url: YahooURL}, nil
+	return &Client{url: YahooURL, gameKeys: make(map[string]string)}, nil
 }
 
 func NewForTest(url string) *Client {
-	return &Client{url: url}
+	return &Client{url: url, gameKeys: make(map[string]string)}
 }
 
-func (c *Client) GetStarters(httpClient *http.Client, leagueID string) ([]model.RosterSpot, error) {
-	content, err := c.yahooRequest(httpClient, "/fantasy/v2/league/nfl.l.%s/settings", leagueID)
+// GetLeagues returns all of the NFL leagues the logged in user belongs to for the given season.
+func (c *Client) GetLeagues(httpClient *http.Client, year string) ([]model.League, error) {
+	content, err := c.yahooRequest(httpClient, "/fantasy/v2/users;use_login=1/games;game_codes=nfl/leagues")
+	if err != nil {
+		return nil, err
+	}
+
+	if content == nil ||
+		content.Users == nil ||
+		len(content.Users.Users) == 0 ||
+		content.Users.Users[0].Games == nil {
+		return nil, errors.New("user has no games")
+	}
+
+	resp := make([]model.League, 0, 4)
+	for _, g := range content.Users.Users[0].Games.Games {
+		c.cacheGameKey(g.Season, g.Key)
+		if g.Season != year || g.Leagues == nil {
+			continue
+		}
+		for _, l := range g.Leagues.Leagues {
+			resp = append(resp, model.League{
+				Platform:   model.PlatformYahoo,
+				ExternalID: l.ID,
+				Name:       l.Name,
+				Year:       g.Season,
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetStarters(httpClient *http.Client, leagueID, year string) ([]model.RosterSpot, error) {
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/settings")
 	if err != nil {
 		return nil, err
 	}
@@ -39,15 +103,11 @@ func (c *Client) GetStarters(httpClient *http.Client, leagueID string) ([]model.
 	}
 	resp := make([]model.RosterSpot, 0, 9)
 	for _, p := range content.League.Settings.RosterPositions.Positions {
-		pos := p.Position
-		if p.Position == "BN" {
+		if p.Position == "BN" || p.Position == "IR" {
 			continue
 		}
-		if p.Position == "W/R/T" {
-			pos = "FLEX"
-		}
 		for range p.Count {
-			resp = append(resp, model.GetRosterSpot(pos))
+			resp = append(resp, model.GetRosterSpot(p.Position))
 		}
 	}
 
@@ -57,8 +117,8 @@ func (c *Client) GetStarters(httpClient *http.Client, leagueID string) ([]model.
 	return resp, nil
 }
 
-func (c *Client) GetManagers(httpClient *http.Client, leagueID string) ([]model.LeagueManager, error) {
-	content, err := c.yahooRequest(httpClient, "/fantasy/v2/league/nfl.l.%s/standings", leagueID)
+func (c *Client) GetManagers(httpClient *http.Client, leagueID, year string) ([]model.LeagueManager, error) {
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/standings")
 	if err != nil {
 		return nil, err
 	}
@@ -86,8 +146,68 @@ func (c *Client) GetManagers(httpClient *http.Client, leagueID string) ([]model.
 	return resp, nil
 }
 
-func (c *Client) GetLeagueName(httpClient *http.Client, leagueID string) (string, error) {
-	content, err := c.yahooRequest(httpClient, "/fantasy/v2/league/nfl.l.%s", leagueID)
+func (c *Client) GetLeagueStandings(httpClient *http.Client, leagueID, year string) ([]model.LeagueStanding, error) {
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/standings")
+	if err != nil {
+		return nil, err
+	}
+
+	if content == nil ||
+		content.League == nil ||
+		content.League.Standings == nil ||
+		content.League.Standings.Teams == nil ||
+		content.League.Standings.Teams.Teams == nil {
+		return nil, errors.New("league has no standings")
+	}
+
+	resp := make([]model.LeagueStanding, 0, len(content.League.Standings.Teams.Teams))
+	for _, t := range content.League.Standings.Teams.Teams {
+		if t.Standings == nil || t.Standings.OutcomeTotals == nil {
+			return nil, fmt.Errorf("team %s has no standings", t.Key)
+		}
+
+		resp = append(resp, model.LeagueStanding{
+			TeamID: t.Key,
+			Rank:   t.Standings.Rank,
+			Wins:   t.Standings.OutcomeTotals.Wins,
+			Losses: t.Standings.OutcomeTotals.Losses,
+			Draws:  t.Standings.OutcomeTotals.Ties,
+			Scored: fmt.Sprintf("%.2f", t.Standings.PointsFor),
+		})
+	}
+
+	// Yahoo has already applied the league's tie-breakers when ranking the teams, so keep its order.
+	slices.SortStableFunc(resp, func(a, b model.LeagueStanding) int {
+		return a.Rank - b.Rank
+	})
+	return resp, nil
+}
+
+// Get the team key of the league champion, or "" if the season isn't over. Once
+// the season is finished Yahoo ranks the teams by how they did in the playoffs.
+func (c *Client) GetLeagueChampion(httpClient *http.Client, leagueID, year string) (string, error) {
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/standings")
+	if err != nil {
+		return "", err
+	}
+
+	if content == nil || content.League == nil || content.League.Standings == nil || content.League.Standings.Teams == nil {
+		return "", errors.New("league has no standings")
+	}
+	if content.League.IsFinished != 1 {
+		return "", nil
+	}
+
+	for _, t := range content.League.Standings.Teams.Teams {
+		if t.Standings != nil && t.Standings.Rank == 1 {
+			return t.Key, nil
+		}
+	}
+	return "", errors.New("league is finished but no team is ranked first")
+}
+
+func (c *Client) GetLeagueName(httpClient *http.Client, leagueID, year string) (string, error) {
+	content, err := c.leagueRequest(httpClient, leagueID, year, "")
 	if err != nil {
 		return "", err
 	}
@@ -99,8 +219,8 @@ func (c *Client) GetLeagueName(httpClient *http.Client, leagueID string) (string
 	return content.League.Name, nil
 }
 
-func (c *Client) GetScoreboard(httpClient *http.Client, leagueID string, week int) ([]model.Matchup, error) {
-	content, err := c.yahooRequest(httpClient, "/fantasy/v2/league/nfl.l.%s/scoreboard;week=%d", leagueID, week)
+func (c *Client) GetScoreboard(httpClient *http.Client, leagueID, year string, week int) ([]model.Matchup, error) {
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/scoreboard;week=%d", week)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +257,36 @@ func (c *Client) GetScoreboard(httpClient *http.Client, leagueID string, week in
 	return results, nil
 }
 
+// Get the regular season matchups for every week after week, the scores are not set.
+func (c *Client) GetSchedule(httpClient *http.Client, leagueID, year string, week int) ([]model.Matchup, error) {
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/settings")
+	if err != nil {
+		return nil, err
+	}
+	if content == nil || content.League == nil || content.League.Settings == nil {
+		return nil, errors.New("league settings not found")
+	}
+
+	end := content.League.EndWeek + 1
+	if content.League.Settings.UsesPlayoff == 1 && content.League.Settings.PlayoffStart > 0 {
+		end = content.League.Settings.PlayoffStart
+	}
+
+	results := make([]model.Matchup, 0, max(end-week, 0)*6)
+	for w := week + 1; w < end; w++ {
+		matchups, err := c.GetScoreboard(httpClient, leagueID, year, w)
+		if err != nil {
+			return nil, fmt.Errorf("error getting scoreboard for week %d: %w", w, err)
+		}
+		for _, m := range matchups {
+			m.TeamA.Score = 0
+			m.TeamB.Score = 0
+			results = append(results, m)
+		}
+	}
+	return results, nil
+}
+
 func validateTeams(teams *internal.Teams) error {
 	if teams == nil || len(teams.Teams) != 2 {
 		return errors.New("invalid teams in result")
@@ -165,23 +315,303 @@ func (c *Client) GetRoster(httpClient *http.Client, teamID string) ([]model.Yaho
 
 	results := make([]model.YahooPlayer, 0, 15)
 	for _, p := range content.Team.Roster.Players.Players {
-		y := model.YahooPlayer{
-			YahooID: p.ID,
-			Pos:     model.ParsePosition(p.Position),
+		results = append(results, toYahooPlayer(&p))
+	}
+
+	return results, nil
+}
+
+// GetPlayerScores returns the points scored by every player on a team's roster for the given week.
+func (c *Client) GetPlayerScores(httpClient *http.Client, teamID string, week int) ([]PlayerScore, error) {
+	content, err := c.yahooRequest(httpClient, "/fantasy/v2/team/%s/roster;week=%d/players/stats", teamID, week)
+	if err != nil {
+		return nil, err
+	}
+
+	if content == nil ||
+		content.Team == nil ||
+		content.Team.Roster == nil ||
+		content.Team.Roster.Players == nil ||
+		content.Team.Roster.Players.Players == nil {
+		return nil, errors.New("team roster not found")
+	}
+
+	results := make([]PlayerScore, 0, 15)
+	for _, p := range content.Team.Roster.Players.Players {
+		s := PlayerScore{Player: toYahooPlayer(&p)}
+		if p.PlayerPoints != nil {
+			s.Score = int32(math.Round(p.PlayerPoints.Total * 1000))
 		}
-		if p.Name != nil {
-			y.FirstName = p.Name.First
-			y.LastName = p.Name.Last
+		results = append(results, s)
+	}
+
+	return results, nil
+}
+
+// GetTransactions returns the successful transactions processed during a week of the season.
+// Yahoo doesn't group transactions by week, so they are filtered using the dates of the week.
+func (c *Client) GetTransactions(httpClient *http.Client, leagueID, year string, week int) ([]Transaction, error) {
+	start, end, err := c.weekDates(httpClient, year, week)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/transactions")
+	if err != nil {
+		return nil, err
+	}
+
+	if content == nil || content.League == nil || content.League.Transactions == nil {
+		return nil, errors.New("league transactions not found")
+	}
+
+	results := make([]Transaction, 0, len(content.League.Transactions.Transactions))
+	for _, tr := range content.League.Transactions.Transactions {
+		processed := time.Unix(tr.Timestamp, 0).UTC()
+		if tr.Status != "successful" || processed.Before(start) || !processed.Before(end) {
+			continue
 		}
-		if y.Pos == model.POS_DEF {
-			y.FirstName = p.TeamFullName
+
+		t := Transaction{
+			Transaction: model.Transaction{
+				ExternalID: tr.Key,
+				Week:       week,
+				Processed:  processed,
+				WaiverBid:  tr.FAABBid,
+			},
+		}
+
+		var players []internal.Player
+		if tr.Players != nil {
+			players = tr.Players.Players
 		}
-		results = append(results, y)
+		var hasAdd, fromWaivers bool
+		for _, p := range players {
+			if p.TransactionData == nil {
+				return nil, fmt.Errorf("transaction %s is missing transaction data", tr.Key)
+			}
+			d := p.TransactionData
+			a := model.TransactionAsset{Type: model.AssetPlayer, PlayerID: p.ID}
+			if d.SourceType == "team" {
+				a.FromTeamID = d.SourceTeamKey
+			}
+			if d.DestinationType == "team" {
+				a.ToTeamID = d.DestinationTeamKey
+				hasAdd = true
+			}
+			if d.SourceType == "waivers" {
+				fromWaivers = true
+			}
+			t.Transaction.Assets = append(t.Transaction.Assets, a)
+			t.Players = append(t.Players, toYahooPlayer(&p))
+		}
+		// Yahoo doesn't say which season a traded pick is for, so it is left empty
+		if tr.Picks != nil {
+			for _, p := range tr.Picks.Picks {
+				t.Transaction.Assets = append(t.Transaction.Assets, model.TransactionAsset{
+					Type:       model.AssetDraftPick,
+					FromTeamID: p.SourceTeamKey,
+					ToTeamID:   p.DestinationTeamKey,
+					PickRound:  p.Round,
+					PickTeamID: p.OriginalTeamKey,
+				})
+			}
+		}
+		if len(t.Transaction.Assets) == 0 {
+			continue
+		}
+
+		switch {
+		case tr.Type == "trade":
+			t.Transaction.Type = model.TransactionTrade
+		case fromWaivers:
+			t.Transaction.Type = model.TransactionWaiver
+		case hasAdd:
+			t.Transaction.Type = model.TransactionAdd
+		default:
+			t.Transaction.Type = model.TransactionDrop
+		}
+
+		results = append(results, t)
 	}
 
+	// Yahoo returns the newest transactions first
+	slices.SortFunc(results, func(a, b Transaction) int {
+		return a.Transaction.Processed.Compare(b.Transaction.Processed)
+	})
 	return results, nil
 }
 
+// Yahoo leagues only have a single draft, so the league key is used as the id of the draft.
+func (c *Client) GetDraft(httpClient *http.Client, leagueID, year string) (*Draft, error) {
+	settings, err := c.leagueRequest(httpClient, leagueID, year, "/settings")
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil || settings.League == nil || settings.League.Settings == nil {
+		return nil, errors.New("league settings not found")
+	}
+
+	content, err := c.leagueRequest(httpClient, leagueID, year, "/draftresults/players")
+	if err != nil {
+		return nil, err
+	}
+	if content == nil || content.League == nil || content.League.DraftResults == nil {
+		return nil, errors.New("league draft results not found")
+	}
+
+	d := &Draft{
+		Draft: model.Draft{
+			ExternalID: content.League.Key,
+			Type:       model.DraftSnake,
+			Started:    time.Unix(settings.League.Settings.DraftTime, 0).UTC(),
+		},
+		Players: make([]model.YahooPlayer, 0, len(content.League.DraftResults.Results)),
+	}
+	if settings.League.Settings.IsAuctionDraft == 1 {
+		d.Draft.Type = model.DraftAuction
+	}
+
+	for _, r := range content.League.DraftResults.Results {
+		if r.Players == nil || len(r.Players.Players) != 1 {
+			return nil, fmt.Errorf("draft pick %d does not have a player", r.Pick)
+		}
+		d.Draft.Picks = append(d.Draft.Picks, model.DraftPick{
+			Round:  r.Round,
+			Pick:   r.Pick,
+			TeamID: r.TeamKey,
+			Cost:   r.Cost,
+		})
+		d.Players = append(d.Players, toYahooPlayer(&r.Players.Players[0]))
+		d.Draft.Rounds = max(d.Draft.Rounds, r.Round)
+	}
+	return d, nil
+}
+
+// Get the time range for a week of the season. The end is exclusive, it is
+// the start of the day after the last day of the week.
+func (c *Client) weekDates(httpClient *http.Client, year string, week int) (time.Time, time.Time, error) {
+	gameKey, err := c.GameKey(httpClient, year)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	content, err := c.yahooRequest(httpClient, "/fantasy/v2/game/%s/game_weeks", gameKey)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if content == nil || content.Game == nil || content.Game.Weeks == nil {
+		return time.Time{}, time.Time{}, errors.New("game weeks not found")
+	}
+
+	for _, w := range content.Game.Weeks.Weeks {
+		if w.Week != week {
+			continue
+		}
+		start, e1 := time.Parse(time.DateOnly, w.Start)
+		end, e2 := time.Parse(time.DateOnly, w.End)
+		if err := errors.Join(e1, e2); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("error parsing dates for week %d: %w", week, err)
+		}
+		return start, end.AddDate(0, 0, 1), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("week %d not found in %s season", week, year)
+}
+
+// ParseRankings reads the players from a Yahoo players collection, like the
+// response from /game/nfl/players;sort=AR. The players are returned in the order
+// they are listed, which is their rank. The players can be from either a game or
+// a league.
+func ParseRankings(r io.Reader) ([]model.YahooPlayer, error) {
+	var res internal.FantasyContent
+	if err := xml.NewDecoder(r).Decode(&res); err != nil {
+		return nil, fmt.Errorf("error parsing yahoo players: %w", err)
+	}
+
+	var players *internal.Players
+	if res.Game != nil && res.Game.Players != nil {
+		players = res.Game.Players
+	} else if res.League != nil && res.League.Players != nil {
+		players = res.League.Players
+	}
+	if players == nil || len(players.Players) == 0 {
+		return nil, errors.New("no players found in yahoo rankings")
+	}
+
+	results := make([]model.YahooPlayer, 0, len(players.Players))
+	for i := range players.Players {
+		results = append(results, toYahooPlayer(&players.Players[i]))
+	}
+	return results, nil
+}
+
+func toYahooPlayer(p *internal.Player) model.YahooPlayer {
+	pos := p.Position
+	if pos == "" {
+		pos = p.DisplayPosition
+	}
+	y := model.YahooPlayer{
+		YahooID: p.ID,
+		Pos:     model.ParsePosition(pos),
+	}
+	if p.Name != nil {
+		y.FirstName = p.Name.First
+		y.LastName = p.Name.Last
+	}
+	if y.Pos == model.POS_DEF {
+		y.FirstName = p.TeamFullName
+	}
+	return y
+}
+
+// GameKey returns the Yahoo game key for the NFL season in the given year.
+func (c *Client) GameKey(httpClient *http.Client, year string) (string, error) {
+	c.mu.Lock()
+	key, found := c.gameKeys[year]
+	c.mu.Unlock()
+	if found {
+		return key, nil
+	}
+
+	content, err := c.yahooRequest(httpClient, "/fantasy/v2/games;game_codes=nfl;seasons=%s", year)
+	if err != nil {
+		return "", err
+	}
+
+	if content == nil || content.Games == nil {
+		return "", fmt.Errorf("no yahoo game found for %s", year)
+	}
+	for _, g := range content.Games.Games {
+		if g.Season == year && g.Key != "" {
+			c.cacheGameKey(g.Season, g.Key)
+			return g.Key, nil
+		}
+	}
+	return "", fmt.Errorf("no yahoo game found for %s", year)
+}
+
+func (c *Client) cacheGameKey(year, key string) {
+	if year == "" || key == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gameKeys[year] = key
+}
+
+// Make a request for a league resource. The path is relative to the league key, which is
+// built from the game key for the season and the league id.
+func (c *Client) leagueRequest(httpClient *http.Client, leagueID, year, path string, args ...any) (*internal.FantasyContent, error) {
+	gameKey, err := c.GameKey(httpClient, year)
+	if err != nil {
+		return nil, err
+	}
+
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, leagueID)
+	return c.yahooRequest(httpClient, "/fantasy/v2/league/%s"+path, append([]any{leagueKey}, args...)...)
+}
+
 func (c *Client) yahooRequest(httpClient *http.Client, path string, args ...any) (*internal.FantasyContent, error) {
 	p := fmt.Sprintf(path, args...)
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", c.url, p), nil)
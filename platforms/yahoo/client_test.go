@@ -3,7 +3,9 @@ package yahoo
 import (
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mww/fantasy_manager_v2/model"
 	"github.com/mww/fantasy_manager_v2/platforms/yahoo/internal"
@@ -16,7 +18,7 @@ func TestGetLeagueMetadata(t *testing.T) {
 
 	c := NewForTest(fakeYahoo.URL())
 
-	name, err := c.GetLeagueName(http.DefaultClient, testutils.YahooLeagueID)
+	name, err := c.GetLeagueName(http.DefaultClient, testutils.YahooLeagueID, "2024")
 	if err != nil {
 		t.Fatalf("unexpected error getting league name: %v", err)
 	}
@@ -31,19 +33,106 @@ func TestGetLeagueMetadata_badLeagueId(t *testing.T) {
 
 	c := NewForTest(fakeYahoo.URL())
 
-	_, err := c.GetLeagueName(http.DefaultClient, "987")
+	_, err := c.GetLeagueName(http.DefaultClient, "987", "2024")
 	if err == nil {
 		t.Fatal("expected an error, but got none")
 	}
 }
 
+func TestGetLeagueMetadata_olderSeason(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	name, err := c.GetLeagueName(http.DefaultClient, testutils.YahooLeagueID, "2023")
+	if err != nil {
+		t.Fatalf("unexpected error getting league name: %v", err)
+	}
+	if name != "Y! Friends and Family League" {
+		t.Errorf("league name was not expected value, got: %s", name)
+	}
+
+	if _, err := c.GetLeagueName(http.DefaultClient, testutils.YahooLeagueID, "1990"); err == nil {
+		t.Errorf("expected an error for a season without a yahoo game, but got none")
+	}
+}
+
+func TestGameKey(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	tests := map[string]string{
+		"2023": "423",
+		"2024": "449",
+	}
+	for year, expected := range tests {
+		key, err := c.GameKey(http.DefaultClient, year)
+		if err != nil {
+			t.Fatalf("unexpected error getting game key for %s: %v", year, err)
+		}
+		if key != expected {
+			t.Errorf("expected game key %s for %s, got: %s", expected, year, key)
+		}
+	}
+
+	// Once the server is gone the keys must still come from the cache
+	fakeYahoo.Close()
+	for year, expected := range tests {
+		key, err := c.GameKey(http.DefaultClient, year)
+		if err != nil {
+			t.Fatalf("unexpected error getting cached game key for %s: %v", year, err)
+		}
+		if key != expected {
+			t.Errorf("expected cached game key %s for %s, got: %s", expected, year, key)
+		}
+	}
+	if _, err := c.GameKey(http.DefaultClient, "2022"); err == nil {
+		t.Errorf("expected an error for an uncached year, but got none")
+	}
+}
+
+func TestGetLeagues(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	tests := map[string]struct {
+		year      string
+		exLeagues []model.League
+	}{
+		"current season": {year: "2024", exLeagues: []model.League{
+			{Platform: model.PlatformYahoo, ExternalID: "431", Name: "Y! Friends and Family League", Year: "2024"},
+			{Platform: model.PlatformYahoo, ExternalID: "149976", Name: "Office Pool", Year: "2024"},
+		}},
+		"old season": {year: "2023", exLeagues: []model.League{
+			{Platform: model.PlatformYahoo, ExternalID: "88213", Name: "Office Pool", Year: "2023"},
+		}},
+		"no leagues": {year: "2019", exLeagues: []model.League{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			leagues, err := c.GetLeagues(http.DefaultClient, tc.year)
+			if err != nil {
+				t.Fatalf("unexpected error getting leagues: %v", err)
+			}
+			if !reflect.DeepEqual(tc.exLeagues, leagues) {
+				t.Errorf("expected: %v, got: %v", tc.exLeagues, leagues)
+			}
+		})
+	}
+}
+
 func TestGetStarters(t *testing.T) {
 	fakeYahoo := testutils.NewFakeYahooServer()
 	defer fakeYahoo.Close()
 
 	c := NewForTest(fakeYahoo.URL())
 
-	starters, err := c.GetStarters(http.DefaultClient, testutils.YahooLeagueID)
+	starters, err := c.GetStarters(http.DefaultClient, testutils.YahooLeagueID, "2024")
 	if err != nil {
 		t.Fatalf("unexpected error getting starters: %v", err)
 	}
@@ -72,7 +161,7 @@ func TestGetTeams(t *testing.T) {
 
 	c := NewForTest(fakeYahoo.URL())
 
-	managers, err := c.GetManagers(http.DefaultClient, testutils.YahooLeagueID)
+	managers, err := c.GetManagers(http.DefaultClient, testutils.YahooLeagueID, "2024")
 	if err != nil {
 		t.Fatalf("unexpected error getting managers: %v", err)
 	}
@@ -105,13 +194,40 @@ func TestGetTeams(t *testing.T) {
 	}
 }
 
+func TestGetLeagueStandings(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	standings, err := c.GetLeagueStandings(http.DefaultClient, testutils.YahooLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting standings: %v", err)
+	}
+
+	expected := []model.LeagueStanding{
+		{TeamID: testutils.YahooTeam10ID, Rank: 1, Wins: 9, Losses: 4, Scored: "1682.33"},
+		{TeamID: testutils.YahooTeam05ID, Rank: 2, Wins: 9, Losses: 4, Scored: "1764.09"},
+		{TeamID: testutils.YahooTeam08ID, Rank: 3, Wins: 8, Losses: 5, Scored: "1569.48"},
+		{TeamID: testutils.YahooTeam12ID, Rank: 4, Wins: 8, Losses: 5, Scored: "1652.27"},
+	}
+
+	if !reflect.DeepEqual(expected, standings) {
+		t.Errorf("expected: %v, got: %v", expected, standings)
+	}
+
+	if _, err := c.GetLeagueStandings(http.DefaultClient, "987", "2024"); err == nil {
+		t.Errorf("expected an error for an unknown league, but got none")
+	}
+}
+
 func TestGetScoreboard(t *testing.T) {
 	fakeYahoo := testutils.NewFakeYahooServer()
 	defer fakeYahoo.Close()
 
 	c := NewForTest(fakeYahoo.URL())
 
-	matchups, err := c.GetScoreboard(http.DefaultClient, testutils.YahooLeagueID, 1)
+	matchups, err := c.GetScoreboard(http.DefaultClient, testutils.YahooLeagueID, "2024", 1)
 	if err != nil {
 		t.Fatalf("unexpected error getting yahoo scoreboard: %v", err)
 	}
@@ -146,6 +262,35 @@ func TestGetScoreboard(t *testing.T) {
 	}
 }
 
+func TestGetSchedule(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	// The playoffs start in week 14, so week 13 is the last week of the regular season
+	matchups, err := c.GetSchedule(http.DefaultClient, testutils.YahooLeagueID, "2024", 12)
+	if err != nil {
+		t.Fatalf("unexpected error getting yahoo schedule: %v", err)
+	}
+
+	expected := []model.Matchup{
+		{
+			Week:  13,
+			TeamA: &model.TeamResult{TeamID: "223.l.431.t.10"},
+			TeamB: &model.TeamResult{TeamID: "223.l.431.t.8"},
+		},
+		{
+			Week:  13,
+			TeamA: &model.TeamResult{TeamID: "223.l.431.t.5"},
+			TeamB: &model.TeamResult{TeamID: "223.l.431.t.12"},
+		},
+	}
+	if !reflect.DeepEqual(expected, matchups) {
+		t.Errorf("expected %v, got %v", expected, matchups)
+	}
+}
+
 func TestGetRoster(t *testing.T) {
 	fakeYahoo := testutils.NewFakeYahooServer()
 	defer fakeYahoo.Close()
@@ -168,6 +313,32 @@ func TestGetRoster(t *testing.T) {
 	}
 }
 
+func TestGetPlayerScores(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	scores, err := c.GetPlayerScores(http.DefaultClient, testutils.YahooTeam10ID, 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting player scores: %v", err)
+	}
+
+	expected := []PlayerScore{
+		{Player: model.YahooPlayer{YahooID: "29288", FirstName: "Tyler", LastName: "Boyd", Pos: model.POS_WR}, Score: 25120},
+		{Player: model.YahooPlayer{YahooID: "30150", FirstName: "Zay", LastName: "Jones", Pos: model.POS_WR}, Score: 13700},
+		{Player: model.YahooPlayer{YahooID: "31012", FirstName: "Mike", LastName: "Gesicki", Pos: model.POS_TE}, Score: 8000},
+	}
+
+	if !reflect.DeepEqual(expected, scores) {
+		t.Errorf("expected: %v, got: %v", expected, scores)
+	}
+
+	if _, err := c.GetPlayerScores(http.DefaultClient, testutils.YahooTeam10ID, 2); err == nil {
+		t.Errorf("expected an error for a week without data, but got none")
+	}
+}
+
 func TestValidateTeams(t *testing.T) {
 	if err := validateTeams(nil); err == nil {
 		t.Errorf("expected an error when teams==nil")
@@ -193,3 +364,200 @@ func TestValidateTeams(t *testing.T) {
 		t.Errorf("unexpected error validating teams: %v", err)
 	}
 }
+
+func TestGetTransactions(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	transactions, err := c.GetTransactions(http.DefaultClient, testutils.YahooLeagueID, "2024", 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting transactions: %v", err)
+	}
+
+	expected := []Transaction{
+		{
+			Transaction: model.Transaction{
+				ExternalID: "449.l.431.tr.12",
+				Type:       model.TransactionWaiver,
+				Week:       2,
+				Processed:  time.Unix(1726041612, 0).UTC(),
+				WaiverBid:  17,
+				Assets: []model.TransactionAsset{
+					{Type: model.AssetPlayer, PlayerID: "40078", ToTeamID: testutils.YahooTeam05ID},
+					{Type: model.AssetPlayer, PlayerID: "26658", FromTeamID: testutils.YahooTeam05ID},
+				},
+			},
+			Players: []model.YahooPlayer{
+				{YahooID: "40078", FirstName: "Andrei", LastName: "Iosivas", Pos: model.POS_WR},
+				{YahooID: "26658", FirstName: "Zach", LastName: "Ertz", Pos: model.POS_TE},
+			},
+		},
+		{
+			Transaction: model.Transaction{
+				ExternalID: "449.l.431.tr.13",
+				Type:       model.TransactionAdd,
+				Week:       2,
+				Processed:  time.Unix(1726132520, 0).UTC(),
+				Assets: []model.TransactionAsset{
+					{Type: model.AssetPlayer, PlayerID: "40090", ToTeamID: testutils.YahooTeam08ID},
+				},
+			},
+			Players: []model.YahooPlayer{
+				{YahooID: "40090", FirstName: "Luke", LastName: "Schoonmaker", Pos: model.POS_TE},
+			},
+		},
+		{
+			Transaction: model.Transaction{
+				ExternalID: "449.l.431.tr.14",
+				Type:       model.TransactionDrop,
+				Week:       2,
+				Processed:  time.Unix(1726140000, 0).UTC(),
+				Assets: []model.TransactionAsset{
+					{Type: model.AssetPlayer, PlayerID: "34054", FromTeamID: testutils.YahooTeam12ID},
+				},
+			},
+			Players: []model.YahooPlayer{
+				{YahooID: "34054", FirstName: "Brian", LastName: "Robinson", Pos: model.POS_RB},
+			},
+		},
+		{
+			Transaction: model.Transaction{
+				ExternalID: "449.l.431.tr.15",
+				Type:       model.TransactionTrade,
+				Week:       2,
+				Processed:  time.Unix(1726250000, 0).UTC(),
+				Assets: []model.TransactionAsset{
+					{Type: model.AssetPlayer, PlayerID: "30150", FromTeamID: testutils.YahooTeam10ID, ToTeamID: testutils.YahooTeam05ID},
+					{Type: model.AssetPlayer, PlayerID: "26664", FromTeamID: testutils.YahooTeam05ID, ToTeamID: testutils.YahooTeam10ID},
+				},
+			},
+			Players: []model.YahooPlayer{
+				{YahooID: "30150", FirstName: "Zay", LastName: "Jones", Pos: model.POS_WR},
+				{YahooID: "26664", FirstName: "Robert", LastName: "Woods", Pos: model.POS_WR},
+			},
+		},
+		{
+			Transaction: model.Transaction{
+				ExternalID: "449.l.431.tr.16",
+				Type:       model.TransactionTrade,
+				Week:       2,
+				Processed:  time.Unix(1726300000, 0).UTC(),
+				Assets: []model.TransactionAsset{
+					{Type: model.AssetPlayer, PlayerID: "40090", FromTeamID: testutils.YahooTeam08ID, ToTeamID: testutils.YahooTeam12ID},
+					{Type: model.AssetDraftPick, FromTeamID: testutils.YahooTeam12ID, ToTeamID: testutils.YahooTeam08ID, PickRound: 3, PickTeamID: testutils.YahooTeam12ID},
+				},
+			},
+			Players: []model.YahooPlayer{
+				{YahooID: "40090", FirstName: "Luke", LastName: "Schoonmaker", Pos: model.POS_TE},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, transactions) {
+		t.Errorf("expected: %v, got: %v", expected, transactions)
+	}
+
+	transactions, err = c.GetTransactions(http.DefaultClient, testutils.YahooLeagueID, "2024", 3)
+	if err != nil {
+		t.Fatalf("unexpected error getting transactions: %v", err)
+	}
+	if len(transactions) != 0 {
+		t.Errorf("expected no transactions in week 3, got: %v", transactions)
+	}
+
+	if _, err := c.GetTransactions(http.DefaultClient, testutils.YahooLeagueID, "2024", 12); err == nil {
+		t.Errorf("expected an error for a week that doesn't exist, but got none")
+	}
+}
+
+func TestGetDraft(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	d, err := c.GetDraft(http.DefaultClient, testutils.YahooLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting draft: %v", err)
+	}
+
+	if d.Draft.ExternalID != "449.l.431" || d.Draft.Type != model.DraftAuction || d.Draft.Rounds != 2 {
+		t.Errorf("unexpected draft values: %v", d.Draft)
+	}
+	if !d.Draft.Started.Equal(time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected draft start time: %v", d.Draft.Started)
+	}
+	if len(d.Draft.Picks) != 8 || len(d.Players) != 8 {
+		t.Fatalf("expected 8 picks and players, got: %d, %d", len(d.Draft.Picks), len(d.Players))
+	}
+
+	expectedPick := model.DraftPick{Round: 2, Pick: 8, TeamID: testutils.YahooTeam05ID, Cost: 22}
+	if !reflect.DeepEqual(expectedPick, d.Draft.Picks[7]) {
+		t.Errorf("expected pick: %v, got: %v", expectedPick, d.Draft.Picks[7])
+	}
+	expectedPlayer := model.YahooPlayer{YahooID: "34054", FirstName: "Brian", LastName: "Robinson", Pos: model.POS_RB}
+	if !reflect.DeepEqual(expectedPlayer, d.Players[7]) {
+		t.Errorf("expected player: %v, got: %v", expectedPlayer, d.Players[7])
+	}
+}
+
+func TestGetLeagueChampion(t *testing.T) {
+	fakeYahoo := testutils.NewFakeYahooServer()
+	defer fakeYahoo.Close()
+
+	c := NewForTest(fakeYahoo.URL())
+
+	champion, err := c.GetLeagueChampion(http.DefaultClient, testutils.YahooLeagueID, "2024")
+	if err != nil {
+		t.Fatalf("unexpected error getting league champion: %v", err)
+	}
+	if champion != testutils.YahooTeam10ID {
+		t.Errorf("expected champion to be %s, got: %s", testutils.YahooTeam10ID, champion)
+	}
+}
+
+func TestParseRankings(t *testing.T) {
+	const rankings = `<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <game>
+    <game_key>449</game_key>
+    <players count="2">
+      <player>
+        <player_key>449.p.33393</player_key>
+        <player_id>33393</player_id>
+        <name><full>Justin Jefferson</full><first>Justin</first><last>Jefferson</last></name>
+        <editorial_team_full_name>Minnesota Vikings</editorial_team_full_name>
+        <primary_position>WR</primary_position>
+      </player>
+      <player>
+        <player_key>449.p.100033</player_key>
+        <player_id>100033</player_id>
+        <name><full>Baltimore</full><first>Baltimore</first><last></last></name>
+        <editorial_team_full_name>Baltimore Ravens</editorial_team_full_name>
+        <primary_position>DEF</primary_position>
+      </player>
+    </players>
+  </game>
+</fantasy_content>`
+
+	players, err := ParseRankings(strings.NewReader(rankings))
+	if err != nil {
+		t.Fatalf("unexpected error parsing rankings: %v", err)
+	}
+	expected := []model.YahooPlayer{
+		{YahooID: "33393", FirstName: "Justin", LastName: "Jefferson", Pos: model.POS_WR},
+		{YahooID: "100033", FirstName: "Baltimore Ravens", Pos: model.POS_DEF},
+	}
+	if !reflect.DeepEqual(expected, players) {
+		t.Errorf("expected: %v, got: %v", expected, players)
+	}
+
+	if _, err := ParseRankings(strings.NewReader(`<fantasy_content><league></league></fantasy_content>`)); err == nil {
+		t.Error("expected an error parsing rankings without any players")
+	}
+	if _, err := ParseRankings(strings.NewReader(`not xml`)); err == nil {
+		t.Error("expected an error parsing rankings that aren't xml")
+	}
+}
@@ -3,17 +3,112 @@ package internal
 type FantasyContent struct {
 	League *League `xml:"league"`
 	Team   *Team   `xml:"team"`
+	Users  *Users  `xml:"users"`
+	Games  *Games  `xml:"games"`
+	Game   *Game   `xml:"game"`
+}
+
+type Users struct {
+	Users []User `xml:"user"`
+}
+
+type User struct {
+	Games *Games `xml:"games"`
+}
+
+type Games struct {
+	Games []Game `xml:"game"`
+}
+
+type Game struct {
+	Key     string     `xml:"game_key"`
+	Code    string     `xml:"code"`
+	Season  string     `xml:"season"`
+	Leagues *Leagues   `xml:"leagues"`
+	Weeks   *GameWeeks `xml:"game_weeks"`
+	Players *Players   `xml:"players"`
+}
+
+type GameWeeks struct {
+	Weeks []GameWeek `xml:"game_week"`
+}
+
+type GameWeek struct {
+	Week  int    `xml:"week"`
+	Start string `xml:"start"` // YYYY-MM-DD
+	End   string `xml:"end"`   // YYYY-MM-DD
+}
+
+type Leagues struct {
+	Leagues []League `xml:"league"`
 }
 
 type League struct {
-	Key        string      `xml:"league_key"`
-	Name       string      `xml:"name"`
-	Settings   *Settings   `xml:"settings"`
-	Standings  *Standings  `xml:"standings"`
-	Scoreboard *Scoreboard `xml:"scoreboard"`
+	Key          string        `xml:"league_key"`
+	ID           string        `xml:"league_id"`
+	Name         string        `xml:"name"`
+	Season       string        `xml:"season"`
+	IsFinished   int           `xml:"is_finished"`
+	EndWeek      int           `xml:"end_week"`
+	Settings     *Settings     `xml:"settings"`
+	Standings    *Standings    `xml:"standings"`
+	Scoreboard   *Scoreboard   `xml:"scoreboard"`
+	Transactions *Transactions `xml:"transactions"`
+	DraftResults *DraftResults `xml:"draft_results"`
+	Players      *Players      `xml:"players"`
+}
+
+type DraftResults struct {
+	Results []DraftResult `xml:"draft_result"`
+}
+
+type DraftResult struct {
+	Pick    int      `xml:"pick"`
+	Round   int      `xml:"round"`
+	Cost    int32    `xml:"cost"`
+	TeamKey string   `xml:"team_key"`
+	Players *Players `xml:"players"`
+}
+
+type Transactions struct {
+	Transactions []Transaction `xml:"transaction"`
+}
+
+type Transaction struct {
+	Key       string   `xml:"transaction_key"`
+	Type      string   `xml:"type"`
+	Status    string   `xml:"status"`
+	Timestamp int64    `xml:"timestamp"`
+	FAABBid   int32    `xml:"faab_bid"`
+	Players   *Players `xml:"players"`
+	// Trades can also move draft picks between teams.
+	Picks *TransactionPicks `xml:"picks"`
+}
+
+type TransactionPicks struct {
+	Picks []TransactionPick `xml:"pick"`
+}
+
+type TransactionPick struct {
+	SourceTeamKey      string `xml:"source_team_key"`
+	DestinationTeamKey string `xml:"destination_team_key"`
+	OriginalTeamKey    string `xml:"original_team_key"`
+	Round              int    `xml:"round"`
+}
+
+type TransactionData struct {
+	Type               string `xml:"type"`
+	SourceType         string `xml:"source_type"`
+	SourceTeamKey      string `xml:"source_team_key"`
+	DestinationType    string `xml:"destination_type"`
+	DestinationTeamKey string `xml:"destination_team_key"`
 }
 
 type Settings struct {
+	IsAuctionDraft  int              `xml:"is_auction_draft"`
+	DraftTime       int64            `xml:"draft_time"`
+	UsesPlayoff     int              `xml:"uses_playoff"`
+	PlayoffStart    int              `xml:"playoff_start_week"`
 	RosterPositions *RosterPositions `xml:"roster_positions"`
 }
 
@@ -35,11 +130,25 @@ type Teams struct {
 }
 
 type Team struct {
-	Key        string      `xml:"team_key"`
-	Name       string      `xml:"name"`
-	Managers   *Managers   `xml:"managers"`
-	TeamPoints *TeamPoints `xml:"team_points"`
-	Roster     *Roster     `xml:"roster"`
+	Key        string         `xml:"team_key"`
+	Name       string         `xml:"name"`
+	Managers   *Managers      `xml:"managers"`
+	TeamPoints *TeamPoints    `xml:"team_points"`
+	Roster     *Roster        `xml:"roster"`
+	Standings  *TeamStandings `xml:"team_standings"`
+}
+
+type TeamStandings struct {
+	Rank          int            `xml:"rank"`
+	PointsFor     float64        `xml:"points_for"`
+	PointsAgainst float64        `xml:"points_against"`
+	OutcomeTotals *OutcomeTotals `xml:"outcome_totals"`
+}
+
+type OutcomeTotals struct {
+	Wins   int `xml:"wins"`
+	Losses int `xml:"losses"`
+	Ties   int `xml:"ties"`
 }
 
 type Managers struct {
@@ -81,6 +190,10 @@ type Player struct {
 	Name         *PlayerName `xml:"name"`
 	Position     string      `xml:"primary_position"`
 	TeamFullName string      `xml:"editorial_team_full_name"`
+	PlayerPoints *TeamPoints `xml:"player_points"`
+	// Transactions only include the display position, not the primary position.
+	DisplayPosition string           `xml:"display_position"`
+	TransactionData *TransactionData `xml:"transaction_data"`
 }
 
 type PlayerName struct {
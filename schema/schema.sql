@@ -2,6 +2,9 @@ CREATE TABLE IF NOT EXISTS players (
     -- id == the sleeper player id
     id                varchar(16) PRIMARY KEY,
     yahoo_id          varchar(16),
+    espn_id           varchar(16),
+    mfl_id            varchar(16),
+    fleaflicker_id    varchar(16),
     name_first        varchar(64) NOT NULL,
     name_last         varchar(64) NOT NULL,
     nickname1         varchar(64),
@@ -35,9 +38,11 @@ CREATE TABLE IF NOT EXISTS player_changes (
 -- metadata about a ranking and a way to link all of the individual player
 -- rankings together.
 CREATE TABLE IF NOT EXISTS rankings (
-    id           serial PRIMARY KEY,
-    ranking_date timestamp with time zone NOT NULL,
-    created      timestamp with time zone DEFAULT (now() at time zone 'utc')
+    id             serial PRIMARY KEY,
+    ranking_date   timestamp with time zone NOT NULL,
+    source         varchar(16) NOT NULL DEFAULT 'fantasypros', -- Where the rankings were imported from
+    scoring_format varchar(16) NOT NULL DEFAULT '', -- ppr, half, standard, etc. Empty if not known
+    created        timestamp with time zone DEFAULT (now() at time zone 'utc')
 );
 
 -- The individual player rankings at a point in time.
@@ -48,6 +53,50 @@ CREATE TABLE IF NOT EXISTS player_rankings (
     PRIMARY KEY (ranking_id, player_id)
 );
 
+-- Rows in an imported ranking that couldn't be matched to a player. They are
+-- removed when they are resolved to a player, which adds them to player_rankings.
+CREATE TABLE IF NOT EXISTS ranking_unmatched (
+    id         serial PRIMARY KEY,
+    ranking_id integer REFERENCES rankings(id),
+    ranking    integer NOT NULL,
+    name       varchar(128) NOT NULL, -- The name as it was in the rankings file
+    team       varchar(8) NOT NULL DEFAULT '', -- Empty if the rankings didn't have a team
+    position   varchar(8) NOT NULL,
+    player_id  varchar(32) NOT NULL DEFAULT '' -- The player id in the rankings file, empty if it didn't have one
+);
+
+-- The rankings a consensus ranking was blended from. A ranking can't be deleted
+-- while a consensus ranking uses it.
+CREATE TABLE IF NOT EXISTS ranking_consensus_sources (
+    ranking_id        integer REFERENCES rankings(id), -- The consensus ranking
+    source_ranking_id integer REFERENCES rankings(id),
+    weight            real NOT NULL,
+    missing_rank      integer NOT NULL, -- The rank used for players that weren't in the source ranking
+    PRIMARY KEY (ranking_id, source_ranking_id)
+);
+
+-- The rank each source ranking gave the players when the consensus ranking was
+-- blended, so the disagreement between the sources can be shown. Players that
+-- weren't in a source ranking don't have a row for it.
+CREATE TABLE IF NOT EXISTS ranking_consensus_players (
+    ranking_id        integer NOT NULL,
+    source_ranking_id integer NOT NULL,
+    player_id         varchar(16) REFERENCES players(id),
+    ranking           integer NOT NULL,
+    PRIMARY KEY (ranking_id, source_ranking_id, player_id),
+    FOREIGN KEY (ranking_id, source_ranking_id) REFERENCES ranking_consensus_sources(ranking_id, source_ranking_id)
+);
+
+-- Names from rankings files that have been matched to a player by hand, so future
+-- imports match them automatically. The name is normalized, see aliasName().
+CREATE TABLE IF NOT EXISTS player_aliases (
+    name      varchar(128) NOT NULL,
+    position  varchar(8) NOT NULL,
+    player_id varchar(16) REFERENCES players(id),
+    created   timestamp with time zone DEFAULT (now() at time zone 'utc'),
+    PRIMARY KEY (name, position)
+);
+
 CREATE TABLE IF NOT EXISTS leagues (
     id          serial PRIMARY KEY,
     platform    varchar(16) NOT NULL, -- Where the league is hosted, sleeper, yahoo, etc.
@@ -58,6 +107,21 @@ CREATE TABLE IF NOT EXISTS leagues (
     created     timestamp with time zone DEFAULT (now() at time zone 'utc')
 );
 
+-- A franchise chains the seasons of a league together, since some platforms
+-- create a new league every season.
+CREATE TABLE IF NOT EXISTS franchises (
+    id      serial PRIMARY KEY,
+    name    varchar(64) NOT NULL,
+    created timestamp with time zone DEFAULT (now() at time zone 'utc')
+);
+
+-- A league can only be a season in a single franchise.
+CREATE TABLE IF NOT EXISTS franchise_leagues (
+    league_id    serial REFERENCES leagues(id),
+    franchise_id serial REFERENCES franchises(id) ON DELETE CASCADE,
+    PRIMARY KEY (league_id)
+);
+
 CREATE TABLE IF NOT EXISTS tokens (
     league_id     serial REFERENCES leagues(id),
     access_token  text,
@@ -99,6 +163,10 @@ CREATE TABLE IF NOT EXISTS team_results (
     match_id       serial NOT NULL, -- from the match_ids sequence
     team           varchar(64) NOT NULL,
     score          integer NOT NULL,
+    -- The team's record for the week if they had played every other team
+    allplay_wins   smallint NOT NULL DEFAULT 0,
+    allplay_losses smallint NOT NULL DEFAULT 0,
+    allplay_draws  smallint NOT NULL DEFAULT 0,
     FOREIGN KEY (league_id, team) REFERENCES league_managers(league_id, external_id)
 );
 
@@ -108,9 +176,33 @@ CREATE TABLE IF NOT EXISTS power_rankings (
     league_id  serial REFERENCES leagues(id),
     ranking_id serial REFERENCES rankings(id), -- Which set of rankings were used to calculate these rankings
     week       smallint, -- If set week will be used to determine win/loss records and streaks as they apply to power rankings 
+    -- The config the power ranking was calculated with. The defaults match the
+    -- weights used before the config could be changed.
+    bench_percent          smallint NOT NULL DEFAULT 40,
+    points_against_percent smallint NOT NULL DEFAULT 30,
+    win_points             smallint NOT NULL DEFAULT 10,
+    streak_points          smallint NOT NULL DEFAULT 5,
+    points_weeks           smallint NOT NULL DEFAULT 3,
+    allplay_points         smallint NOT NULL DEFAULT 0,
+    schedule_points        smallint NOT NULL DEFAULT 0,
+    tie_breaks             varchar(128) NOT NULL DEFAULT 'points_for,record,head_to_head,roster',
+    compare_id integer REFERENCES power_rankings(id) ON DELETE SET NULL, -- The power ranking the rank changes were calculated from
     created    timestamp with time zone DEFAULT (now() at time zone 'utc')
 );
 
+-- The power ranking weights for a league, leagues without a row use the defaults.
+CREATE TABLE IF NOT EXISTS power_ranking_configs (
+    league_id              serial PRIMARY KEY REFERENCES leagues(id),
+    bench_percent          smallint NOT NULL, -- percent of a bench player's value used in the roster score
+    points_against_percent smallint NOT NULL, -- percent of the average points against added to the score
+    win_points             smallint NOT NULL, -- points for each win, each loss subtracts the same
+    streak_points          smallint NOT NULL, -- points for each game in the current streak
+    points_weeks           smallint NOT NULL, -- number of recent weeks used to average points for and against
+    allplay_points         smallint NOT NULL, -- points for each expected win from the all-play record, 0 to not use it
+    schedule_points        smallint NOT NULL, -- points for each game over .500 by past opponents, 0 to not use it
+    tie_breaks             varchar(128) NOT NULL DEFAULT 'points_for,record,head_to_head,roster' -- comma separated, in the order they are used
+);
+
 -- These are the individual team results for a specific power ranking
 CREATE TABLE IF NOT EXISTS team_power_rankings (
     power_ranking_id     serial REFERENCES power_rankings(id),
@@ -119,15 +211,24 @@ CREATE TABLE IF NOT EXISTS team_power_rankings (
     rank                 smallint NOT NULL, -- what ranking did the power ranking algorithm assign the team
     rank_change          smallint, -- how did the ranking change from the previous ranking?
     total_score          integer NOT NULL, -- how many points the power ranking algorithm assigned the team
-    roster_score         integer, -- the portion of the total score from the roster
-    record_score         integer, -- the portion of the total score from a team's record
-    streak_score         integer, -- the portion of the total score from a team's current win/loss streak
-    points_for_score     integer, -- the portion of the total score calculated by points scored
-    points_against_score integer, -- the portion of the total score calculated by the points against
+    position             smallint NOT NULL DEFAULT 0, -- the order of the team in the power ranking, tied teams are in tie-break order
     PRIMARY KEY (power_ranking_id, league_id, team),
     FOREIGN KEY (league_id, team) REFERENCES league_managers(league_id, external_id)
 );
 
+-- The portion of a team's total score from each component of the power ranking
+-- algorithm, like the roster, record or streak.
+CREATE TABLE IF NOT EXISTS team_power_ranking_scores (
+    power_ranking_id serial REFERENCES power_rankings(id),
+    league_id        serial REFERENCES leagues(id),
+    team             varchar(64),
+    component        varchar(32) NOT NULL, -- the name of the component
+    position         smallint NOT NULL, -- the order the component was calculated in
+    score            integer NOT NULL,
+    PRIMARY KEY (power_ranking_id, league_id, team, component),
+    FOREIGN KEY (league_id, team) REFERENCES league_managers(league_id, external_id)
+);
+
 CREATE TABLE IF NOT EXISTS power_rankings_rosters (
     power_ranking_id serial REFERENCES power_rankings(id),
     league_id        serial REFERENCES leagues(id),
@@ -139,10 +240,132 @@ CREATE TABLE IF NOT EXISTS power_rankings_rosters (
     -- Non-starters only have a portion of their score used here.
     player_points    integer NOT NULL,
     starter          boolean NOT NULL,
+    roster_spot      varchar(32) NOT NULL DEFAULT '', -- the roster spot a starter was picked for, like QB or RB/WR/TE
     PRIMARY KEY (power_ranking_id, league_id, team, player_id),
     FOREIGN KEY (league_id, team) REFERENCES league_managers(league_id, external_id)
 );
 
+-- Completed transactions for a league, adds, drops, waiver claims and trades.
+CREATE TABLE IF NOT EXISTS transactions (
+    id          serial PRIMARY KEY,
+    league_id   serial REFERENCES leagues(id),
+    external_id varchar(64) NOT NULL, -- The id assigned by the platform
+    type        varchar(16) NOT NULL, -- add, drop, waiver or trade
+    week        smallint NOT NULL,
+    waiver_bid  integer, -- The FAAB bid for a waiver claim
+    processed   timestamp with time zone NOT NULL,
+    created     timestamp with time zone DEFAULT (now() at time zone 'utc'),
+    UNIQUE (league_id, external_id)
+);
+
+-- Everything that moved in a transaction, players, draft picks and FAAB budget.
+CREATE TABLE IF NOT EXISTS transaction_assets (
+    transaction_id serial REFERENCES transactions(id) ON DELETE CASCADE,
+    idx            smallint NOT NULL, -- Keeps the assets in the order the platform returned them
+    asset_type     varchar(8) NOT NULL, -- player, pick or faab
+    from_team      varchar(64), -- NULL when the player came from free agency or waivers
+    to_team        varchar(64), -- NULL when the player was dropped
+    player_id      varchar(16) REFERENCES players(id),
+    pick_season    varchar(4),
+    pick_round     smallint,
+    pick_team      varchar(64), -- The team that originally owned the draft pick
+    faab           integer,
+    PRIMARY KEY (transaction_id, idx)
+);
+
+-- Drafts for a league, a league can have more than one (e.g. dynasty rookie drafts).
+CREATE TABLE IF NOT EXISTS drafts (
+    id          serial PRIMARY KEY,
+    league_id   serial REFERENCES leagues(id),
+    external_id varchar(64) NOT NULL, -- The id assigned by the platform
+    draft_type  varchar(8) NOT NULL, -- snake, linear or auction
+    rounds      smallint NOT NULL,
+    started     timestamp with time zone NOT NULL,
+    created     timestamp with time zone DEFAULT (now() at time zone 'utc'),
+    UNIQUE (league_id, external_id)
+);
+
+CREATE TABLE IF NOT EXISTS draft_picks (
+    draft_id  serial REFERENCES drafts(id) ON DELETE CASCADE,
+    pick_no   smallint NOT NULL, -- The overall pick number, starting at 1
+    round     smallint NOT NULL,
+    team      varchar(64) NOT NULL,
+    player_id varchar(16) REFERENCES players(id),
+    keeper    boolean NOT NULL DEFAULT false,
+    cost      integer NOT NULL DEFAULT 0, -- The auction price
+    PRIMARY KEY (draft_id, pick_no)
+);
+
+-- Each team's Elo rating after every week with results. Week 0 is the rating the
+-- team started the season with, which can carry over from the previous season.
+CREATE TABLE IF NOT EXISTS elo_ratings (
+    league_id serial REFERENCES leagues(id),
+    week      smallint NOT NULL,
+    team      varchar(64) NOT NULL,
+    rating    double precision NOT NULL,
+    PRIMARY KEY (league_id, week, team),
+    FOREIGN KEY (league_id, team) REFERENCES league_managers(league_id, external_id)
+);
+
+-- CREATE TABLE IF NOT EXISTS doesn't change a table that already exists, so the
+-- columns added since a table was created are also added here for older databases.
+-- These do nothing on a new database.
+ALTER TABLE players ADD COLUMN IF NOT EXISTS espn_id varchar(16);
+ALTER TABLE players ADD COLUMN IF NOT EXISTS mfl_id varchar(16);
+ALTER TABLE players ADD COLUMN IF NOT EXISTS fleaflicker_id varchar(16);
+ALTER TABLE power_rankings
+    ADD COLUMN IF NOT EXISTS bench_percent          smallint NOT NULL DEFAULT 40,
+    ADD COLUMN IF NOT EXISTS points_against_percent smallint NOT NULL DEFAULT 30,
+    ADD COLUMN IF NOT EXISTS win_points             smallint NOT NULL DEFAULT 10,
+    ADD COLUMN IF NOT EXISTS streak_points          smallint NOT NULL DEFAULT 5,
+    ADD COLUMN IF NOT EXISTS points_weeks           smallint NOT NULL DEFAULT 3;
+ALTER TABLE team_results
+    ADD COLUMN IF NOT EXISTS allplay_wins   smallint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS allplay_losses smallint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS allplay_draws  smallint NOT NULL DEFAULT 0;
+ALTER TABLE power_rankings ADD COLUMN IF NOT EXISTS allplay_points smallint NOT NULL DEFAULT 0;
+ALTER TABLE power_rankings ADD COLUMN IF NOT EXISTS schedule_points smallint NOT NULL DEFAULT 0;
+ALTER TABLE power_rankings ADD COLUMN IF NOT EXISTS compare_id integer REFERENCES power_rankings(id) ON DELETE SET NULL;
+ALTER TABLE power_rankings_rosters ADD COLUMN IF NOT EXISTS roster_spot varchar(32) NOT NULL DEFAULT '';
+ALTER TABLE power_rankings ADD COLUMN IF NOT EXISTS tie_breaks varchar(128) NOT NULL DEFAULT 'points_for,record,head_to_head,roster';
+ALTER TABLE team_power_rankings ADD COLUMN IF NOT EXISTS position smallint NOT NULL DEFAULT 0;
+ALTER TABLE rankings
+    ADD COLUMN IF NOT EXISTS source         varchar(16) NOT NULL DEFAULT 'fantasypros',
+    ADD COLUMN IF NOT EXISTS scoring_format varchar(16) NOT NULL DEFAULT '';
+
+-- Power rankings used to save the component scores as columns on team_power_rankings.
+-- Copy them into team_power_ranking_scores, with the names and order of the components
+-- in powerRankingComponents, and then drop the old columns.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = current_schema() AND table_name = 'team_power_rankings'
+                 AND column_name = 'roster_score') THEN
+        INSERT INTO team_power_ranking_scores (power_ranking_id, league_id, team, component, position, score)
+            SELECT t.power_ranking_id, t.league_id, t.team, s.component, s.position, coalesce(s.score, 0)
+            FROM team_power_rankings t
+            CROSS JOIN LATERAL (VALUES
+                ('Roster', 0, t.roster_score),
+                ('Points For', 1, t.points_for_score),
+                ('Points Against', 2, t.points_against_score),
+                ('Record', 3, t.record_score),
+                ('Streak', 4, t.streak_score)
+            ) AS s(component, position, score)
+            ON CONFLICT DO NOTHING;
+
+        ALTER TABLE team_power_rankings
+            DROP COLUMN roster_score,
+            DROP COLUMN record_score,
+            DROP COLUMN streak_score,
+            DROP COLUMN points_for_score,
+            DROP COLUMN points_against_score;
+    END IF;
+END $$;
+
 CREATE INDEX IF NOT EXISTS player_name_idx ON players USING gin(fts_player);
 CREATE INDEX IF NOT EXISTS player_yahoo_id_idx ON players(yahoo_id);
+CREATE INDEX IF NOT EXISTS player_espn_id_idx ON players(espn_id);
+CREATE INDEX IF NOT EXISTS player_mfl_id_idx ON players(mfl_id);
+CREATE INDEX IF NOT EXISTS player_fleaflicker_id_idx ON players(fleaflicker_id);
 CREATE INDEX IF NOT EXISTS player_change_idx ON player_changes(player, created DESC);
+CREATE INDEX IF NOT EXISTS transactions_week_idx ON transactions(league_id, week);
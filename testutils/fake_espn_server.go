@@ -0,0 +1,101 @@
+package testutils
+
+import (
+	"embed"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	ESPNLeagueID = "336358"
+	ESPNSWID     = "{TEST-SWID}"
+)
+
+//go:embed espndata
+var espndata embed.FS
+
+type FakeESPNServer struct {
+	s *httptest.Server
+}
+
+func NewFakeESPNServer() *FakeESPNServer {
+	r := chi.NewRouter()
+	r.Get("/apis/v2/fans/{swid}", espnFanHandler)
+	r.Get("/apis/v3/games/ffl/seasons/{year}/segments/0/leagues/{leagueID}", espnLeagueHandler)
+
+	return &FakeESPNServer{
+		s: httptest.NewServer(r),
+	}
+}
+
+func (f *FakeESPNServer) Close() {
+	f.s.Close()
+}
+
+func (f *FakeESPNServer) URL() string {
+	return f.s.URL
+}
+
+func espnFanHandler(w http.ResponseWriter, r *http.Request) {
+	if chi.URLParam(r, "swid") != ESPNSWID {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"messages": ["fan not found"]}`))
+		return
+	}
+	serveESPNFile(w, "fan.json")
+}
+
+func espnLeagueHandler(w http.ResponseWriter, r *http.Request) {
+	// The real API returns a 401 for private leagues when the cookies are missing.
+	if _, err := r.Cookie("espn_s2"); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"messages": ["You are not authorized to view this League."]}`))
+		return
+	}
+
+	if chi.URLParam(r, "leagueID") != ESPNLeagueID || chi.URLParam(r, "year") != "2024" {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"messages": ["Not Found"]}`))
+		return
+	}
+
+	views := r.URL.Query()["view"]
+	switch {
+	case slices.Contains(views, "mSettings"):
+		serveESPNFile(w, "league_settings.json")
+	case slices.Contains(views, "mTeam"):
+		serveESPNFile(w, "league_teams.json")
+	case slices.Contains(views, "mRoster"):
+		serveESPNFile(w, "league_rosters.json")
+	case slices.Contains(views, "mMatchupScore"):
+		week := r.URL.Query().Get("scoringPeriodId")
+		if week != "1" {
+			// ESPN returns the schedule without any scores for future weeks
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"schedule": []}`))
+			return
+		}
+		serveESPNFile(w, "matchups-week-01.json")
+	default:
+		log.Printf("unsupported views requested from fake espn server: %v", views)
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func serveESPNFile(w http.ResponseWriter, name string) {
+	b, err := espndata.ReadFile(fmt.Sprintf("espndata/%s", name))
+	if err != nil {
+		log.Printf("error reading espndata/%s: %v", name, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
@@ -0,0 +1,122 @@
+package testutils
+
+import (
+	"embed"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	FleaflickerLeagueID = "349505"
+	FleaflickerEmail    = "flickeruser@example.com"
+)
+
+//go:embed fleaflickerdata
+var fleaflickerdata embed.FS
+
+type FakeFleaflickerServer struct {
+	s *httptest.Server
+}
+
+func NewFakeFleaflickerServer() *FakeFleaflickerServer {
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		r.Get("/FetchUserLeagues", fleaflickerUserLeaguesHandler)
+		r.Get("/FetchLeagueStandings", fleaflickerLeagueHandler("league_standings.json"))
+		r.Get("/FetchLeagueRosters", fleaflickerLeagueHandler("league_rosters.json"))
+		r.Get("/FetchLeagueRules", fleaflickerLeagueHandler("league_rules.json"))
+		r.Get("/FetchLeagueScoreboard", fleaflickerScoreboardHandler)
+		r.Get("/FetchLeagueBoxscore", fleaflickerBoxscoreHandler)
+	})
+
+	return &FakeFleaflickerServer{
+		s: httptest.NewServer(r),
+	}
+}
+
+func (f *FakeFleaflickerServer) Close() {
+	f.s.Close()
+}
+
+func (f *FakeFleaflickerServer) URL() string {
+	return f.s.URL
+}
+
+func fleaflickerUserLeaguesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("email") == FleaflickerEmail && r.URL.Query().Get("season") == "2024" {
+		serveFleaflickerFile(w, "user_leagues.json")
+	} else {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}
+}
+
+func fleaflickerLeagueHandler(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isFleaflickerLeague(w, r) {
+			return
+		}
+		serveFleaflickerFile(w, name)
+	}
+}
+
+func fleaflickerScoreboardHandler(w http.ResponseWriter, r *http.Request) {
+	if !isFleaflickerLeague(w, r) {
+		return
+	}
+	if r.URL.Query().Get("scoring_period") != "1" {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"games": []}`))
+		return
+	}
+	serveFleaflickerFile(w, "league_scoreboard_week_01.json")
+}
+
+func fleaflickerBoxscoreHandler(w http.ResponseWriter, r *http.Request) {
+	if !isFleaflickerLeague(w, r) {
+		return
+	}
+	gameID := r.URL.Query().Get("fantasy_game_id")
+	if gameID != "48290001" && gameID != "48290002" {
+		fleaflickerError(w, http.StatusNotFound, "Game not found")
+		return
+	}
+	serveFleaflickerFile(w, fmt.Sprintf("league_boxscore_%s.json", gameID))
+}
+
+// Verify the request is for the fake league, and write an error response if it isn't.
+func isFleaflickerLeague(w http.ResponseWriter, r *http.Request) bool {
+	q := r.URL.Query()
+	if q.Get("sport") != "NFL" || q.Get("league_id") != FleaflickerLeagueID {
+		fleaflickerError(w, http.StatusNotFound, "League not found")
+		return false
+	}
+	if season := q.Get("season"); season != "" && season != "2024" {
+		fleaflickerError(w, http.StatusNotFound, "Season not found")
+		return false
+	}
+	return true
+}
+
+func fleaflickerError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(fmt.Sprintf(`{"errorMessage": "%s"}`, msg)))
+}
+
+func serveFleaflickerFile(w http.ResponseWriter, name string) {
+	b, err := fleaflickerdata.ReadFile(fmt.Sprintf("fleaflickerdata/%s", name))
+	if err != nil {
+		log.Printf("error reading fleaflickerdata/%s: %v", name, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
@@ -0,0 +1,146 @@
+package testutils
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const MFLLeagueID = "38291"
+
+//go:embed mfldata
+var mfldata embed.FS
+
+type FakeMFLServer struct {
+	s *httptest.Server
+}
+
+func NewFakeMFLServer() *FakeMFLServer {
+	r := chi.NewRouter()
+	r.Get("/{year:\\d+}/export", mflExportHandler)
+
+	return &FakeMFLServer{
+		s: httptest.NewServer(r),
+	}
+}
+
+func (f *FakeMFLServer) Close() {
+	f.s.Close()
+}
+
+func (f *FakeMFLServer) URL() string {
+	return f.s.URL
+}
+
+func mflExportHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("JSON") != "1" {
+		log.Printf("fake mfl server only supports JSON responses")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	reqType := q.Get("TYPE")
+	if reqType == "myleagues" {
+		serveMFLFile(w, "myleagues.json")
+		return
+	}
+	if reqType == "players" {
+		mflPlayersHandler(w, strings.Split(q.Get("PLAYERS"), ","))
+		return
+	}
+
+	// MFL returns a 200 with an error message in the body for unknown leagues.
+	if q.Get("L") != MFLLeagueID || chi.URLParam(r, "year") != "2024" {
+		mflError(w, "Invalid league ID")
+		return
+	}
+
+	switch reqType {
+	case "league":
+		serveMFLFile(w, "league.json")
+	case "rosters":
+		serveMFLFile(w, "rosters.json")
+	case "leagueStandings":
+		serveMFLFile(w, "league-standings.json")
+	case "weeklyResults":
+		if q.Get("W") != "1" {
+			// MFL returns an empty result for weeks that haven't been played
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"version":"1.0","weeklyResults":{"week":"` + q.Get("W") + `"},"encoding":"utf-8"}`))
+			return
+		}
+		serveMFLFile(w, "weekly-results-week-01.json")
+	default:
+		mflError(w, fmt.Sprintf("Unknown TYPE: %s", reqType))
+	}
+}
+
+// Only return the requested players, and like MFL return a single
+// object instead of an array when there is only one result.
+func mflPlayersHandler(w http.ResponseWriter, ids []string) {
+	b, err := mfldata.ReadFile("mfldata/players.json")
+	if err != nil {
+		log.Printf("error reading mfldata/players.json: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var all struct {
+		Players struct {
+			Player []map[string]string `json:"player"`
+		} `json:"players"`
+	}
+	if err := json.Unmarshal(b, &all); err != nil {
+		log.Printf("error parsing mfldata/players.json: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	found := make([]map[string]string, 0, len(ids))
+	for _, p := range all.Players.Player {
+		if slices.Contains(ids, p["id"]) {
+			found = append(found, p)
+		}
+	}
+
+	var players any = found
+	if len(found) == 1 {
+		players = found[0]
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"version": "1.0",
+		"players": map[string]any{"player": players},
+	})
+}
+
+func mflError(w http.ResponseWriter, msg string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"version": "1.0",
+		"error":   map[string]string{"$t": msg},
+	})
+}
+
+func serveMFLFile(w http.ResponseWriter, name string) {
+	b, err := mfldata.ReadFile(fmt.Sprintf("mfldata/%s", name))
+	if err != nil {
+		log.Printf("error reading mfldata/%s: %v", name, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
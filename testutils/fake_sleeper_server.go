@@ -11,7 +11,12 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-const SleeperLeagueID = "924039165950484480"
+const (
+	SleeperLeagueID = "924039165950484480"
+	SleeperDraftID  = "924094593375830016"
+	// The previous season of SleeperLeagueID, it has the same managers.
+	SleeperPreviousLeagueID = "784462448236363776"
+)
 
 //go:embed sleeperdata
 var sleeperdata embed.FS
@@ -35,7 +40,11 @@ func NewFakeSleeperServer() *FakeSleeperServer {
 			r.Get("/users", leagueUsersHandler)
 			r.Get("/rosters", leagueRostersHandler)
 			r.Get("/matchups/{week:\\d+}", leagueMatchupsHandlers)
+			r.Get("/transactions/{week:\\d+}", leagueTransactionsHandler)
+			r.Get("/drafts", leagueDraftsHandler)
 		})
+
+		r.Get("/draft/{draftID}/picks", draftPicksHandler)
 	})
 
 	return &FakeSleeperServer{
@@ -82,6 +91,8 @@ func leagueHandler(w http.ResponseWriter, r *http.Request) {
 	leagueID := chi.URLParam(r, "leagueID")
 	if leagueID == SleeperLeagueID {
 		serveSleeperFile(w, "league.json")
+	} else if leagueID == SleeperPreviousLeagueID {
+		serveSleeperFile(w, "league-2022.json")
 	} else {
 		w.WriteHeader(http.StatusNotFound)
 		w.Write([]byte("null"))
@@ -90,7 +101,7 @@ func leagueHandler(w http.ResponseWriter, r *http.Request) {
 
 func leagueUsersHandler(w http.ResponseWriter, r *http.Request) {
 	leagueID := chi.URLParam(r, "leagueID")
-	if leagueID == SleeperLeagueID {
+	if leagueID == SleeperLeagueID || leagueID == SleeperPreviousLeagueID {
 		serveSleeperFile(w, "league_users.json")
 	} else {
 		w.WriteHeader(http.StatusOK)
@@ -100,7 +111,7 @@ func leagueUsersHandler(w http.ResponseWriter, r *http.Request) {
 
 func leagueRostersHandler(w http.ResponseWriter, r *http.Request) {
 	leagueID := chi.URLParam(r, "leagueID")
-	if leagueID == SleeperLeagueID {
+	if leagueID == SleeperLeagueID || leagueID == SleeperPreviousLeagueID {
 		serveSleeperFile(w, "league_rosters.json")
 	} else {
 		w.WriteHeader(http.StatusOK)
@@ -115,6 +126,11 @@ func leagueMatchupsHandlers(w http.ResponseWriter, r *http.Request) {
 			if week <= 5 && week >= 1 {
 				serveSleeperFile(w, fmt.Sprintf("matchups-week-%02d.json", week))
 				return
+			} else if week > 5 && week <= 14 {
+				// The rest of the regular season hasn't been played yet, the
+				// teams rotate through the same three sets of matchups.
+				serveSleeperFile(w, fmt.Sprintf("matchups-unplayed-%d.json", week%3))
+				return
 			}
 		} else {
 			log.Printf("error parsing week param: %v", err)
@@ -126,6 +142,39 @@ func leagueMatchupsHandlers(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(`{"errMsg": "not found"}`))
 }
 
+func leagueDraftsHandler(w http.ResponseWriter, r *http.Request) {
+	leagueID := chi.URLParam(r, "leagueID")
+	if leagueID == SleeperLeagueID {
+		serveSleeperFile(w, "league_drafts.json")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("[]"))
+}
+
+func draftPicksHandler(w http.ResponseWriter, r *http.Request) {
+	if chi.URLParam(r, "draftID") == SleeperDraftID {
+		serveSleeperFile(w, "draft_picks.json")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("[]"))
+}
+
+func leagueTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	leagueID := chi.URLParam(r, "leagueID")
+	if leagueID == SleeperLeagueID && chi.URLParam(r, "week") == "2" {
+		serveSleeperFile(w, "transactions-week-02.json")
+		return
+	}
+
+	// Sleeper returns an empty list for weeks without any transactions
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("[]"))
+}
+
 func serveSleeperFile(w http.ResponseWriter, name string) {
 	b, err := sleeperdata.ReadFile(fmt.Sprintf("sleeperdata/%s", name))
 	if err != nil {
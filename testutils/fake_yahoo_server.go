@@ -13,7 +13,6 @@ import (
 
 const (
 	YahooLeagueID = "431"
-	fullYahooID   = "nfl.l.431"
 	YahooTeam05ID = "223.l.431.t.5"
 	YahooTeam08ID = "223.l.431.t.8"
 	YahooTeam10ID = "223.l.431.t.10"
@@ -36,8 +35,14 @@ func NewFakeYahooServer() *FakeYahooServer {
 			r.Get("/settings", leagueSettingsHandler)
 			r.Get("/standings", leagueStandingsHandler)
 			r.Get("/scoreboard;week={week}", leagueScoreboardHandler)
+			r.Get("/transactions", yahooLeagueTransactionsHandler)
+			r.Get("/draftresults/players", yahooDraftResultsHandler)
 		})
 		r.Get("/team/{teamID}/roster", rosterHandler)
+		r.Get("/team/{teamID}/roster;week={week}/players/stats", rosterStatsHandler)
+		r.Get("/users;use_login=1/games;game_codes=nfl/leagues", yahooUserLeaguesHandler)
+		r.Get("/games;game_codes=nfl;seasons={year}", yahooGamesHandler)
+		r.Get("/game/{gameKey}/game_weeks", yahooGameWeeksHandler)
 	})
 
 	return &FakeYahooServer{
@@ -53,9 +58,34 @@ func (f *FakeYahooServer) URL() string {
 	return f.s.URL
 }
 
+func yahooUserLeaguesHandler(w http.ResponseWriter, r *http.Request) {
+	serveYahooFile(w, "user_leagues.xml")
+}
+
+func yahooGamesHandler(w http.ResponseWriter, r *http.Request) {
+	year := chi.URLParam(r, "year")
+	if year == "2023" || year == "2024" {
+		serveYahooFile(w, fmt.Sprintf("games-%s.xml", year))
+		return
+	}
+
+	// Yahoo returns an empty games list for seasons it doesn't know about
+	serveYahooFile(w, "games-empty.xml")
+}
+
+func yahooGameWeeksHandler(w http.ResponseWriter, r *http.Request) {
+	if chi.URLParam(r, "gameKey") == "449" {
+		serveYahooFile(w, "game_weeks.xml")
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte("error"))
+}
+
 func leagueMetadataHandler(w http.ResponseWriter, r *http.Request) {
 	leagueID := chi.URLParam(r, "leagueID")
-	if leagueID == fullYahooID {
+	if isYahooLeague(leagueID) {
 		serveYahooFile(w, "league_metadata.xml")
 		return
 	}
@@ -66,7 +96,7 @@ func leagueMetadataHandler(w http.ResponseWriter, r *http.Request) {
 
 func leagueSettingsHandler(w http.ResponseWriter, r *http.Request) {
 	leagueID := chi.URLParam(r, "leagueID")
-	if leagueID == fullYahooID {
+	if isYahooLeague(leagueID) {
 		serveYahooFile(w, "settings.xml")
 		return
 	}
@@ -77,7 +107,7 @@ func leagueSettingsHandler(w http.ResponseWriter, r *http.Request) {
 
 func leagueStandingsHandler(w http.ResponseWriter, r *http.Request) {
 	leagueID := chi.URLParam(r, "leagueID")
-	if leagueID == fullYahooID {
+	if isYahooLeague(leagueID) {
 		serveYahooFile(w, "standings.xml")
 		return
 	}
@@ -88,12 +118,12 @@ func leagueStandingsHandler(w http.ResponseWriter, r *http.Request) {
 
 func leagueScoreboardHandler(w http.ResponseWriter, r *http.Request) {
 	leagueID := chi.URLParam(r, "leagueID")
-	if leagueID == fullYahooID {
+	if isYahooLeague(leagueID) {
 		weekStr := chi.URLParam(r, "week")
 		week, err := strconv.Atoi(weekStr)
 		if err != nil {
 			log.Printf("error parsing week param: %v", err)
-		} else if week == 1 {
+		} else if week == 1 || week == 13 {
 			serveYahooFile(w, fmt.Sprintf("scoreboard-week-%02d.xml", week))
 			return
 		}
@@ -103,6 +133,28 @@ func leagueScoreboardHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("error"))
 }
 
+func yahooLeagueTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	leagueID := chi.URLParam(r, "leagueID")
+	if isYahooLeague(leagueID) {
+		serveYahooFile(w, "transactions.xml")
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte("error"))
+}
+
+func yahooDraftResultsHandler(w http.ResponseWriter, r *http.Request) {
+	leagueID := chi.URLParam(r, "leagueID")
+	if isYahooLeague(leagueID) {
+		serveYahooFile(w, "draftresults.xml")
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte("error"))
+}
+
 func rosterHandler(w http.ResponseWriter, r *http.Request) {
 	teamID := chi.URLParam(r, "teamID")
 	switch teamID {
@@ -124,6 +176,35 @@ func rosterHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func rosterStatsHandler(w http.ResponseWriter, r *http.Request) {
+	teamID := chi.URLParam(r, "teamID")
+	week := chi.URLParam(r, "week")
+
+	var team string
+	switch teamID {
+	case YahooTeam05ID:
+		team = "05"
+	case YahooTeam08ID:
+		team = "08"
+	case YahooTeam10ID:
+		team = "10"
+	case YahooTeam12ID:
+		team = "12"
+	}
+
+	if team == "" || week != "1" {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("error"))
+		return
+	}
+	serveYahooFile(w, fmt.Sprintf("roster-stats-week-01-team-%s.xml", team))
+}
+
+// The test league exists in both the 2023 (423) and 2024 (449) seasons.
+func isYahooLeague(leagueKey string) bool {
+	return leagueKey == "423.l.431" || leagueKey == "449.l.431"
+}
+
 func serveYahooFile(w http.ResponseWriter, name string) {
 	b, err := yahoodata.ReadFile(fmt.Sprintf("yahoodata/%s", name))
 	if err != nil {
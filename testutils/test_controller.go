@@ -15,12 +15,18 @@ type TestController struct {
 	YahooConfig *oauth2.Config
 	fakeSleeper *FakeSleeperServer
 	fakeYahoo   *FakeYahooServer
+	fakeESPN    *FakeESPNServer
+	fakeMFL     *FakeMFLServer
+	fakeFlea    *FakeFleaflickerServer
 	fakeOAuth   *httptest.Server
 }
 
 func (c *TestController) Close() {
 	c.fakeSleeper.Close()
 	c.fakeYahoo.Close()
+	c.fakeESPN.Close()
+	c.fakeMFL.Close()
+	c.fakeFlea.Close()
 	c.fakeOAuth.Close()
 }
 
@@ -32,6 +38,18 @@ func (c *TestController) YahooURL() string {
 	return c.fakeYahoo.URL()
 }
 
+func (c *TestController) ESPNURL() string {
+	return c.fakeESPN.URL()
+}
+
+func (c *TestController) MFLURL() string {
+	return c.fakeMFL.URL()
+}
+
+func (c *TestController) FleaflickerURL() string {
+	return c.fakeFlea.URL()
+}
+
 func NewTestController(db *TestDB) *TestController {
 	fakeOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("request to fake oauth server: %s", r.RequestURI)
@@ -60,6 +78,9 @@ func NewTestController(db *TestDB) *TestController {
 		YahooConfig: fakeYahooConfig,
 		fakeSleeper: NewFakeSleeperServer(),
 		fakeYahoo:   NewFakeYahooServer(),
+		fakeESPN:    NewFakeESPNServer(),
+		fakeMFL:     NewFakeMFLServer(),
+		fakeFlea:    NewFakeFleaflickerServer(),
 		fakeOAuth:   fakeOAuthServer,
 	}
 }
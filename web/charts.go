@@ -0,0 +1,224 @@
+package web
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+// The sizes of the rank history chart, in pixels.
+const (
+	chartMargin      = 40
+	chartWeekWidth   = 60
+	chartRankHeight  = 30
+	chartLabelsWidth = 160
+)
+
+// The sizes of the value charts, in pixels. The values are scaled to fit the height,
+// and the axis is wider to fit large values.
+const (
+	chartValueHeight    = 240
+	chartValueAxisWidth = 70
+	chartValueTicks     = 5
+)
+
+// Colors for the lines of the chart, they repeat if there are more teams.
+var chartColors = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b",
+	"#e377c2", "#7f7f7f", "#bcbd22", "#17becf", "#393b79", "#637939",
+}
+
+// rankChart has everything needed to draw the rank history of the teams as an SVG,
+// with the weeks along the bottom and rank 1 at the top.
+type rankChart struct {
+	Width  int
+	Height int
+	Weeks  []chartLabel
+	Ranks  []chartLabel
+	Lines  []chartLine
+}
+
+type chartLabel struct {
+	X    int
+	Y    int
+	Text string
+}
+
+type chartLine struct {
+	Color  string
+	Points string     // The points of the SVG polyline
+	Label  chartLabel // The team name, next to the team's last rank
+}
+
+func newRankChart(h *model.PowerRankingHistory) *rankChart {
+	numRanks := len(h.Teams)
+	for _, t := range h.Teams {
+		for _, r := range t.Ranks {
+			numRanks = max(numRanks, r)
+		}
+	}
+
+	x := func(i int) int { return chartMargin + i*chartWeekWidth }
+	y := func(rank int) int { return chartMargin + (rank-1)*chartRankHeight }
+
+	c := &rankChart{
+		Width:  x(max(len(h.Weeks)-1, 0)) + chartLabelsWidth,
+		Height: y(numRanks) + chartMargin,
+		Weeks:  make([]chartLabel, 0, len(h.Weeks)),
+		Ranks:  make([]chartLabel, 0, numRanks),
+		Lines:  make([]chartLine, 0, len(h.Teams)),
+	}
+	for i, w := range h.Weeks {
+		c.Weeks = append(c.Weeks, chartLabel{X: x(i), Y: y(numRanks) + chartMargin/2, Text: fmt.Sprintf("Week %d", w)})
+	}
+	for r := 1; r <= numRanks; r++ {
+		c.Ranks = append(c.Ranks, chartLabel{X: chartMargin / 2, Y: y(r), Text: fmt.Sprint(r)})
+	}
+
+	for i, t := range h.Teams {
+		line := chartLine{Color: chartColors[i%len(chartColors)]}
+		points := make([]string, 0, len(t.Ranks))
+		// Weeks where the team wasn't ranked are skipped, joining the weeks around them
+		for j, r := range t.Ranks {
+			if r == 0 {
+				continue
+			}
+			points = append(points, fmt.Sprintf("%d,%d", x(j), y(r)))
+			line.Label = chartLabel{X: x(j) + 8, Y: y(r), Text: t.TeamName}
+		}
+		line.Points = strings.Join(points, " ")
+		c.Lines = append(c.Lines, line)
+	}
+	return c
+}
+
+// valueChart has everything needed to draw values that change over time as an SVG,
+// like a team's roster value each week or a player's rank in each ranking.
+type valueChart struct {
+	Width   int
+	Height  int
+	XLabels []chartLabel
+	YLabels []chartLabel
+	Lines   []chartLine
+}
+
+// One line of a value chart, with a value for each of the x labels. Values of 0
+// are missing and are skipped, joining the values around them.
+type chartSeries struct {
+	Name   string
+	Values []int64
+}
+
+// Build a chart with the values scaled between the smallest and largest value. The
+// largest value is at the top, unless inverted is true, which is used for ranks so
+// that rank 1 is at the top.
+func newValueChart(xLabels []string, series []chartSeries, inverted bool) *valueChart {
+	lo, hi := int64(0), int64(0)
+	for _, s := range series {
+		for _, v := range s.Values {
+			if v == 0 {
+				continue
+			}
+			if lo == 0 || v < lo {
+				lo = v
+			}
+			hi = max(hi, v)
+		}
+	}
+	// Avoid dividing by 0 when every value is the same
+	span := max(hi-lo, 1)
+
+	x := func(i int) int { return chartValueAxisWidth + i*chartWeekWidth }
+	y := func(v int64) int {
+		offset := hi - v
+		if inverted {
+			offset = v - lo
+		}
+		return chartMargin + int(offset*chartValueHeight/span)
+	}
+
+	c := &valueChart{
+		Width:   x(max(len(xLabels)-1, 0)) + chartLabelsWidth,
+		Height:  chartMargin + chartValueHeight + chartMargin,
+		XLabels: make([]chartLabel, 0, len(xLabels)),
+		YLabels: make([]chartLabel, 0, chartValueTicks),
+		Lines:   make([]chartLine, 0, len(series)),
+	}
+	for i, l := range xLabels {
+		c.XLabels = append(c.XLabels, chartLabel{X: x(i), Y: c.Height - chartMargin/2, Text: l})
+	}
+	if hi > 0 {
+		ticks := make([]int64, 0, chartValueTicks)
+		for i := range chartValueTicks {
+			ticks = append(ticks, lo+(hi-lo)*int64(i)/(chartValueTicks-1))
+		}
+		// Small ranges have the same tick more than once
+		for _, v := range slices.Compact(ticks) {
+			c.YLabels = append(c.YLabels, chartLabel{X: chartValueAxisWidth - 10, Y: y(v), Text: fmt.Sprint(v)})
+		}
+	}
+
+	for i, s := range series {
+		line := chartLine{Color: chartColors[i%len(chartColors)]}
+		points := make([]string, 0, len(s.Values))
+		for j, v := range s.Values {
+			if v == 0 {
+				continue
+			}
+			points = append(points, fmt.Sprintf("%d,%d", x(j), y(v)))
+			line.Label = chartLabel{X: x(j) + 8, Y: y(v), Text: s.Name}
+		}
+		line.Points = strings.Join(points, " ")
+		c.Lines = append(c.Lines, line)
+	}
+	return c
+}
+
+// Chart the total value of each team's roster for each week of the power ranking history.
+func newRosterValueChart(h *model.PowerRankingHistory) *valueChart {
+	weeks := make([]string, 0, len(h.Weeks))
+	for _, w := range h.Weeks {
+		weeks = append(weeks, fmt.Sprintf("Week %d", w))
+	}
+	series := make([]chartSeries, 0, len(h.Teams))
+	for _, t := range h.Teams {
+		s := chartSeries{Name: t.TeamName, Values: make([]int64, 0, len(t.RosterValues))}
+		for _, v := range t.RosterValues {
+			s.Values = append(s.Values, int64(v))
+		}
+		series = append(series, s)
+	}
+	return newValueChart(weeks, series, false)
+}
+
+// Chart a player's rank in each ranking, with a line for each source and scoring
+// format since their ranks aren't comparable. Rankings on the same date share a label.
+func newPlayerRankingChart(points []model.PlayerRankingPoint) *valueChart {
+	dates := make([]string, 0, len(points))
+	at := make([]int, len(points)) // The index of the date of each point
+	for i, p := range points {
+		if i == 0 || !p.Date.Equal(points[i-1].Date) {
+			dates = append(dates, p.Date.Format("Jan 2"))
+		}
+		at[i] = len(dates) - 1
+	}
+
+	series := make([]chartSeries, 0)
+	seriesIdx := make(map[string]int)
+	for j, p := range points {
+		name := rankingSourceNames[p.Source]
+		if p.Scoring != model.SCORING_UNKNOWN {
+			name += " " + p.Scoring.Friendly()
+		}
+		i, found := seriesIdx[name]
+		if !found {
+			i = len(series)
+			seriesIdx[name] = i
+			series = append(series, chartSeries{Name: name, Values: make([]int64, len(dates))})
+		}
+		series[i].Values[at[j]] = int64(p.Rank)
+	}
+	return newValueChart(dates, series, true)
+}
@@ -0,0 +1,151 @@
+package web
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mww/fantasy_manager_v2/model"
+)
+
+func TestNewRankChart(t *testing.T) {
+	h := &model.PowerRankingHistory{
+		Weeks:           []int{1, 2, 3},
+		PowerRankingIDs: []int32{4, 5, 6},
+		Teams: []model.TeamRankHistory{
+			{TeamID: "1", TeamName: "AAA", Ranks: []int{2, 1, 1}},
+			{TeamID: "2", TeamName: "BBB", Ranks: []int{1, 0, 2}},
+		},
+	}
+
+	c := newRankChart(h)
+	if c.Width != 40+2*60+160 || c.Height != 40+30+40 {
+		t.Errorf("unexpected chart size: %dx%d", c.Width, c.Height)
+	}
+
+	expectedWeeks := []chartLabel{{X: 40, Y: 90, Text: "Week 1"}, {X: 100, Y: 90, Text: "Week 2"}, {X: 160, Y: 90, Text: "Week 3"}}
+	if !reflect.DeepEqual(expectedWeeks, c.Weeks) {
+		t.Errorf("expected week labels: %v, got: %v", expectedWeeks, c.Weeks)
+	}
+	expectedRanks := []chartLabel{{X: 20, Y: 40, Text: "1"}, {X: 20, Y: 70, Text: "2"}}
+	if !reflect.DeepEqual(expectedRanks, c.Ranks) {
+		t.Errorf("expected rank labels: %v, got: %v", expectedRanks, c.Ranks)
+	}
+
+	// The week team BBB wasn't ranked is skipped
+	expectedLines := []chartLine{
+		{Color: chartColors[0], Points: "40,70 100,40 160,40", Label: chartLabel{X: 168, Y: 40, Text: "AAA"}},
+		{Color: chartColors[1], Points: "40,40 160,70", Label: chartLabel{X: 168, Y: 70, Text: "BBB"}},
+	}
+	if !reflect.DeepEqual(expectedLines, c.Lines) {
+		t.Errorf("expected lines: %v, got: %v", expectedLines, c.Lines)
+	}
+
+	// Without any power rankings there is nothing to draw
+	c = newRankChart(&model.PowerRankingHistory{})
+	if len(c.Weeks) != 0 || len(c.Lines) != 0 {
+		t.Errorf("expected an empty chart, got: %v", c)
+	}
+}
+
+func TestNewValueChart(t *testing.T) {
+	series := []chartSeries{
+		{Name: "AAA", Values: []int64{100, 0, 300}},
+		{Name: "BBB", Values: []int64{200, 200, 0}},
+	}
+
+	c := newValueChart([]string{"A", "B", "C"}, series, false)
+	if c.Width != 70+2*60+160 || c.Height != 40+240+40 {
+		t.Errorf("unexpected chart size: %dx%d", c.Width, c.Height)
+	}
+
+	expectedX := []chartLabel{{X: 70, Y: 300, Text: "A"}, {X: 130, Y: 300, Text: "B"}, {X: 190, Y: 300, Text: "C"}}
+	if !reflect.DeepEqual(expectedX, c.XLabels) {
+		t.Errorf("expected x labels: %v, got: %v", expectedX, c.XLabels)
+	}
+	expectedY := []chartLabel{
+		{X: 60, Y: 280, Text: "100"},
+		{X: 60, Y: 220, Text: "150"},
+		{X: 60, Y: 160, Text: "200"},
+		{X: 60, Y: 100, Text: "250"},
+		{X: 60, Y: 40, Text: "300"},
+	}
+	if !reflect.DeepEqual(expectedY, c.YLabels) {
+		t.Errorf("expected y labels: %v, got: %v", expectedY, c.YLabels)
+	}
+
+	// The missing values are skipped
+	expectedLines := []chartLine{
+		{Color: chartColors[0], Points: "70,280 190,40", Label: chartLabel{X: 198, Y: 40, Text: "AAA"}},
+		{Color: chartColors[1], Points: "70,160 130,160", Label: chartLabel{X: 138, Y: 160, Text: "BBB"}},
+	}
+	if !reflect.DeepEqual(expectedLines, c.Lines) {
+		t.Errorf("expected lines: %v, got: %v", expectedLines, c.Lines)
+	}
+
+	// Inverted charts have the smallest value at the top, and small ranges don't repeat labels
+	c = newValueChart([]string{"A", "B"}, []chartSeries{{Name: "Rank", Values: []int64{3, 1}}}, true)
+	expectedY = []chartLabel{{X: 60, Y: 40, Text: "1"}, {X: 60, Y: 160, Text: "2"}, {X: 60, Y: 280, Text: "3"}}
+	if !reflect.DeepEqual(expectedY, c.YLabels) {
+		t.Errorf("expected y labels: %v, got: %v", expectedY, c.YLabels)
+	}
+	if c.Lines[0].Points != "70,280 130,40" {
+		t.Errorf("unexpected points: %s", c.Lines[0].Points)
+	}
+
+	// Without any values there is nothing to draw
+	c = newValueChart(nil, nil, false)
+	if len(c.XLabels) != 0 || len(c.YLabels) != 0 || len(c.Lines) != 0 {
+		t.Errorf("expected an empty chart, got: %v", c)
+	}
+}
+
+func TestNewPlayerRankingChart(t *testing.T) {
+	day1 := time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, time.September, 8, 0, 0, 0, 0, time.UTC)
+	points := []model.PlayerRankingPoint{
+		{RankingID: 1, Date: day1, Source: model.RankingSourceFantasyPros, Scoring: model.SCORING_HALF, Rank: 10},
+		{RankingID: 2, Date: day1, Source: model.RankingSourceCSV, Rank: 12},
+		{RankingID: 3, Date: day2, Source: model.RankingSourceFantasyPros, Scoring: model.SCORING_HALF, Rank: 5},
+	}
+
+	c := newPlayerRankingChart(points)
+	if len(c.XLabels) != 2 || c.XLabels[0].Text != "Sep 1" || c.XLabels[1].Text != "Sep 8" {
+		t.Errorf("unexpected x labels: %v", c.XLabels)
+	}
+
+	// A line for each source and scoring format, the best rank at the top
+	if len(c.Lines) != 2 {
+		t.Fatalf("expected 2 lines, got: %v", c.Lines)
+	}
+	if c.Lines[0].Label.Text != "FantasyPros CSV Half PPR" || c.Lines[0].Points != "70,211 130,40" {
+		t.Errorf("unexpected first line: %v", c.Lines[0])
+	}
+	if c.Lines[1].Label.Text != "CSV" || c.Lines[1].Points != "70,280" {
+		t.Errorf("unexpected second line: %v", c.Lines[1])
+	}
+}
+
+func TestNewRosterValueChart(t *testing.T) {
+	h := &model.PowerRankingHistory{
+		Weeks:           []int{1, 2},
+		PowerRankingIDs: []int32{4, 5},
+		Teams: []model.TeamRankHistory{
+			{TeamID: "1", TeamName: "AAA", Ranks: []int{1, 2}, RosterValues: []int32{5000, 4000}},
+			{TeamID: "2", TeamName: "BBB", Ranks: []int{2, 1}, RosterValues: []int32{3000, 6000}},
+		},
+	}
+
+	c := newRosterValueChart(h)
+	expectedX := []chartLabel{{X: 70, Y: 300, Text: "Week 1"}, {X: 130, Y: 300, Text: "Week 2"}}
+	if !reflect.DeepEqual(expectedX, c.XLabels) {
+		t.Errorf("expected x labels: %v, got: %v", expectedX, c.XLabels)
+	}
+	expectedLines := []chartLine{
+		{Color: chartColors[0], Points: "70,120 130,200", Label: chartLabel{X: 138, Y: 200, Text: "AAA"}},
+		{Color: chartColors[1], Points: "70,280 130,40", Label: chartLabel{X: 138, Y: 40, Text: "BBB"}},
+	}
+	if !reflect.DeepEqual(expectedLines, c.Lines) {
+		t.Errorf("expected lines: %v, got: %v", expectedLines, c.Lines)
+	}
+}
@@ -64,16 +64,7 @@ func getPlayerHandler(ctrl controller.C, render *render.Render) http.HandlerFunc
 			return
 		}
 
-		scores, err := ctrl.GetPlayerScores(r.Context(), playerID)
-		if err != nil {
-			log.Printf("error getting player scores: %v", err)
-		}
-
-		data := map[string]any{
-			"player": p,
-			"scores": scores,
-		}
-		render.HTML(w, http.StatusOK, "player", data)
+		render.HTML(w, http.StatusOK, "player", getPlayerPageData(r, ctrl, p))
 	}
 }
 
@@ -106,16 +97,29 @@ func updatePlayerHandler(ctrl controller.C, render *render.Render) http.HandlerF
 			return
 		}
 
-		scores, err := ctrl.GetPlayerScores(r.Context(), playerID)
-		if err != nil {
-			log.Printf("error getting player scores: %v", err)
-		}
+		render.HTML(w, http.StatusOK, "player", getPlayerPageData(r, ctrl, p))
+	}
+}
 
-		data := map[string]any{
-			"player": p,
-			"scores": scores,
-		}
-		render.HTML(w, http.StatusOK, "player", data)
+// Get everything shown on the player page. Errors getting the scores and rankings are
+// logged, the page is still shown without them.
+func getPlayerPageData(r *http.Request, ctrl controller.C, p *model.Player) map[string]any {
+	scores, err := ctrl.GetPlayerScores(r.Context(), p.ID)
+	if err != nil {
+		log.Printf("error getting player scores: %v", err)
+	}
+
+	rankings, err := ctrl.GetPlayerRankings(r.Context(), p.ID)
+	if err != nil {
+		log.Printf("error getting player rankings: %v", err)
+	}
+
+	return map[string]any{
+		"player":       p,
+		"scores":       scores,
+		"rankings":     rankings,
+		"rankingChart": newPlayerRankingChart(rankings),
+		"sourceNames":  rankingSourceNames,
 	}
 }
 
@@ -126,7 +130,14 @@ func rankingsRootHandler(ctrl controller.C, render *render.Render) http.HandlerF
 			render.HTML(w, http.StatusInternalServerError, "500", err)
 			return
 		}
-		render.HTML(w, http.StatusOK, "rankingsUploadPage", rankings)
+		data := map[string]any{
+			"rankings":       rankings,
+			"sources":        model.RankingSources,
+			"sourceNames":    rankingSourceNames,
+			"scoringFormats": model.ScoringFormats,
+			"positions":      rankingPositions,
+		}
+		render.HTML(w, http.StatusOK, "rankingsUploadPage", data)
 	}
 }
 
@@ -153,13 +164,163 @@ func rankingsHandler(ctrl controller.C, render *render.Render) http.HandlerFunc
 		})
 
 		data := map[string]any{
-			"date":    ranking.Date,
-			"players": players,
+			"date":        ranking.Date,
+			"source":      ranking.Source,
+			"sourceNames": rankingSourceNames,
+			"id":          ranking.ID,
+			"scoring":     ranking.Scoring,
+			"unmatched":   ranking.Unmatched,
+			"players":     players,
+		}
+		// Consensus rankings show how each of their sources ranked the players
+		if ranking.Source == model.RankingSourceConsensus {
+			consensus, err := ctrl.GetConsensusRanking(r.Context(), ranking.ID)
+			if err != nil {
+				render.HTML(w, http.StatusInternalServerError, "500", err.Error())
+				return
+			}
+			data["consensus"] = consensus
 		}
 		render.HTML(w, http.StatusOK, "rankings", data)
 	}
 }
 
+func compareRankingsHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		fromID, err := strconv.Atoi(r.FormValue("from"))
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse from ranking id: %v", err))
+			return
+		}
+		toID, err := strconv.Atoi(r.FormValue("to"))
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse to ranking id: %v", err))
+			return
+		}
+		pos := model.ParsePosition(r.FormValue("pos"))
+
+		comparison, err := ctrl.CompareRankings(r.Context(), int32(fromID), int32(toID), pos)
+		if err != nil {
+			render.HTML(w, http.StatusNotFound, "404", fmt.Sprintf("ranking not found: %v", err))
+			return
+		}
+
+		rankings, err := ctrl.ListRankings(r.Context())
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		data := map[string]any{
+			"comparison":  comparison,
+			"rankings":    rankings,
+			"positions":   rankingPositions,
+			"sourceNames": rankingSourceNames,
+		}
+		render.HTML(w, http.StatusOK, "rankingComparison", data)
+	}
+}
+
+func consensusRankingPageHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rankings, err := ctrl.ListRankings(r.Context())
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+		data := map[string]any{
+			"rankings":       rankings,
+			"sourceNames":    rankingSourceNames,
+			"scoringFormats": model.ScoringFormats,
+		}
+		render.HTML(w, http.StatusOK, "rankingConsensus", data)
+	}
+}
+
+func createConsensusRankingHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err.Error())
+			return
+		}
+
+		d := r.PostForm.Get("rankings-date")
+		t, err := time.Parse(time.DateOnly, d)
+		if err != nil {
+			msg := fmt.Sprintf("Unable to parse rankings date. Expected format is YYYY-MM-DD: %v", err)
+			render.HTML(w, http.StatusBadRequest, "400", msg)
+			return
+		}
+		opts := model.ConsensusOptions{
+			Date:    t,
+			Scoring: model.ParseScoringFormat(r.PostForm.Get("rankings-scoring")),
+		}
+
+		// Each picked ranking has its own weight and missing rank fields
+		for _, v := range r.PostForm["ranking"] {
+			id, err := strconv.Atoi(v)
+			if err != nil {
+				render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse ranking id: %v", err))
+				return
+			}
+			s := model.ConsensusSource{RankingID: int32(id), Weight: 1}
+			if weight := strings.TrimSpace(r.PostForm.Get("weight-" + v)); weight != "" {
+				if s.Weight, err = strconv.ParseFloat(weight, 64); err != nil {
+					render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse weight of ranking %d: %v", id, err))
+					return
+				}
+			}
+			if m := strings.TrimSpace(r.PostForm.Get("missing-" + v)); m != "" {
+				missing, err := strconv.Atoi(m)
+				if err != nil {
+					render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse missing rank of ranking %d: %v", id, err))
+					return
+				}
+				s.MissingRank = int32(missing)
+			}
+			opts.Sources = append(opts.Sources, s)
+		}
+
+		id, err := ctrl.AddConsensusRanking(r.Context(), opts)
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err.Error())
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/players/rankings/%d", id), http.StatusSeeOther)
+	}
+}
+
+// The content types of the rankings files that can be uploaded. Browsers on Windows
+// often send CSV files as application/vnd.ms-excel.
+var rankingContentTypes = []string{
+	"text/csv",
+	"application/vnd.ms-excel",
+	"text/plain",
+	"application/json",
+	"application/xml",
+	"text/xml",
+}
+
+// The names of the ranking sources shown on the rankings pages.
+var rankingSourceNames = map[string]string{
+	model.RankingSourceFantasyPros: "FantasyPros CSV",
+	model.RankingSourceCSV:         "CSV",
+	model.RankingSourceJSON:        "JSON",
+	model.RankingSourceSleeper:     "Sleeper ADP",
+	model.RankingSourceYahoo:       "Yahoo",
+	model.RankingSourceConsensus:   "Consensus",
+}
+
+// The positions rankings can be compared by.
+var rankingPositions = []model.Position{
+	model.POS_QB,
+	model.POS_RB,
+	model.POS_WR,
+	model.POS_TE,
+	model.POS_K,
+	model.POS_DEF,
+}
+
 func rankingsUploadHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Parse the multipart form. 5 << 20 specifices a maximum upload of 5 MB files.
@@ -172,12 +333,27 @@ func rankingsUploadHandler(ctrl controller.C, render *render.Render) http.Handle
 		}
 		defer file.Close()
 
-		if handler.Header.Get("Content-Type") != "text/csv" {
-			msg := fmt.Sprintf("Only CSV files are supported. Got %s", handler.Header.Get("Content-Type"))
+		if !slices.Contains(rankingContentTypes, handler.Header.Get("Content-Type")) {
+			msg := fmt.Sprintf("Only CSV, JSON and XML files are supported. Got %s", handler.Header.Get("Content-Type"))
 			render.HTML(w, http.StatusBadRequest, "400", msg)
 			return
 		}
 
+		opts := model.RankingImportOptions{
+			Source:  r.FormValue("rankings-source"),
+			Scoring: model.ParseScoringFormat(r.FormValue("rankings-scoring")),
+			Columns: model.RankingColumns{
+				Rank:     strings.TrimSpace(r.FormValue("column-rank")),
+				Name:     strings.TrimSpace(r.FormValue("column-name")),
+				Team:     strings.TrimSpace(r.FormValue("column-team")),
+				Position: strings.TrimSpace(r.FormValue("column-position")),
+			},
+		}
+		if opts.Source != "" && !slices.Contains(model.RankingSources, opts.Source) {
+			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("Unknown rankings source: %s", opts.Source))
+			return
+		}
+
 		d := r.FormValue("rankings-date")
 		t, err := time.Parse(time.DateOnly, d)
 		if err != nil {
@@ -186,7 +362,7 @@ func rankingsUploadHandler(ctrl controller.C, render *render.Render) http.Handle
 			return
 		}
 
-		id, err := ctrl.AddRanking(r.Context(), file, t)
+		id, err := ctrl.AddRanking(r.Context(), file, t, opts)
 		if err != nil {
 			render.HTML(w, http.StatusInternalServerError, "500", err.Error())
 			return
@@ -195,6 +371,87 @@ func rankingsUploadHandler(ctrl controller.C, render *render.Render) http.Handle
 	}
 }
 
+func rankingReviewHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rankingID, err := getID(r, "rankingID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		ranking, err := ctrl.GetRanking(r.Context(), rankingID)
+		if err != nil {
+			render.HTML(w, http.StatusNotFound, "404", fmt.Sprintf("ranking not found: %v", err))
+			return
+		}
+
+		unmatched, err := ctrl.ListUnmatchedRankingPlayers(r.Context(), rankingID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		data := map[string]any{
+			"ranking":   ranking,
+			"unmatched": unmatched,
+		}
+		render.HTML(w, http.StatusOK, "rankingReview", data)
+	}
+}
+
+func resolveUnmatchedPlayerHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rankingID, err := getID(r, "rankingID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+		unmatchedID, err := getID(r, "unmatchedID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		// A player id typed in is used over one of the candidates
+		playerID := strings.TrimSpace(r.FormValue("otherPlayerID"))
+		if playerID == "" {
+			playerID = r.FormValue("playerID")
+		}
+		if playerID == "" {
+			render.HTML(w, http.StatusBadRequest, "400", "A player must be picked")
+			return
+		}
+		saveAlias := r.FormValue("alias") == "on"
+
+		if err := ctrl.ResolveUnmatchedRankingPlayer(r.Context(), rankingID, unmatchedID, playerID, saveAlias); err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/players/rankings/%d/review", rankingID), http.StatusSeeOther)
+	}
+}
+
+func dismissUnmatchedPlayerHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rankingID, err := getID(r, "rankingID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+		unmatchedID, err := getID(r, "unmatchedID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		if err := ctrl.DismissUnmatchedRankingPlayer(r.Context(), rankingID, unmatchedID); err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/players/rankings/%d/review", rankingID), http.StatusSeeOther)
+	}
+}
+
 func forceUpdatePlayers(ctrl controller.C, render *render.Render) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := ctrl.UpdatePlayers(r.Context()); err != nil {
@@ -250,11 +507,48 @@ func getLeagueHandler(ctrl controller.C, render *render.Render) http.HandlerFunc
 			return
 		}
 
+		drafts, err := ctrl.ListDrafts(r.Context(), leagueID)
+		if err != nil {
+			log.Printf("error listing drafts for league %d: %v", leagueID, err)
+			drafts = make([]model.Draft, 0)
+		}
+
+		powerRankingConfig, err := ctrl.GetPowerRankingConfig(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		franchise, err := ctrl.GetLeagueFranchise(r.Context(), leagueID)
+		if err != nil {
+			log.Printf("error getting franchise for league %d: %v", leagueID, err)
+		}
+
+		// List the franchises so the league can be linked to one
+		franchises, err := ctrl.ListFranchises(r.Context())
+		if err != nil {
+			log.Printf("error listing franchises: %v", err)
+			franchises = make([]model.Franchise, 0)
+		}
+
+		allPlay, err := ctrl.GetAllPlayStandings(r.Context(), leagueID)
+		if err != nil {
+			log.Printf("error getting all-play standings for league %d: %v", leagueID, err)
+			allPlay = make([]model.AllPlayStanding, 0)
+		}
+
 		data := map[string]any{
 			"league":        l,
 			"results":       resultWeeks,
 			"powerRankings": powerRankings,
+			"powerConfig":   powerRankingConfig,
+			"tieBreaks":     model.DefaultTieBreaks(),
+			"tieBreakNames": tieBreakNames,
 			"rankings":      rankings,
+			"drafts":        drafts,
+			"franchise":     franchise,
+			"franchises":    franchises,
+			"allPlay":       allPlay,
 		}
 		render.HTML(w, http.StatusOK, "league", data)
 	}
@@ -345,7 +639,7 @@ func getLeagueResultsHandler(ctrl controller.C, render *render.Render) http.Hand
 	}
 }
 
-func getLeagueResultsTemplateHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+func getLeagueTransactionsHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		leagueID, err := getID(r, "leagueID")
 		if err != nil {
@@ -353,83 +647,331 @@ func getLeagueResultsTemplateHandler(ctrl controller.C, render *render.Render) h
 			return
 		}
 
-		week, err := strconv.Atoi(chi.URLParam(r, "week"))
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
 		if err != nil {
-			msg := fmt.Sprintf("error reading week value: %v", err)
-			render.HTML(w, http.StatusBadRequest, "400", msg)
+			render.HTML(w, http.StatusNotFound, "404", err.Error())
 			return
 		}
 
-		league, err := ctrl.GetLeague(r.Context(), leagueID)
+		transactions, err := ctrl.ListLeagueTransactions(r.Context(), leagueID)
 		if err != nil {
 			render.HTML(w, http.StatusInternalServerError, "500", err)
 			return
 		}
 
-		matchups, err := ctrl.GetLeagueResults(r.Context(), leagueID, week)
+		data := map[string]any{
+			"league":       league,
+			"transactions": transactions,
+		}
+		render.HTML(w, http.StatusOK, "leagueTransactions", data)
+	}
+}
+
+func getStrengthOfScheduleHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
 		if err != nil {
-			render.HTML(w, http.StatusInternalServerError, "500", err)
+			render.HTML(w, http.StatusBadRequest, "400", err)
 			return
 		}
 
-		topScores, err := ctrl.GetTopScores(r.Context(), leagueID, week)
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
 		if err != nil {
-			render.HTML(w, http.StatusInternalServerError, "500", err)
+			render.HTML(w, http.StatusNotFound, "404", err.Error())
 			return
 		}
 
-		prList, err := ctrl.ListPowerRankings(r.Context(), leagueID)
+		schedule, err := ctrl.GetStrengthOfSchedule(r.Context(), leagueID)
 		if err != nil {
-			render.HTML(w, http.StatusInternalServerError, "500", fmt.Errorf("error listing power rankings: %v", err))
+			render.HTML(w, http.StatusInternalServerError, "500", err)
 			return
 		}
-		var powerRanking *model.PowerRanking
-		if int(prList[0].Week) == week {
-			powerRanking, err = ctrl.GetPowerRanking(r.Context(), leagueID, prList[0].ID)
-			if err != nil {
-				render.HTML(w, http.StatusInternalServerError, "500", fmt.Errorf("error fetching power ranking: %v", err))
-				return
-			}
+
+		data := map[string]any{
+			"league":   league,
+			"schedule": schedule,
 		}
+		render.HTML(w, http.StatusOK, "leagueSchedule", data)
+	}
+}
 
-		var standings []model.LeagueStanding
-		standings, err = ctrl.GetLeagueStandings(r.Context(), leagueID)
+func simulateSeasonHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
 		if err != nil {
-			log.Printf("error getting league standings, non-fatal: %v", err)
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
 		}
 
-		var res strings.Builder
-		res.WriteString("---\n")
-		res.WriteString(fmt.Sprintf("title: \"%s\"\n", league.Name))
-		res.WriteString(fmt.Sprintf("date: %s\n", time.Now().Format(time.DateOnly)))
-		res.WriteString("image: cover.jpeg\n")
-		res.WriteString(fmt.Sprintf("description: \"Week %d, %s\"\n", week, time.Now().Format("2006")))
-		res.WriteString("layout: \"season\"\n")
-		res.WriteString(fmt.Sprintf("url: \"/ff/seasons/%s/week-%02d\"\n", time.Now().Format("2006"), week))
-		res.WriteString("---\n\n")
-
-		res.WriteString("TODO - short statement about the week\n\n")
-
-		res.WriteString("# Results\n\n")
-		res.WriteString("League Median: TODO\n")
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusNotFound, "404", err.Error())
+			return
+		}
 
-		res.WriteString("{{< table-with-class \"results-table\" >}}\n")
-		res.WriteString("| Team | Score |\n")
-		res.WriteString("| ---- | ----- |\n")
-		for _, m := range matchups {
-			var w, l *model.TeamResult
-			if m.TeamA.Score > m.TeamB.Score {
-				w = m.TeamA
-				l = m.TeamB
-			} else {
-				w = m.TeamB
-				l = m.TeamA
+		// Any values that aren't set use the defaults, without a seed a new one is picked.
+		cfg := model.DefaultSimulationConfig()
+		fields := map[string]*int{
+			"simulations":  &cfg.Simulations,
+			"playoffTeams": &cfg.PlayoffTeams,
+			"byes":         &cfg.Byes,
+		}
+		for name, v := range fields {
+			if r.FormValue(name) == "" {
+				continue
+			}
+			if *v, err = strconv.Atoi(r.FormValue(name)); err != nil {
+				render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse %s: %v", name, err))
+				return
+			}
+		}
+		if seed := r.FormValue("seed"); seed != "" {
+			if cfg.Seed, err = strconv.ParseUint(seed, 10, 64); err != nil {
+				render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse seed: %v", err))
+				return
 			}
-			res.WriteString(fmt.Sprintf("| **%s** | **%s** |\n", w.TeamName, formatScore(w.Score)))
-			res.WriteString(fmt.Sprintf("| %s | %s |\n", l.TeamName, formatScore(l.Score)))
-			res.WriteString("| | |\n")
 		}
-		res.WriteString("{{< /table-with-class >}}\n\n")
+
+		sim, err := ctrl.SimulateSeason(r.Context(), leagueID, cfg)
+		if errors.Is(err, controller.ErrSimulationNotSupported) {
+			data := map[string]any{
+				"league":      league,
+				"unsupported": true,
+			}
+			render.HTML(w, http.StatusOK, "leagueSimulation", data)
+			return
+		} else if errors.Is(err, controller.ErrInvalidSimulationConfig) {
+			render.HTML(w, http.StatusBadRequest, "400", err.Error())
+			return
+		} else if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		seeds := make([]int, len(sim.Teams))
+		for i := range seeds {
+			seeds[i] = i + 1
+		}
+
+		data := map[string]any{
+			"league":     league,
+			"simulation": sim,
+			"seeds":      seeds,
+		}
+		render.HTML(w, http.StatusOK, "leagueSimulation", data)
+	}
+}
+
+func syncDraftsHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		if err := ctrl.SyncDraftsFromPlatform(r.Context(), leagueID); err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+	}
+}
+
+func getDraftGradesHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		draftID, err := getID(r, "draftID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusNotFound, "404", err.Error())
+			return
+		}
+
+		draft, grades, err := ctrl.GetDraftGrades(r.Context(), leagueID, draftID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		data := map[string]any{
+			"league": league,
+			"draft":  draft,
+			"grades": grades,
+		}
+		render.HTML(w, http.StatusOK, "draftGrades", data)
+	}
+}
+
+func linkFranchiseHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+		// 0 is used to create a new franchise
+		franchiseID, err := strconv.Atoi(r.FormValue("franchise"))
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		id, err := ctrl.LinkLeagueToFranchise(r.Context(), leagueID, int32(franchiseID))
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/franchises/%d", id), http.StatusSeeOther)
+	}
+}
+
+func discoverFranchiseHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		id, err := ctrl.DiscoverFranchise(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/franchises/%d", id), http.StatusSeeOther)
+	}
+}
+
+func getFranchiseHistoryHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		franchiseID, err := getID(r, "franchiseID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		history, err := ctrl.GetFranchiseHistory(r.Context(), franchiseID)
+		if err != nil {
+			render.HTML(w, http.StatusNotFound, "404", err.Error())
+			return
+		}
+
+		data := map[string]any{
+			"history": history,
+		}
+		render.HTML(w, http.StatusOK, "franchiseHistory", data)
+	}
+}
+
+func getLeagueResultsTemplateHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		week, err := strconv.Atoi(chi.URLParam(r, "week"))
+		if err != nil {
+			msg := fmt.Sprintf("error reading week value: %v", err)
+			render.HTML(w, http.StatusBadRequest, "400", msg)
+			return
+		}
+
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		matchups, err := ctrl.GetLeagueResults(r.Context(), leagueID, week)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		topScores, err := ctrl.GetTopScores(r.Context(), leagueID, week)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		prList, err := ctrl.ListPowerRankings(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", fmt.Errorf("error listing power rankings: %v", err))
+			return
+		}
+		var powerRanking *model.PowerRanking
+		if int(prList[0].Week) == week {
+			powerRanking, err = ctrl.GetPowerRanking(r.Context(), leagueID, prList[0].ID)
+			if err != nil {
+				render.HTML(w, http.StatusInternalServerError, "500", fmt.Errorf("error fetching power ranking: %v", err))
+				return
+			}
+		}
+
+		var standings []model.LeagueStanding
+		standings, err = ctrl.GetLeagueStandings(r.Context(), leagueID)
+		if err != nil {
+			log.Printf("error getting league standings, non-fatal: %v", err)
+		}
+
+		transactions, err := ctrl.GetLeagueTransactions(r.Context(), leagueID, week)
+		if err != nil {
+			log.Printf("error getting league transactions, non-fatal: %v", err)
+		}
+
+		var res strings.Builder
+		res.WriteString("---\n")
+		res.WriteString(fmt.Sprintf("title: \"%s\"\n", league.Name))
+		res.WriteString(fmt.Sprintf("date: %s\n", time.Now().Format(time.DateOnly)))
+		res.WriteString("image: cover.jpeg\n")
+		res.WriteString(fmt.Sprintf("description: \"Week %d, %s\"\n", week, time.Now().Format("2006")))
+		res.WriteString("layout: \"season\"\n")
+		res.WriteString(fmt.Sprintf("url: \"/ff/seasons/%s/week-%02d\"\n", time.Now().Format("2006"), week))
+		res.WriteString("---\n\n")
+
+		res.WriteString("TODO - short statement about the week\n\n")
+
+		res.WriteString("# Results\n\n")
+		res.WriteString("League Median: TODO\n")
+
+		res.WriteString("{{< table-with-class \"results-table\" >}}\n")
+		res.WriteString("| Team | Score |\n")
+		res.WriteString("| ---- | ----- |\n")
+		for _, m := range matchups {
+			var w, l *model.TeamResult
+			if m.TeamA.Score > m.TeamB.Score {
+				w = m.TeamA
+				l = m.TeamB
+			} else {
+				w = m.TeamB
+				l = m.TeamA
+			}
+			res.WriteString(fmt.Sprintf("| **%s** | **%s** |\n", w.TeamName, formatScore(w.Score)))
+			res.WriteString(fmt.Sprintf("| %s | %s |\n", l.TeamName, formatScore(l.Score)))
+			res.WriteString("| | |\n")
+		}
+		res.WriteString("{{< /table-with-class >}}\n\n")
 
 		res.WriteString("# Fantasy Heros\n")
 		res.WriteString("TODO - short commentary\n\n")
@@ -445,7 +987,12 @@ func getLeagueResultsTemplateHandler(ctrl controller.C, render *render.Render) h
 
 		res.WriteString("\n")
 		res.WriteString("# Transaction action\n")
-		res.WriteString("TODO - write about and trades or waiver wire moves\n")
+		if len(transactions) == 0 {
+			res.WriteString("No trades or waiver wire moves this week\n")
+		}
+		for _, t := range transactions {
+			res.WriteString(fmt.Sprintf("- %s\n", t.Summary()))
+		}
 
 		if powerRanking != nil {
 			res.WriteString("\n")
@@ -508,29 +1055,262 @@ func createPowerRankingsHandler(ctrl controller.C, render *render.Render) http.H
 			return
 		}
 
-		rankingID, err := strconv.Atoi(r.FormValue("ranking"))
+		form, err := getPowerRankingForm(r)
 		if err != nil {
-			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse ranking id: %v", err))
+			render.HTML(w, http.StatusBadRequest, "400", err)
 			return
 		}
 
-		week, err := strconv.Atoi(r.FormValue("week"))
+		id, err := ctrl.CalculatePowerRanking(r.Context(), leagueID, form.rankingID, form.week, form.compareID)
 		if err != nil {
-			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse week value: %v", err))
+			render.HTML(w, http.StatusInternalServerError, "500", err)
 			return
 		}
-		if week < 0 || week > 17 {
-			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("week must be between 0 and 17, got: %d", week))
+
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/power/%d", leagueID, id), http.StatusSeeOther)
+	}
+}
+
+func previewPowerRankingHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
 			return
 		}
 
-		id, err := ctrl.CalculatePowerRanking(r.Context(), leagueID, int32(rankingID), week)
+		form, err := getPowerRankingForm(r)
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
 		if err != nil {
 			render.HTML(w, http.StatusInternalServerError, "500", err)
 			return
 		}
 
-		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/power/%d", leagueID, id), http.StatusSeeOther)
+		pr, err := ctrl.PreviewPowerRanking(r.Context(), leagueID, form.rankingID, form.week, form.compareID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		elo, eloRatings := getEloRatings(r, ctrl, leagueID, form.week)
+		data := map[string]any{
+			"league":     league,
+			"power":      pr,
+			"preview":    true,
+			"elo":        elo,
+			"eloRatings": eloRatings,
+		}
+		render.HTML(w, http.StatusOK, "powerRanking", data)
+	}
+}
+
+func getPowerRankingHistoryHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		history, err := ctrl.GetPowerRankingHistory(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		data := map[string]any{
+			"league":      league,
+			"history":     history,
+			"chart":       newRankChart(history),
+			"rosterChart": newRosterValueChart(history),
+		}
+		render.HTML(w, http.StatusOK, "powerRankingHistory", data)
+	}
+}
+
+func explainPowerRankingHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		powerRankingID, err := getID(r, "powerRankingID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		league, err := ctrl.GetLeague(r.Context(), leagueID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		explanation, err := ctrl.GetPowerRankingExplanation(r.Context(), leagueID, powerRankingID)
+		if err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		data := map[string]any{
+			"league":      league,
+			"explanation": explanation,
+		}
+		render.HTML(w, http.StatusOK, "powerRankingExplanation", data)
+	}
+}
+
+func recomputePowerRankingHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		powerRankingID, err := getID(r, "powerRankingID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse form: %v", err))
+			return
+		}
+
+		form, err := getPowerRankingForm(r)
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		if err := ctrl.RecomputePowerRanking(r.Context(), leagueID, powerRankingID, form.rankingID, form.week, form.compareID); err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/power/%d", leagueID, powerRankingID), http.StatusSeeOther)
+	}
+}
+
+func deletePowerRankingHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		powerRankingID, err := getID(r, "powerRankingID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		if err := ctrl.DeletePowerRanking(r.Context(), leagueID, powerRankingID); err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+	}
+}
+
+// The values from the forms used to calculate a power ranking.
+type powerRankingForm struct {
+	rankingID int32
+	week      int
+	compareID int32 // 0 to compare to the most recent power ranking for an earlier week
+}
+
+func getPowerRankingForm(r *http.Request) (*powerRankingForm, error) {
+	rankingID, err := strconv.Atoi(r.FormValue("ranking"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ranking id: %v", err)
+	}
+
+	week, err := strconv.Atoi(r.FormValue("week"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse week value: %v", err)
+	}
+	if week < 0 || week > 17 {
+		return nil, fmt.Errorf("week must be between 0 and 17, got: %d", week)
+	}
+
+	compareID := 0
+	if v := r.FormValue("compare"); v != "" {
+		if compareID, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("unable to parse compare id: %v", err)
+		}
+	}
+	return &powerRankingForm{rankingID: int32(rankingID), week: week, compareID: int32(compareID)}, nil
+}
+
+// The names of the tie-breaks shown when picking their order.
+var tieBreakNames = map[string]string{
+	model.TieBreakPointsFor:  "Points for",
+	model.TieBreakRecord:     "Record",
+	model.TieBreakHeadToHead: "Head-to-head record",
+	model.TieBreakRoster:     "Roster score",
+}
+
+func updatePowerRankingConfigHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leagueID, err := getID(r, "leagueID")
+		if err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", err)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse form: %v", err))
+			return
+		}
+
+		var cfg model.PowerRankingConfig
+		fields := map[string]*int{
+			"benchPercent":         &cfg.BenchPercent,
+			"pointsAgainstPercent": &cfg.PointsAgainstPercent,
+			"winPoints":            &cfg.WinPoints,
+			"streakPoints":         &cfg.StreakPoints,
+			"pointsWeeks":          &cfg.PointsWeeks,
+			"allPlayPoints":        &cfg.AllPlayPoints,
+			"schedulePoints":       &cfg.SchedulePoints,
+		}
+		for name, v := range fields {
+			if *v, err = strconv.Atoi(r.FormValue(name)); err != nil {
+				render.HTML(w, http.StatusBadRequest, "400", fmt.Sprintf("unable to parse %s: %v", name, err))
+				return
+			}
+		}
+		// The tie-breaks are in order, and any left empty aren't used
+		cfg.TieBreaks = make([]string, 0, len(r.Form["tieBreak"]))
+		for _, tb := range r.Form["tieBreak"] {
+			if tb != "" {
+				cfg.TieBreaks = append(cfg.TieBreaks, tb)
+			}
+		}
+
+		if err := ctrl.UpdatePowerRankingConfig(r.Context(), leagueID, &cfg); err != nil {
+			render.HTML(w, http.StatusInternalServerError, "500", err)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 	}
 }
 
@@ -560,14 +1340,46 @@ func showPowerRankingHandler(ctrl controller.C, render *render.Render) http.Hand
 			return
 		}
 
+		rankings, err := ctrl.ListRankings(r.Context())
+		if err != nil {
+			log.Printf("error listing rankings: %v", err)
+			rankings = make([]model.Ranking, 0)
+		}
+
+		powerRankings, err := ctrl.ListPowerRankings(r.Context(), leagueID)
+		if err != nil {
+			log.Printf("error listing power rankings for league %d: %v", leagueID, err)
+			powerRankings = make([]model.PowerRanking, 0)
+		}
+
+		elo, eloRatings := getEloRatings(r, ctrl, leagueID, int(pr.Week))
 		data := map[string]any{
-			"league": league,
-			"power":  pr,
+			"league":        league,
+			"power":         pr,
+			"rankings":      rankings,
+			"powerRankings": powerRankings,
+			"elo":           elo,
+			"eloRatings":    eloRatings,
 		}
 		render.HTML(w, http.StatusOK, "powerRanking", data)
 	}
 }
 
+// Get the Elo history shown with a power ranking, and the current rating of each team.
+// The ratings are extra information for the page, so errors are only logged.
+func getEloRatings(r *http.Request, ctrl controller.C, leagueID int32, week int) (*model.EloHistory, map[string]float64) {
+	elo, err := ctrl.GetEloHistory(r.Context(), leagueID, week)
+	if err != nil {
+		log.Printf("error getting elo ratings for league %d: %v", leagueID, err)
+		elo = &model.EloHistory{}
+	}
+	eloRatings := make(map[string]float64)
+	for _, t := range elo.Teams {
+		eloRatings[t.TeamID] = t.Rating
+	}
+	return elo, eloRatings
+}
+
 func showPowerRankingsTextHandler(ctrl controller.C, render *render.Render) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		leagueID, err := getID(r, "leagueID")
@@ -591,12 +1403,13 @@ func showPowerRankingsTextHandler(ctrl controller.C, render *render.Render) http
 		var builder strings.Builder
 		for i := range pr.Teams {
 			var s string
+			rank := pr.DisplayRank(pr.Teams[i].Rank)
 			if pr.Teams[i].RankChange > 0 {
-				s = fmt.Sprintf("%d. %s (+%d)\n", pr.Teams[i].Rank, pr.Teams[i].TeamName, pr.Teams[i].RankChange)
+				s = fmt.Sprintf("%s. %s (+%d)\n", rank, pr.Teams[i].TeamName, pr.Teams[i].RankChange)
 			} else if pr.Teams[i].RankChange < 0 {
-				s = fmt.Sprintf("%d. %s (%d)\n", pr.Teams[i].Rank, pr.Teams[i].TeamName, pr.Teams[i].RankChange)
+				s = fmt.Sprintf("%s. %s (%d)\n", rank, pr.Teams[i].TeamName, pr.Teams[i].RankChange)
 			} else {
-				s = fmt.Sprintf("%d. %s\n", pr.Teams[i].Rank, pr.Teams[i].TeamName)
+				s = fmt.Sprintf("%s. %s\n", rank, pr.Teams[i].TeamName)
 			}
 			builder.WriteString(s)
 		}
@@ -616,7 +1429,7 @@ func platformLeaguesHandler(ctrl controller.C, render *render.Render) http.Handl
 			return
 		}
 
-		leagues, err := ctrl.GetLeaguesFromPlatform(r.Context(), username, platform, year)
+		leagues, err := ctrl.GetLeaguesFromPlatform(r.Context(), username, platform, year, "")
 		if err != nil {
 			render.HTML(w, http.StatusInternalServerError, "500", err)
 			return
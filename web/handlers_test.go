@@ -14,6 +14,9 @@ import (
 	"testing"
 
 	"github.com/mww/fantasy_manager_v2/controller"
+	"github.com/mww/fantasy_manager_v2/platforms/espn"
+	"github.com/mww/fantasy_manager_v2/platforms/fleaflicker"
+	"github.com/mww/fantasy_manager_v2/platforms/mfl"
 	"github.com/mww/fantasy_manager_v2/platforms/sleeper"
 	"github.com/mww/fantasy_manager_v2/platforms/yahoo"
 	"github.com/mww/fantasy_manager_v2/testutils"
@@ -51,8 +54,11 @@ func TestRankingsUploadHandler_success(t *testing.T) {
 	defer testCtrl.Close()
 	sleeperClient := sleeper.NewForTest(testCtrl.SleeperURL())
 	yahooClient := yahoo.NewForTest(testCtrl.YahooURL())
+	espnClient := espn.NewForTest(testCtrl.ESPNURL())
+	mflClient := mfl.NewForTest(testCtrl.MFLURL())
+	fleaflickerClient := fleaflicker.NewForTest(testCtrl.FleaflickerURL())
 
-	ctrl, err := controller.New(testCtrl.Clock, testDB.DB, sleeperClient, yahooClient, testCtrl.YahooConfig)
+	ctrl, err := controller.New(testCtrl.Clock, testDB.DB, sleeperClient, yahooClient, testCtrl.YahooConfig, espnClient, mflClient, fleaflickerClient)
 	if err != nil {
 		t.Fatalf("error creating controller: %v", err)
 	}
@@ -74,13 +80,16 @@ func TestRankingsUploadHandler_badFileContentType(t *testing.T) {
 	defer testCtrl.Close()
 	sleeperClient := sleeper.NewForTest(testCtrl.SleeperURL())
 	yahooClient := yahoo.NewForTest(testCtrl.YahooURL())
+	espnClient := espn.NewForTest(testCtrl.ESPNURL())
+	mflClient := mfl.NewForTest(testCtrl.MFLURL())
+	fleaflickerClient := fleaflicker.NewForTest(testCtrl.FleaflickerURL())
 
-	ctrl, err := controller.New(testCtrl.Clock, testDB.DB, sleeperClient, yahooClient, testCtrl.YahooConfig)
+	ctrl, err := controller.New(testCtrl.Clock, testDB.DB, sleeperClient, yahooClient, testCtrl.YahooConfig, espnClient, mflClient, fleaflickerClient)
 	if err != nil {
 		t.Fatalf("error creating controller: %v", err)
 	}
 
-	resp := runRankingsUploadHandlerTest(t, ctrl, "application/json", "2024-07-29")
+	resp := runRankingsUploadHandlerTest(t, ctrl, "image/png", "2024-07-29")
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusBadRequest {
@@ -92,7 +101,7 @@ func TestRankingsUploadHandler_badFileContentType(t *testing.T) {
 		t.Fatalf("error response body: %v", err)
 	}
 
-	if !strings.Contains(string(b), "Only CSV files are supported. Got application/json") {
+	if !strings.Contains(string(b), "Only CSV, JSON and XML files are supported. Got image/png") {
 		t.Errorf("response body does not contain expected string")
 	}
 }
@@ -102,8 +111,11 @@ func TestRankingsUploadHandler_badDate(t *testing.T) {
 	defer testCtrl.Close()
 	sleeperClient := sleeper.NewForTest(testCtrl.SleeperURL())
 	yahooClient := yahoo.NewForTest(testCtrl.YahooURL())
+	espnClient := espn.NewForTest(testCtrl.ESPNURL())
+	mflClient := mfl.NewForTest(testCtrl.MFLURL())
+	fleaflickerClient := fleaflicker.NewForTest(testCtrl.FleaflickerURL())
 
-	ctrl, err := controller.New(testCtrl.Clock, testDB.DB, sleeperClient, yahooClient, testCtrl.YahooConfig)
+	ctrl, err := controller.New(testCtrl.Clock, testDB.DB, sleeperClient, yahooClient, testCtrl.YahooConfig, espnClient, mflClient, fleaflickerClient)
 	if err != nil {
 		t.Fatalf("error creating controller: %v", err)
 	}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"log"
 	"net/http"
 	"time"
 
@@ -33,10 +34,25 @@ func oauthRedirectHandler(ctrl controller.C, render *render.Render) http.Handler
 			return
 		}
 
+		year := time.Now().Format("2006") // Just get the 4 digit year
+		leagues, err := ctrl.GetLeaguesFromPlatform(r.Context(), "", model.PlatformYahoo, year, state)
+		if err != nil || len(leagues) == 0 {
+			// Fall back to letting the user enter the league id by hand.
+			log.Printf("unable to list yahoo leagues, got %d leagues and err: %v", len(leagues), err)
+			data := map[string]any{
+				"state": state,
+				"year":  year,
+			}
+			render.HTML(w, http.StatusOK, "addYahooLeague", data)
+			return
+		}
+
 		data := map[string]any{
-			"state": state,
-			"year":  time.Now().Format("2006"),
+			"platform": model.PlatformYahoo,
+			"leagues":  leagues,
+			"year":     year,
+			"state":    state,
 		}
-		render.HTML(w, http.StatusOK, "addYahooLeague", data)
+		render.HTML(w, http.StatusOK, "leaguesPlatformLeagues", data)
 	}
 }
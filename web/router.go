@@ -35,7 +35,13 @@ func getRouter(ctrl controller.C, render *render.Render) *chi.Mux {
 		r.Route("/rankings", func(r chi.Router) {
 			r.Get("/", rankingsRootHandler(ctrl, render))
 			r.Post("/", rankingsUploadHandler(ctrl, render))
+			r.Get("/compare", compareRankingsHandler(ctrl, render))
+			r.Get("/consensus", consensusRankingPageHandler(ctrl, render))
+			r.Post("/consensus", createConsensusRankingHandler(ctrl, render))
 			r.Get("/{rankingID:\\d+}", rankingsHandler(ctrl, render))
+			r.Get("/{rankingID:\\d+}/review", rankingReviewHandler(ctrl, render))
+			r.Post("/{rankingID:\\d+}/review/{unmatchedID:\\d+}", resolveUnmatchedPlayerHandler(ctrl, render))
+			r.Post("/{rankingID:\\d+}/review/{unmatchedID:\\d+}/dismiss", dismissUnmatchedPlayerHandler(ctrl, render))
 		})
 	})
 
@@ -45,14 +51,31 @@ func getRouter(ctrl controller.C, render *render.Render) *chi.Mux {
 		r.Post("/{leagueID:\\d+}/results/sync", syncWeekResultsHandler(ctrl, render))
 		r.Get("/{leagueID:\\d+}/week/{week:\\d+}", getLeagueResultsHandler(ctrl, render))
 		r.Get("/{leagueID:\\d+}/week/{week:\\d+}/template", getLeagueResultsTemplateHandler(ctrl, render))
+		r.Get("/{leagueID:\\d+}/transactions", getLeagueTransactionsHandler(ctrl, render))
+		r.Get("/{leagueID:\\d+}/schedule", getStrengthOfScheduleHandler(ctrl, render))
+		r.Get("/{leagueID:\\d+}/simulate", simulateSeasonHandler(ctrl, render))
+		r.Post("/{leagueID:\\d+}/drafts/sync", syncDraftsHandler(ctrl, render))
+		r.Get("/{leagueID:\\d+}/drafts/{draftID:\\d+}", getDraftGradesHandler(ctrl, render))
+		r.Post("/{leagueID:\\d+}/franchise", linkFranchiseHandler(ctrl, render))
+		r.Post("/{leagueID:\\d+}/franchise/discover", discoverFranchiseHandler(ctrl, render))
 		r.Post("/{leagueID:\\d+}/power", createPowerRankingsHandler(ctrl, render))
+		r.Post("/{leagueID:\\d+}/power/config", updatePowerRankingConfigHandler(ctrl, render))
+		r.Get("/{leagueID:\\d+}/power/preview", previewPowerRankingHandler(ctrl, render))
+		r.Get("/{leagueID:\\d+}/power/history", getPowerRankingHistoryHandler(ctrl, render))
 		r.Get("/{leagueID:\\d+}/power/{powerRankingID:\\d+}", showPowerRankingHandler(ctrl, render))
+		r.Post("/{leagueID:\\d+}/power/{powerRankingID:\\d+}/recompute", recomputePowerRankingHandler(ctrl, render))
+		r.Post("/{leagueID:\\d+}/power/{powerRankingID:\\d+}/delete", deletePowerRankingHandler(ctrl, render))
+		r.Get("/{leagueID:\\d+}/power/{powerRankingID:\\d+}/explain", explainPowerRankingHandler(ctrl, render))
 		r.Get("/{leagueID:\\d+}/power/{powerRankingID:\\d+}/text", showPowerRankingsTextHandler(ctrl, render))
 		r.Get("/platformLeagues", platformLeaguesHandler(ctrl, render))
 		r.Get("/", leaguesHandler(ctrl, render))
 		r.Post("/", leaguesPostHandler(ctrl, render))
 	})
 
+	r.Route("/franchises", func(r chi.Router) {
+		r.Get("/{franchiseID:\\d+}", getFranchiseHistoryHandler(ctrl, render))
+	})
+
 	r.Route("/oauth", func(r chi.Router) {
 		r.Get("/link", oauthLinkHandler(ctrl, render))
 		r.Get("/redirect", oauthRedirectHandler(ctrl, render))